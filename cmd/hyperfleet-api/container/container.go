@@ -6,6 +6,7 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/config"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/events"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
 )
@@ -21,12 +22,21 @@ type Container struct {
 	resourceLabelDao     dao.ResourceLabelDao
 	adapterStatusDao     dao.AdapterStatusDao
 	resourceConditionDao dao.ResourceConditionDao
+	resourceChangeDao    dao.ResourceChangeDao
+	resourceOutboxDao    dao.ResourceOutboxDao
+	resourceEventDao     dao.ResourceEventDao
+	resourceRevisionDao  dao.ResourceRevisionDao
+	idempotencyKeyDao    dao.IdempotencyKeyDao
 	genericDao           dao.GenericDao
 
 	resourceService      services.ResourceService
 	adapterStatusService services.AdapterStatusService
+	watchService         services.WatchService
+	idempotencyService   services.IdempotencyService
 	genericService       services.GenericService
 
+	eventDispatcher *events.Dispatcher
+
 	schemaValidator *validators.SchemaValidator
 	jwtHandler      *auth.JWTHandler
 }
@@ -1,6 +1,7 @@
 package container
 
 import (
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -35,6 +36,16 @@ func TestContainerCachesDAOsAndServices(t *testing.T) {
 	Expect(c.AdapterStatusDao()).To(BeIdenticalTo(c.AdapterStatusDao()))
 	Expect(c.ResourceConditionDao()).NotTo(BeNil())
 	Expect(c.ResourceConditionDao()).To(BeIdenticalTo(c.ResourceConditionDao()))
+	Expect(c.ResourceChangeDao()).NotTo(BeNil())
+	Expect(c.ResourceChangeDao()).To(BeIdenticalTo(c.ResourceChangeDao()))
+	Expect(c.ResourceOutboxDao()).NotTo(BeNil())
+	Expect(c.ResourceOutboxDao()).To(BeIdenticalTo(c.ResourceOutboxDao()))
+	Expect(c.ResourceEventDao()).NotTo(BeNil())
+	Expect(c.ResourceEventDao()).To(BeIdenticalTo(c.ResourceEventDao()))
+	Expect(c.ResourceRevisionDao()).NotTo(BeNil())
+	Expect(c.ResourceRevisionDao()).To(BeIdenticalTo(c.ResourceRevisionDao()))
+	Expect(c.IdempotencyKeyDao()).NotTo(BeNil())
+	Expect(c.IdempotencyKeyDao()).To(BeIdenticalTo(c.IdempotencyKeyDao()))
 	Expect(c.GenericDao()).NotTo(BeNil())
 	Expect(c.GenericDao()).To(BeIdenticalTo(c.GenericDao()))
 
@@ -44,6 +55,10 @@ func TestContainerCachesDAOsAndServices(t *testing.T) {
 	Expect(c.AdapterStatusService()).To(BeIdenticalTo(c.AdapterStatusService()))
 	Expect(c.ResourceService()).NotTo(BeNil())
 	Expect(c.ResourceService()).To(BeIdenticalTo(c.ResourceService()))
+	Expect(c.WatchService()).NotTo(BeNil())
+	Expect(c.WatchService()).To(BeIdenticalTo(c.WatchService()))
+	Expect(c.IdempotencyService()).NotTo(BeNil())
+	Expect(c.IdempotencyService()).To(BeIdenticalTo(c.IdempotencyService()))
 }
 
 func TestContainerConstructionIsLazy(t *testing.T) {
@@ -55,14 +70,37 @@ func TestContainerConstructionIsLazy(t *testing.T) {
 	Expect(c.resourceLabelDao).To(BeNil())
 	Expect(c.adapterStatusDao).To(BeNil())
 	Expect(c.resourceConditionDao).To(BeNil())
+	Expect(c.resourceChangeDao).To(BeNil())
+	Expect(c.resourceOutboxDao).To(BeNil())
+	Expect(c.resourceEventDao).To(BeNil())
+	Expect(c.resourceRevisionDao).To(BeNil())
+	Expect(c.idempotencyKeyDao).To(BeNil())
 	Expect(c.genericDao).To(BeNil())
 	Expect(c.resourceService).To(BeNil())
 	Expect(c.adapterStatusService).To(BeNil())
+	Expect(c.watchService).To(BeNil())
+	Expect(c.idempotencyService).To(BeNil())
 	Expect(c.genericService).To(BeNil())
+	Expect(c.eventDispatcher).To(BeNil())
 	Expect(c.schemaValidator).To(BeNil())
 	Expect(c.jwtHandler).To(BeNil())
 }
 
+func TestContainerEvents(t *testing.T) {
+	RegisterTestingT(t)
+
+	c := newTestContainer(t)
+	Expect(c.EventOutbox()).To(BeNil())
+
+	c = newTestContainer(t)
+	c.cfg.Events.Enabled = true
+	c.cfg.Events.Sink = config.EventSinkFile
+	c.cfg.Events.File.Path = filepath.Join(t.TempDir(), "events.jsonl")
+	Expect(c.EventOutbox()).NotTo(BeNil())
+	Expect(c.EventDispatcher()).NotTo(BeNil())
+	Expect(c.EventDispatcher()).To(BeIdenticalTo(c.EventDispatcher()))
+}
+
 func TestContainerDoesNotInitializeGlobalRegistry(t *testing.T) {
 	RegisterTestingT(t)
 
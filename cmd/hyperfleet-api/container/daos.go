@@ -32,6 +32,41 @@ func (c *Container) ResourceConditionDao() dao.ResourceConditionDao {
 	return c.resourceConditionDao
 }
 
+func (c *Container) ResourceChangeDao() dao.ResourceChangeDao {
+	if c.resourceChangeDao == nil {
+		c.resourceChangeDao = dao.NewResourceChangeDao(c.SessionFactory())
+	}
+	return c.resourceChangeDao
+}
+
+func (c *Container) ResourceOutboxDao() dao.ResourceOutboxDao {
+	if c.resourceOutboxDao == nil {
+		c.resourceOutboxDao = dao.NewResourceOutboxDao(c.SessionFactory())
+	}
+	return c.resourceOutboxDao
+}
+
+func (c *Container) ResourceEventDao() dao.ResourceEventDao {
+	if c.resourceEventDao == nil {
+		c.resourceEventDao = dao.NewResourceEventDao(c.SessionFactory())
+	}
+	return c.resourceEventDao
+}
+
+func (c *Container) ResourceRevisionDao() dao.ResourceRevisionDao {
+	if c.resourceRevisionDao == nil {
+		c.resourceRevisionDao = dao.NewResourceRevisionDao(c.SessionFactory())
+	}
+	return c.resourceRevisionDao
+}
+
+func (c *Container) IdempotencyKeyDao() dao.IdempotencyKeyDao {
+	if c.idempotencyKeyDao == nil {
+		c.idempotencyKeyDao = dao.NewIdempotencyKeyDao(c.SessionFactory())
+	}
+	return c.idempotencyKeyDao
+}
+
 func (c *Container) GenericDao() dao.GenericDao {
 	if c.genericDao == nil {
 		c.genericDao = dao.NewGenericDao(c.SessionFactory())
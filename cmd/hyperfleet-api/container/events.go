@@ -0,0 +1,25 @@
+package container
+
+import (
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/events"
+)
+
+// EventOutbox returns the outbox resource writes queue CloudEvents in, or nil when
+// events are disabled.
+func (c *Container) EventOutbox() events.Outbox {
+	if !c.cfg.Events.Enabled {
+		return nil
+	}
+	return events.NewOutbox(c.ResourceOutboxDao(), c.cfg.Events.Source)
+}
+
+func (c *Container) EventDispatcher() *events.Dispatcher {
+	if c.eventDispatcher == nil {
+		sink, err := events.NewSink(c.cfg.Events)
+		if err != nil {
+			panic("failed to create event sink: " + err.Error())
+		}
+		c.eventDispatcher = events.NewDispatcher(c.ResourceOutboxDao(), c.SessionFactory(), sink, c.cfg.Events)
+	}
+	return c.eventDispatcher
+}
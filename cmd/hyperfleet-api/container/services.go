@@ -11,7 +11,12 @@ func (c *Container) ResourceService() services.ResourceService {
 			c.ResourceLabelDao(),
 			c.AdapterStatusDao(),
 			c.ResourceConditionDao(),
+			c.ResourceChangeDao(),
+			c.ResourceEventDao(),
+			c.ResourceRevisionDao(),
+			c.EventOutbox(),
 			c.GenericService(),
+			c.SchemaValidator(),
 		)
 		if err != nil {
 			panic("failed to create resource service: " + err.Error())
@@ -23,11 +28,30 @@ func (c *Container) ResourceService() services.ResourceService {
 
 func (c *Container) AdapterStatusService() services.AdapterStatusService {
 	if c.adapterStatusService == nil {
-		c.adapterStatusService = services.NewAdapterStatusService(c.AdapterStatusDao())
+		c.adapterStatusService = services.NewAdapterStatusService(c.AdapterStatusDao(), c.GenericService())
 	}
 	return c.adapterStatusService
 }
 
+func (c *Container) WatchService() services.WatchService {
+	if c.watchService == nil {
+		c.watchService = services.NewWatchService(
+			c.ResourceChangeDao(),
+			c.SessionFactory(),
+			c.cfg.Server.Watch.Heartbeat,
+			c.cfg.Server.Watch.Retention,
+		)
+	}
+	return c.watchService
+}
+
+func (c *Container) IdempotencyService() services.IdempotencyService {
+	if c.idempotencyService == nil {
+		c.idempotencyService = services.NewIdempotencyService(c.IdempotencyKeyDao(), c.cfg.Server.Idempotency.TTL)
+	}
+	return c.idempotencyService
+}
+
 func (c *Container) GenericService() services.GenericService {
 	if c.genericService == nil {
 		c.genericService = services.NewGenericService(c.GenericDao())
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift-hyperfleet/hyperfleet-api/cmd/hyperfleet-api/migrate"
+	"github.com/openshift-hyperfleet/hyperfleet-api/cmd/hyperfleet-api/openapicmd"
 	"github.com/openshift-hyperfleet/hyperfleet-api/cmd/hyperfleet-api/servecmd"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
@@ -30,10 +31,11 @@ func main() {
 	// All subcommands under root
 	migrateCmd := migrate.NewMigrateCommand()
 	serveCmd := servecmd.NewServeCommand()
+	openapiCmd := openapicmd.NewOpenAPICommand()
 	versionCmd := newVersionCommand()
 
 	// Add subcommand(s)
-	rootCmd.AddCommand(migrateCmd, serveCmd, versionCmd)
+	rootCmd.AddCommand(migrateCmd, serveCmd, openapiCmd, versionCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		logger.WithError(ctx, err).Error("Error running command")
@@ -0,0 +1,80 @@
+package openapicmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/apidoc"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
+)
+
+// NewOpenAPICommand prints the OpenAPI document the API serves at /openapi for the given
+// configuration, for generating clients without running the server.
+func NewOpenAPICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "Print the OpenAPI document for the configured entities",
+		Long: "Print the OpenAPI document served at /api/hyperfleet/v1/openapi, built from the configured\n" +
+			"entities and the spec schemas at --server-openapi-schema-path.",
+		RunE:         runOpenAPI,
+		SilenceUsage: true,
+	}
+
+	config.AddConfigFlag(cmd)
+	config.AddServerFlags(cmd)
+	cmd.Flags().StringP("output", "o", "", "Write the document to this file instead of stdout")
+
+	return cmd
+}
+
+func runOpenAPI(cmd *cobra.Command, _ []string) error {
+	// Keep stdout for the document.
+	logger.ReconfigureGlobalLogger(&logger.LogConfig{
+		Output:    os.Stderr,
+		Component: "hyperfleet-api",
+		Version:   api.Version,
+		Level:     slog.LevelWarn,
+		Format:    logger.FormatText,
+	})
+
+	loader := config.NewConfigLoader()
+	cfg, err := loader.Load(cmd.Context(), cmd)
+	if err != nil {
+		return fmt.Errorf("load configuration: %w", err)
+	}
+
+	registry.LoadDescriptors(cfg.Entities)
+	registry.Validate()
+
+	validator, err := validators.NewSchemaValidator(cfg.Server.OpenAPISchemaPath)
+	if err != nil {
+		return fmt.Errorf("load spec schemas: %w", err)
+	}
+	doc, err := apidoc.Build(validator)
+	if err != nil {
+		return fmt.Errorf("build OpenAPI document: %w", err)
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal OpenAPI document: %w", err)
+	}
+	data = append(data, '\n')
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+	return os.WriteFile(output, data, 0o600)
+}
@@ -6,9 +6,11 @@ import (
 
 	"github.com/openshift-hyperfleet/hyperfleet-api/cmd/hyperfleet-api/server"
 	requestlogging "github.com/openshift-hyperfleet/hyperfleet-api/cmd/hyperfleet-api/server/logging"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/apidoc"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/auth"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/config"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/handlers"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/middleware"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
@@ -26,7 +28,10 @@ func BuildAPIServer(
 	cfg *config.ApplicationConfig,
 	resourceService services.ResourceService,
 	adapterStatusService services.AdapterStatusService,
+	watchService services.WatchService,
+	idempotencyService services.IdempotencyService,
 	schemaValidator *validators.SchemaValidator,
+	openapiHandler *handlers.OpenAPIHandler,
 	jwtHandler *auth.JWTHandler,
 	sessionFactory db.SessionFactory,
 ) (*server.APIServer, error) {
@@ -67,11 +72,13 @@ func BuildAPIServer(
 	}
 
 	registrars := []server.RouteRegistrar{
-		server.NewEntityRouteRegistrar(resourceService, adapterStatusService, schemaValidator),
+		server.NewEntityRouteRegistrar(
+			resourceService, adapterStatusService, watchService, idempotencyService, schemaValidator,
+		),
 	}
 
 	router, err := server.NewRouterFromConfig(
-		mainMiddleware, apiMiddleware, protectedAPIMiddleware, authMiddleware, registrars,
+		openapiHandler, mainMiddleware, apiMiddleware, protectedAPIMiddleware, authMiddleware, registrars,
 	)
 	if err != nil {
 		return nil, err
@@ -79,3 +86,13 @@ func BuildAPIServer(
 
 	return server.NewAPIServer(cfg.Server, router), nil
 }
+
+// NewOpenAPIHandler builds the API document from the entity registry and the spec schemas
+// loaded by schemaValidator, which may be nil, and returns the handler that serves it.
+func NewOpenAPIHandler(schemaValidator *validators.SchemaValidator) (*handlers.OpenAPIHandler, error) {
+	doc, err := apidoc.Build(schemaValidator)
+	if err != nil {
+		return nil, fmt.Errorf("build OpenAPI document: %w", err)
+	}
+	return handlers.NewOpenAPIHandler(doc)
+}
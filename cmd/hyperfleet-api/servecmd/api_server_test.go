@@ -37,7 +37,9 @@ func TestBuildAPIServer_TenantMiddlewareWiredWhenEnabled(t *testing.T) {
 		},
 	}
 
-	apiServer, err := BuildAPIServer(cfg, nil, nil, nil, nil, nil)
+	openapiHandler, err := NewOpenAPIHandler(nil)
+	Expect(err).NotTo(HaveOccurred())
+	apiServer, err := BuildAPIServer(cfg, nil, nil, nil, nil, nil, openapiHandler, nil, nil)
 	Expect(err).NotTo(HaveOccurred())
 
 	listener, err := apiServer.Listen()
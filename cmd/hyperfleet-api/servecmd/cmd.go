@@ -127,11 +127,36 @@ func runServe(cmd *cobra.Command, args []string) (runErr error) {
 		logger.WithError(ctx, collectorErr).Error("Failed to register reconciliation collector")
 	}
 
+	// Registered before the API drain so it stops after it. Undelivered events stay in the
+	// outbox for the next dispatcher to pick up.
+	if cfg.Events.Enabled {
+		dispatcher := ctr.EventDispatcher()
+		dispatchCtx, stopDispatch := context.WithCancel(context.Background())
+		dispatchDone := make(chan struct{})
+		go func() {
+			defer close(dispatchDone)
+			dispatcher.Run(dispatchCtx)
+		}()
+		c.Add(func() error {
+			stopDispatch()
+			<-dispatchDone
+			return nil
+		})
+		logger.With(ctx, "sink", cfg.Events.Sink).Info("Event dispatcher started")
+	}
+
+	openapiHandler, err := NewOpenAPIHandler(ctr.SchemaValidator())
+	if err != nil {
+		return err
+	}
 	apiServer, err := BuildAPIServer(
 		cfg,
 		ctr.ResourceService(),
 		ctr.AdapterStatusService(),
+		ctr.WatchService(),
+		ctr.IdempotencyService(),
 		ctr.SchemaValidator(),
+		openapiHandler,
 		ctr.JWTHandler(),
 		ctr.SessionFactory(),
 	)
@@ -142,6 +167,34 @@ func runServe(cmd *cobra.Command, args []string) (runErr error) {
 	// draining. addDrain uses Shutdown with a budget, falling back to Close.
 	addDrain(c, apiServer, cfg.Health.ShutdownTimeout)
 
+	// Registered after the API drain so it runs first: stopping the watch service ends
+	// open watch streams, which would otherwise hold the drain until its budget expires.
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	go ctr.WatchService().Run(watchCtx)
+	c.Add(func() error {
+		stopWatch()
+		return nil
+	})
+
+	pruneCtx, stopPrune := context.WithCancel(context.Background())
+	go ctr.IdempotencyService().Run(pruneCtx)
+	c.Add(func() error {
+		stopPrune()
+		return nil
+	})
+
+	reloads := make(chan os.Signal, 1)
+	signal.Notify(reloads, syscall.SIGHUP)
+	reloadCtx, stopReloads := context.WithCancel(context.Background())
+	go reloadDescriptorsOnSignal(
+		reloadCtx, cmd, reloads, ctr.ResourceService(), ctr.SchemaValidator(), openapiHandler,
+	)
+	c.Add(func() error {
+		signal.Stop(reloads)
+		stopReloads()
+		return nil
+	})
+
 	metricsServer := server.NewMetricsServer(cfg.Metrics)
 	addDrain(c, metricsServer, metricsDrainTimeout)
 
@@ -0,0 +1,57 @@
+package servecmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/apidoc"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/handlers"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
+)
+
+// reloadDescriptorsOnSignal reloads the configuration on every signal received from
+// reloads (SIGHUP) and swaps its entity descriptors into the running API, so that changes
+// to condition rules, required adapters, name bounds, or references need no rollout.
+// A change the running API cannot take, such as one that would add or remove routes, is
+// rejected and logged, and the descriptors in use are kept. Once descriptors are swapped
+// in, the document served by openapiHandler is rebuilt from them, as it shows references.
+func reloadDescriptorsOnSignal(
+	ctx context.Context,
+	cmd *cobra.Command,
+	reloads <-chan os.Signal,
+	resourceService services.ResourceService,
+	schemaValidator *validators.SchemaValidator,
+	openapiHandler *handlers.OpenAPIHandler,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reloads:
+		}
+
+		cfg, err := config.NewConfigLoader().Load(ctx, cmd)
+		if err != nil {
+			logger.WithError(ctx, err).Warn("Failed to load configuration, keeping entity descriptors in use")
+			continue
+		}
+		if err = resourceService.ReloadDescriptors(cfg.Entities); err != nil {
+			logger.WithError(ctx, err).Warn("Rejected entity descriptor reload, keeping entity descriptors in use")
+			continue
+		}
+		logger.With(ctx, "kinds", len(cfg.Entities)).Info("Reloaded entity descriptors")
+
+		doc, err := apidoc.Build(schemaValidator)
+		if err == nil {
+			err = openapiHandler.SetDocument(doc)
+		}
+		if err != nil {
+			logger.WithError(ctx, err).Warn("Failed to rebuild OpenAPI document, serving the previous one")
+		}
+	}
+}
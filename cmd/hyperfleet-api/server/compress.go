@@ -38,6 +38,21 @@ func (w *gzipResponseWriter) Write(b []byte) (int, error) {
 	return w.writer.Write(b)
 }
 
+// FlushError pushes buffered compressed data to the client, so streaming
+// responses such as watch streams are not held back by the gzip buffer.
+func (w *gzipResponseWriter) FlushError() error {
+	w.ensureHeaders()
+	if err := w.writer.Flush(); err != nil {
+		return err
+	}
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying ResponseWriter.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // CompressMiddleware gzip-encodes the response body when the client indicates
 // support for it via the Accept-Encoding header.
 func CompressMiddleware(next http.Handler) http.Handler {
@@ -217,6 +217,12 @@ func (w *metricsResponseWrapper) WriteHeader(code int) {
 	w.wrapped.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the wrapped writer, so streaming
+// responses can still flush.
+func (w *metricsResponseWrapper) Unwrap() http.ResponseWriter {
+	return w.wrapped
+}
+
 func init() {
 	// Register the metrics:
 	prometheus.MustRegister(requestCountMetric)
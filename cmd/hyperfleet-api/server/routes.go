@@ -15,7 +15,9 @@ type RouteRegistrar struct {
 	Name     string
 }
 
+// NewRouterFromConfig builds the router. openapiHandler serves the API document at /openapi.
 func NewRouterFromConfig(
+	openapiHandler *handlers.OpenAPIHandler,
 	mainMiddleware []Middleware,
 	apiMiddleware []Middleware,
 	protectedAPIMiddleware []Middleware,
@@ -41,10 +43,6 @@ func NewRouterFromConfig(
 	}
 
 	//  /api/hyperfleet/v1/openapi
-	openapiHandler, err := handlers.NewOpenAPIHandler()
-	if err != nil {
-		return nil, fmt.Errorf("unable to create OpenAPI handler: %w", err)
-	}
 	apiV1Router.HandleFunc("GET /openapi.html", openapiHandler.GetOpenAPIUI)
 	apiV1Router.HandleFunc("GET /openapi", openapiHandler.GetOpenAPI)
 
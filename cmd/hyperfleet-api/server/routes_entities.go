@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/handlers"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
@@ -14,12 +15,16 @@ import (
 func NewEntityRouteRegistrar(
 	resourceService services.ResourceService,
 	adapterStatusService services.AdapterStatusService,
+	watchService services.WatchService,
+	idempotencyService services.IdempotencyService,
 	schemaValidator *validators.SchemaValidator,
 ) RouteRegistrar {
 	return RouteRegistrar{
 		Name: "entities",
 		Register: func(router *Router) error {
-			return RegisterEntityRoutes(router, resourceService, adapterStatusService, schemaValidator)
+			return RegisterEntityRoutes(
+				router, resourceService, adapterStatusService, watchService, idempotencyService, schemaValidator,
+			)
 		},
 	}
 }
@@ -31,19 +36,38 @@ func NewEntityRouteRegistrar(
 // Top-level entities get routes at /{plural}. Child entities (ParentKind != "")
 // get nested routes under /{parent_plural}/{parent_id}/{plural} plus flat
 // read/update/delete access at /{plural} (POST rejected - needs parent context).
-// All entities get /{id}/statuses sub-routes for adapter status reporting.
+// Deeper kinds are also nested under every longer ancestor chain, e.g.
+// /clusters/{ancestor_2_id}/nodepools/{parent_id}/machines, and can be listed
+// under any ancestor above their parent at /{ancestor_plural}/{ancestor_id}/{plural}.
+// Every list, nested or not, has an /{plural}:aggregate counterpart counting the
+// matching resources per group. All entities get /{id}/statuses sub-routes for
+// adapter status reporting, /{id}/history for their audit log, /{id}/revisions plus
+// /{id}/rollback for their spec history, and /{id}/referencers listing the resources
+// referencing them.
+// Creates and force-deletes honour the Idempotency-Key header, and every write,
+// here and on /resources, can be dry-run with ?dryRun=true.
 //
-// The kind-agnostic /resources root endpoint is registered separately.
+// The kind-agnostic /resources root endpoint, including /resources:batch for
+// multi-operation transactions, /resources:aggregate for counts across kinds and
+// /resources/{id}/graph for a resource's ownership and reference graph, and the /kinds
+// discovery endpoint describing the registered kinds are registered separately.
 func RegisterEntityRoutes(
 	router *Router,
 	resourceService services.ResourceService,
 	adapterStatusService services.AdapterStatusService,
+	watchService services.WatchService,
+	idempotencyService services.IdempotencyService,
 	schemaValidator *validators.SchemaValidator,
 ) error {
-	if err := registerPerEntityRoutes(router, resourceService, adapterStatusService); err != nil {
+	if err := registerPerEntityRoutes(
+		router, resourceService, adapterStatusService, watchService, idempotencyService, schemaValidator,
+	); err != nil {
 		return fmt.Errorf("register entity routes: %w", err)
 	}
-	registerRootResourceRoutes(router, resourceService, adapterStatusService, schemaValidator)
+	registerRootResourceRoutes(
+		router, resourceService, adapterStatusService, watchService, idempotencyService, schemaValidator,
+	)
+	registerKindRoutes(router, schemaValidator)
 	return nil
 }
 
@@ -51,6 +75,9 @@ func registerPerEntityRoutes(
 	router *Router,
 	resourceService services.ResourceService,
 	adapterStatusService services.AdapterStatusService,
+	watchService services.WatchService,
+	idempotencyService services.IdempotencyService,
+	schemaValidator *validators.SchemaValidator,
 ) error {
 	descriptors := registry.All()
 	slices.SortFunc(descriptors, func(a, b registry.EntityDescriptor) int {
@@ -58,53 +85,107 @@ func registerPerEntityRoutes(
 	})
 
 	for _, descriptor := range descriptors {
-		if descriptor.Plural == "resources" {
+		if descriptor.Plural == "resources" || descriptor.Plural == "kinds" {
 			return fmt.Errorf(
-				"entity kind %q uses reserved plural %q which would shadow /resources root endpoint",
-				descriptor.Kind, descriptor.Plural,
+				"entity kind %q uses reserved plural %q which would shadow /%s root endpoint",
+				descriptor.Kind, descriptor.Plural, descriptor.Plural,
 			)
 		}
-		h := handlers.NewResourceHandler(descriptor, resourceService)
+		h := handlers.NewResourceHandler(descriptor, resourceService, watchService, schemaValidator)
 		sh := handlers.NewResourceStatusHandler(descriptor, resourceService, adapterStatusService)
 
 		if descriptor.ParentKind != "" {
-			parent := registry.MustGet(descriptor.ParentKind)
-			registerEntityResourceRoutes(router, "/"+parent.Plural+"/{parent_id}/"+descriptor.Plural, h, sh)
+			// AncestorsOf skips an unregistered parent; fail loudly instead.
+			registry.MustGet(descriptor.ParentKind)
+		}
+		// Nested routes under every ancestor chain ending at the parent: the parent
+		// alone, then the grandparent and parent, up to a top-level kind.
+		ancestors := registry.AncestorsOf(descriptor.Kind)
+		for depth := 1; depth <= len(ancestors); depth++ {
+			registerEntityResourceRoutes(
+				router, ancestorPrefix(ancestors[:depth])+"/"+descriptor.Plural, h, sh, idempotencyService,
+			)
+		}
+		// Descendant listings under each ancestor above the parent.
+		for i := 1; i < len(ancestors); i++ {
+			router.HandleFunc(
+				"GET /"+ancestors[i].Plural+"/{ancestor_id}/"+descriptor.Plural, h.ListDescendants(ancestors[i].Kind),
+			)
 		}
-		registerEntityResourceRoutes(router, "/"+descriptor.Plural, h, sh)
+		registerEntityResourceRoutes(router, "/"+descriptor.Plural, h, sh, idempotencyService)
 	}
 	return nil
 }
 
+// ancestorPrefix builds the path addressing a resource's ancestors, outermost first,
+// from the ancestors nearest first; each ID wildcard is named by AncestorPathValue.
+func ancestorPrefix(ancestors []registry.EntityDescriptor) string {
+	var b strings.Builder
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		b.WriteString("/" + ancestors[i].Plural + "/{" + handlers.AncestorPathValue(i+1) + "}")
+	}
+	return b.String()
+}
+
 func registerRootResourceRoutes(
 	router *Router,
 	resourceService services.ResourceService,
 	adapterStatusService services.AdapterStatusService,
+	watchService services.WatchService,
+	idempotencyService services.IdempotencyService,
 	schemaValidator *validators.SchemaValidator,
 ) {
-	rootHandler := handlers.NewRootResourceHandler(resourceService, adapterStatusService, schemaValidator)
+	rootHandler := handlers.NewRootResourceHandler(
+		resourceService, adapterStatusService, watchService, schemaValidator,
+	)
 	prefix := "/resources"
 	router.HandleFunc("GET "+prefix, rootHandler.List)
-	router.HandleFunc("POST "+prefix, rootHandler.Create)
+	router.HandleFunc("GET "+prefix+":aggregate", rootHandler.Aggregate)
+	router.HandleFunc("POST "+prefix, handlers.DryRunnable(handlers.Idempotent(idempotencyService, rootHandler.Create)))
+	router.HandleFunc("POST "+prefix+":batch", handlers.DryRunnable(rootHandler.Batch))
 	router.HandleFunc("GET "+prefix+"/{id}", rootHandler.Get)
-	router.HandleFunc("PATCH "+prefix+"/{id}", rootHandler.Patch)
-	router.HandleFunc("DELETE "+prefix+"/{id}", rootHandler.Delete)
-	router.HandleFunc("POST "+prefix+"/{id}/force-delete", rootHandler.ForceDelete)
+	router.HandleFunc("PATCH "+prefix+"/{id}", handlers.DryRunnable(rootHandler.Patch))
+	router.HandleFunc("DELETE "+prefix+"/{id}", handlers.DryRunnable(rootHandler.Delete))
+	router.HandleFunc(
+		"POST "+prefix+"/{id}/force-delete",
+		handlers.DryRunnable(handlers.Idempotent(idempotencyService, rootHandler.ForceDelete)),
+	)
+	router.HandleFunc("GET "+prefix+"/{id}/history", rootHandler.History)
+	router.HandleFunc("GET "+prefix+"/{id}/revisions", rootHandler.Revisions)
+	router.HandleFunc("GET "+prefix+"/{id}/revisions/{generation}", rootHandler.Revision)
+	router.HandleFunc("POST "+prefix+"/{id}/rollback", handlers.DryRunnable(rootHandler.Rollback))
+	router.HandleFunc("GET "+prefix+"/{id}/referencers", rootHandler.Referencers)
+	router.HandleFunc("GET "+prefix+"/{id}/graph", rootHandler.Graph)
 	router.HandleFunc("GET "+prefix+"/{id}/statuses", rootHandler.ListStatuses)
-	router.HandleFunc("PUT "+prefix+"/{id}/statuses", rootHandler.CreateStatus)
+	router.HandleFunc("PUT "+prefix+"/{id}/statuses", handlers.DryRunnable(rootHandler.CreateStatus))
 }
 
 func registerEntityResourceRoutes(
 	router *Router, pathSuffix string,
 	h *handlers.ResourceHandler, sh *handlers.ResourceStatusHandler,
+	idempotencyService services.IdempotencyService,
 ) {
 	prefix := pathSuffix
 	router.HandleFunc("GET "+prefix, h.List)
-	router.HandleFunc("POST "+prefix, h.Create)
+	router.HandleFunc("GET "+prefix+":aggregate", h.Aggregate)
+	router.HandleFunc("POST "+prefix, handlers.DryRunnable(handlers.Idempotent(idempotencyService, h.Create)))
 	router.HandleFunc("GET "+prefix+"/{id}", h.Get)
-	router.HandleFunc("PATCH "+prefix+"/{id}", h.Patch)
-	router.HandleFunc("DELETE "+prefix+"/{id}", h.Delete)
-	router.HandleFunc("POST "+prefix+"/{id}/force-delete", h.ForceDelete)
+	router.HandleFunc("PATCH "+prefix+"/{id}", handlers.DryRunnable(h.Patch))
+	router.HandleFunc("DELETE "+prefix+"/{id}", handlers.DryRunnable(h.Delete))
+	router.HandleFunc(
+		"POST "+prefix+"/{id}/force-delete", handlers.DryRunnable(handlers.Idempotent(idempotencyService, h.ForceDelete)),
+	)
+	router.HandleFunc("GET "+prefix+"/{id}/history", h.History)
+	router.HandleFunc("GET "+prefix+"/{id}/revisions", h.Revisions)
+	router.HandleFunc("GET "+prefix+"/{id}/revisions/{generation}", h.Revision)
+	router.HandleFunc("POST "+prefix+"/{id}/rollback", handlers.DryRunnable(h.Rollback))
+	router.HandleFunc("GET "+prefix+"/{id}/referencers", h.Referencers)
 	router.HandleFunc("GET "+prefix+"/{id}/statuses", sh.List)
-	router.HandleFunc("PUT "+prefix+"/{id}/statuses", sh.Create)
+	router.HandleFunc("PUT "+prefix+"/{id}/statuses", handlers.DryRunnable(sh.Create))
+}
+
+func registerKindRoutes(router *Router, schemaValidator *validators.SchemaValidator) {
+	h := handlers.NewKindHandler(schemaValidator)
+	router.HandleFunc("GET /kinds", h.List)
+	router.HandleFunc("GET /kinds/{kind}", h.Get)
 }
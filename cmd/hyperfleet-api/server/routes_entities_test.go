@@ -20,20 +20,34 @@ func TestRegisterEntityRoutes_TopLevelEntity(t *testing.T) {
 	})
 
 	apiV1 := NewRouter().Group(apiV1BasePath)
-	RegisterEntityRoutes(apiV1, nil, nil, nil)
+	RegisterEntityRoutes(apiV1, nil, nil, nil, nil, nil)
 
 	id := uuid.NewString()
 	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/channels")
 	assertRouteMatches(t, apiV1, "POST", "/api/hyperfleet/v1/channels")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/channels:aggregate")
 	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/channels/"+id)
 	assertRouteMatches(t, apiV1, "PATCH", "/api/hyperfleet/v1/channels/"+id)
 	assertRouteMatches(t, apiV1, "DELETE", "/api/hyperfleet/v1/channels/"+id)
 	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/channels/"+id+"/statuses")
 	assertRouteMatches(t, apiV1, "PUT", "/api/hyperfleet/v1/channels/"+id+"/statuses")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/channels/"+id+"/history")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/channels/"+id+"/revisions")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/channels/"+id+"/revisions/3")
+	assertRouteMatches(t, apiV1, "POST", "/api/hyperfleet/v1/channels/"+id+"/rollback")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/channels/"+id+"/referencers")
 
 	// Root /resources routes should also have statuses
 	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/resources/"+id+"/statuses")
 	assertRouteMatches(t, apiV1, "PUT", "/api/hyperfleet/v1/resources/"+id+"/statuses")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/resources/"+id+"/history")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/resources/"+id+"/revisions")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/resources/"+id+"/revisions/3")
+	assertRouteMatches(t, apiV1, "POST", "/api/hyperfleet/v1/resources/"+id+"/rollback")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/resources/"+id+"/referencers")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/resources/"+id+"/graph")
+	assertRouteMatches(t, apiV1, "POST", "/api/hyperfleet/v1/resources:batch")
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/resources:aggregate")
 }
 
 func TestRegisterEntityRoutes_ChildEntity(t *testing.T) {
@@ -47,7 +61,7 @@ func TestRegisterEntityRoutes_ChildEntity(t *testing.T) {
 	})
 
 	apiV1 := NewRouter().Group(apiV1BasePath)
-	RegisterEntityRoutes(apiV1, nil, nil, nil)
+	RegisterEntityRoutes(apiV1, nil, nil, nil, nil, nil)
 
 	parentID := uuid.NewString()
 	childID := uuid.NewString()
@@ -55,11 +69,17 @@ func TestRegisterEntityRoutes_ChildEntity(t *testing.T) {
 
 	assertRouteMatches(t, apiV1, "GET", nested)
 	assertRouteMatches(t, apiV1, "POST", nested)
+	assertRouteMatches(t, apiV1, "GET", nested+":aggregate")
 	assertRouteMatches(t, apiV1, "GET", nested+"/"+childID)
 	assertRouteMatches(t, apiV1, "PATCH", nested+"/"+childID)
 	assertRouteMatches(t, apiV1, "DELETE", nested+"/"+childID)
 	assertRouteMatches(t, apiV1, "GET", nested+"/"+childID+"/statuses")
 	assertRouteMatches(t, apiV1, "PUT", nested+"/"+childID+"/statuses")
+	assertRouteMatches(t, apiV1, "GET", nested+"/"+childID+"/history")
+	assertRouteMatches(t, apiV1, "GET", nested+"/"+childID+"/revisions")
+	assertRouteMatches(t, apiV1, "GET", nested+"/"+childID+"/revisions/3")
+	assertRouteMatches(t, apiV1, "POST", nested+"/"+childID+"/rollback")
+	assertRouteMatches(t, apiV1, "GET", nested+"/"+childID+"/referencers")
 
 	flat := "/api/hyperfleet/v1/versions"
 	assertRouteMatches(t, apiV1, "GET", flat)
@@ -69,6 +89,58 @@ func TestRegisterEntityRoutes_ChildEntity(t *testing.T) {
 	assertRouteMatches(t, apiV1, "DELETE", flat+"/"+childID)
 	assertRouteMatches(t, apiV1, "GET", flat+"/"+childID+"/statuses")
 	assertRouteMatches(t, apiV1, "PUT", flat+"/"+childID+"/statuses")
+	assertRouteMatches(t, apiV1, "GET", flat+"/"+childID+"/history")
+	assertRouteMatches(t, apiV1, "GET", flat+"/"+childID+"/revisions")
+	assertRouteMatches(t, apiV1, "GET", flat+"/"+childID+"/revisions/3")
+	assertRouteMatches(t, apiV1, "POST", flat+"/"+childID+"/rollback")
+	assertRouteMatches(t, apiV1, "GET", flat+"/"+childID+"/referencers")
+}
+
+func TestRegisterEntityRoutes_DeepHierarchy(t *testing.T) {
+	RegisterTestingT(t)
+	registry.Reset()
+	registry.Register(registry.EntityDescriptor{Kind: "Cluster", Plural: "clusters"})
+	registry.Register(registry.EntityDescriptor{Kind: "NodePool", Plural: "nodepools", ParentKind: "Cluster"})
+	registry.Register(registry.EntityDescriptor{Kind: "Machine", Plural: "machines", ParentKind: "NodePool"})
+
+	apiV1 := NewRouter().Group(apiV1BasePath)
+	RegisterEntityRoutes(apiV1, nil, nil, nil, nil, nil)
+
+	clusterID := uuid.NewString()
+	nodePoolID := uuid.NewString()
+	machineID := uuid.NewString()
+
+	for _, collection := range []string{
+		"/api/hyperfleet/v1/clusters/" + clusterID + "/nodepools/" + nodePoolID + "/machines",
+		"/api/hyperfleet/v1/nodepools/" + nodePoolID + "/machines",
+		"/api/hyperfleet/v1/machines",
+	} {
+		assertRouteMatches(t, apiV1, "GET", collection)
+		assertRouteMatches(t, apiV1, "POST", collection)
+		assertRouteMatches(t, apiV1, "GET", collection+"/"+machineID)
+		assertRouteMatches(t, apiV1, "PATCH", collection+"/"+machineID)
+		assertRouteMatches(t, apiV1, "DELETE", collection+"/"+machineID)
+		assertRouteMatches(t, apiV1, "POST", collection+"/"+machineID+"/force-delete")
+		assertRouteMatches(t, apiV1, "GET", collection+"/"+machineID+"/statuses")
+		assertRouteMatches(t, apiV1, "PUT", collection+"/"+machineID+"/statuses")
+		assertRouteMatches(t, apiV1, "GET", collection+"/"+machineID+"/history")
+	}
+
+	// Machines listed under their grandparent across its nodepools.
+	assertRouteMatches(t, apiV1, "GET", "/api/hyperfleet/v1/clusters/"+clusterID+"/machines")
+}
+
+func TestAncestorPrefix(t *testing.T) {
+	RegisterTestingT(t)
+	ancestors := []registry.EntityDescriptor{
+		{Kind: "NodePool", Plural: "nodepools"},
+		{Kind: "Cluster", Plural: "clusters"},
+		{Kind: "Region", Plural: "regions"},
+	}
+	Expect(ancestorPrefix(ancestors[:1])).To(Equal("/nodepools/{parent_id}"))
+	Expect(ancestorPrefix(ancestors)).To(Equal(
+		"/regions/{ancestor_3_id}/clusters/{ancestor_2_id}/nodepools/{parent_id}",
+	))
 }
 
 func TestRegisterEntityRoutes_UnresolvableParentKind_Panics(t *testing.T) {
@@ -83,7 +155,7 @@ func TestRegisterEntityRoutes_UnresolvableParentKind_Panics(t *testing.T) {
 	apiV1 := NewRouter().Group(apiV1BasePath)
 
 	Expect(func() {
-		RegisterEntityRoutes(apiV1, nil, nil, nil)
+		RegisterEntityRoutes(apiV1, nil, nil, nil, nil, nil)
 	}).To(PanicWith(ContainSubstring("not registered")))
 }
 
@@ -94,7 +166,7 @@ func TestRegisterEntityRoutes_EmptyRegistry(t *testing.T) {
 	apiV1 := NewRouter().Group(apiV1BasePath)
 
 	Expect(func() {
-		RegisterEntityRoutes(apiV1, nil, nil, nil)
+		RegisterEntityRoutes(apiV1, nil, nil, nil, nil, nil)
 	}).ToNot(Panic())
 }
 
@@ -5,7 +5,10 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/handlers"
 )
 
 // TestNewRouterFromConfig_PublicVsProtectedMiddleware guards the middleware layering:
@@ -54,7 +57,13 @@ func TestNewRouterFromConfig_PublicVsProtectedMiddleware(t *testing.T) {
 		},
 	}
 
+	openapiHandler, err := handlers.NewOpenAPIHandler(
+		&openapi3.T{OpenAPI: "3.0.0", Info: &openapi3.Info{Title: "test", Version: "1"}, Paths: openapi3.NewPaths()},
+	)
+	Expect(err).NotTo(HaveOccurred())
+
 	router, err := NewRouterFromConfig(
+		openapiHandler,
 		nil,
 		[]Middleware{countingAPIMiddleware},
 		[]Middleware{countingProtectedAPIMiddleware},
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// IdempotencyKey records a write made under an Idempotency-Key header, so that a retry of
+// the same request replays the original response. A StatusCode of 0 marks a key claimed
+// by a request that has not finished; its row is only visible once that request commits.
+type IdempotencyKey struct {
+	CreatedTime     time.Time      `json:"created_time"`
+	ExpiresTime     time.Time      `json:"expires_time"`
+	Owner           string         `json:"owner" gorm:"primaryKey;size:255"`
+	Key             string         `json:"key" gorm:"primaryKey;size:255"`
+	RequestHash     string         `json:"request_hash" gorm:"size:64;not null"`
+	ResponseHeaders datatypes.JSON `json:"response_headers" gorm:"type:jsonb"`
+	ResponseBody    []byte         `json:"-"`
+	StatusCode      int            `json:"status_code"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// Completed reports whether the request that claimed the key has recorded its response.
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
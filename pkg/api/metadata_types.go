@@ -40,7 +40,11 @@ type Meta struct {
 
 // PagingMeta List Paging metadata
 type PagingMeta struct {
-	Page  int64
-	Size  int64
-	Total int64
+	// Continue resumes the list after this page. It is empty on the last page.
+	Continue string
+	Page     int64
+	Size     int64
+	Total    int64
+	// Uncounted is set when the caller skipped counting, so Total is not known.
+	Uncounted bool
 }
@@ -152,3 +152,19 @@ func PresentAdapterStatus(adapterStatus *api.AdapterStatus) (openapi.AdapterStat
 		ObservedGeneration: adapterStatus.ObservedGeneration,
 	}, nil
 }
+
+// PresentAdapterStatusList wraps presented adapter statuses and their paging metadata in
+// a list page.
+func PresentAdapterStatusList(items []openapi.AdapterStatus, paging *api.PagingMeta) openapi.AdapterStatusList {
+	list := openapi.AdapterStatusList{
+		Items:    items,
+		Page:     int32(paging.Page), //nolint:gosec
+		Size:     int32(paging.Size), //nolint:gosec
+		Continue: presentContinue(paging),
+	}
+	if !paging.Uncounted {
+		total := int32(paging.Total) //nolint:gosec
+		list.Total = &total
+	}
+	return list
+}
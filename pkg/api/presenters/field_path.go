@@ -0,0 +1,259 @@
+package presenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// fieldSelector is an element filter in a fields path, such as the [type=Reconciled] of
+// status.conditions[type=Reconciled].status. rest is the path within matching elements,
+// e.g. ".status", or empty to keep them whole.
+type fieldSelector struct {
+	field string
+	value string
+	rest  string
+}
+
+// parseSelector parses the selector a requested field applies to the slice at prefix.
+func parseSelector(key, prefix string) (fieldSelector, bool) {
+	s, ok := strings.CutPrefix(key, prefix+"[")
+	if !ok {
+		return fieldSelector{}, false
+	}
+	inner, rest, ok := strings.Cut(s, "]")
+	if !ok {
+		return fieldSelector{}, false
+	}
+	field, value, ok := strings.Cut(inner, "=")
+	if !ok || field == "" || value == "" {
+		return fieldSelector{}, false
+	}
+	if rest != "" && (!strings.HasPrefix(rest, ".") || len(rest) == 1) {
+		return fieldSelector{}, false
+	}
+	return fieldSelector{field: field, value: value, rest: rest}, true
+}
+
+// validateSelectors checks the selectors requested on the slice at prefix, whose
+// elements are elemType structs, and removes them from in.
+func validateSelectors(in map[string]bool, prefix string, elemType reflect.Type) *errors.ServiceError {
+	for k := range in {
+		if !strings.HasPrefix(k, prefix+"[") {
+			continue
+		}
+		selector, ok := parseSelector(k, prefix)
+		if !ok {
+			return errors.Validation("Invalid field `%s`: expected %s[<field>=<value>]", k, prefix)
+		}
+		if _, found := jsonField(elemType, selector.field); !found {
+			return errors.Validation("The following field(s) doesn't exist in `%s`: %s", elemType.Name(), k)
+		}
+		if selector.rest != "" {
+			elemValue := reflect.New(elemType).Elem().Interface()
+			if err := validate(elemValue, map[string]bool{prefix + selector.rest: true}, prefix); err != nil {
+				return errors.Validation("The following field(s) doesn't exist in `%s`: %s", elemType.Name(), k)
+			}
+		}
+		delete(in, k)
+	}
+	return nil
+}
+
+// requestedSelectors returns the valid selectors requested on the slice at prefix.
+func requestedSelectors(in map[string]bool, prefix string) []fieldSelector {
+	var selectors []fieldSelector
+	for k := range in {
+		if selector, ok := parseSelector(k, prefix); ok {
+			selectors = append(selectors, selector)
+		}
+	}
+	return selectors
+}
+
+// selectElement adds to in the fields that the selectors matching elem request within
+// it, so that structToMap projects them.
+func selectElement(elem reflect.Value, in map[string]bool, prefix string, selectors []fieldSelector) map[string]bool {
+	elem = reflect.Indirect(elem)
+	var selected map[string]bool
+	for _, selector := range selectors {
+		index, ok := jsonField(elem.Type(), selector.field)
+		if !ok {
+			continue
+		}
+		value := elem.Field(index)
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		if fmt.Sprint(value.Interface()) != selector.value {
+			continue
+		}
+		if selected == nil {
+			selected = make(map[string]bool, len(in)+1)
+			for k, v := range in {
+				selected[k] = v
+			}
+		}
+		if selector.rest == "" {
+			selected[prefix+".*"] = true
+		} else {
+			selected[prefix+selector.rest] = true
+		}
+	}
+	if selected == nil {
+		return in
+	}
+	return selected
+}
+
+// jsonField returns the index of the struct field whose JSON name is name.
+func jsonField(t reflect.Type, name string) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if tag != "" && tag != "-" && strings.Split(tag, ",")[0] == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func isMapType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Map
+}
+
+// removeMapPaths removes from in the dotted paths requested within the map field at
+// prefix. Paths with an empty segment are left to be reported as unknown.
+func removeMapPaths(in map[string]bool, prefix string) {
+	for k := range in {
+		if path, ok := strings.CutPrefix(k, prefix+"."); ok && !slices.Contains(strings.Split(path, "."), "") {
+			delete(in, k)
+		}
+	}
+}
+
+// requestedPaths returns the dotted paths requested within the field at prefix.
+func requestedPaths(in map[string]bool, prefix string) []string {
+	var paths []string
+	for k := range in {
+		if path, ok := strings.CutPrefix(k, prefix+"."); ok && path != "" {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// projectMap returns the parts of a map value, such as spec or labels, at the given
+// dotted paths. A path descends into nested objects and applies to every element of an
+// array; a key containing dots, such as the label app.kubernetes.io/name, is matched
+// whole. It reports false when none of the paths exists.
+func projectMap(field interface{}, paths []string) (interface{}, bool) {
+	if len(paths) == 0 {
+		return nil, false
+	}
+	data, err := json.Marshal(field)
+	if err != nil {
+		return nil, false
+	}
+	var value interface{}
+	if unmarshalErr := json.Unmarshal(data, &value); unmarshalErr != nil {
+		return nil, false
+	}
+
+	var result interface{}
+	for _, path := range paths {
+		if picked, ok := pickPath(value, strings.Split(path, ".")); ok {
+			result = mergeProjections(result, picked)
+		}
+	}
+	if result == nil {
+		return nil, false
+	}
+	return compactProjection(result), true
+}
+
+// pickPath returns value reduced to the given path segments. Array elements lacking the
+// path are nil, so that picks of the same array merge element by element.
+func pickPath(value interface{}, segments []string) (interface{}, bool) {
+	if len(segments) == 0 {
+		return value, true
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// prefer the longest key, so that keys containing dots match whole
+		for i := len(segments); i > 0; i-- {
+			key := strings.Join(segments[:i], ".")
+			child, ok := v[key]
+			if !ok {
+				continue
+			}
+			if picked, found := pickPath(child, segments[i:]); found {
+				return map[string]interface{}{key: picked}, true
+			}
+		}
+	case []interface{}:
+		picked := make([]interface{}, len(v))
+		var found bool
+		for i, elem := range v {
+			if p, ok := pickPath(elem, segments); ok {
+				picked[i] = p
+				found = true
+			}
+		}
+		return picked, found
+	}
+	return nil, false
+}
+
+func mergeProjections(a, b interface{}) interface{} {
+	if b == nil {
+		return a
+	}
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			for k, v := range bv {
+				av[k] = mergeProjections(av[k], v)
+			}
+			return av
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok && len(av) == len(bv) {
+			for i := range av {
+				av[i] = mergeProjections(av[i], bv[i])
+			}
+			return av
+		}
+	}
+	return b
+}
+
+// compactProjection drops the placeholders pickPath leaves for array elements lacking
+// every requested path.
+func compactProjection(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			v[k] = compactProjection(child)
+		}
+	case []interface{}:
+		compacted := make([]interface{}, 0, len(v))
+		for _, elem := range v {
+			if elem != nil {
+				compacted = append(compacted, compactProjection(elem))
+			}
+		}
+		return compacted
+	}
+	return value
+}
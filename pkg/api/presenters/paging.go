@@ -0,0 +1,24 @@
+package presenters
+
+import (
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+)
+
+// presentTotal returns the total of a list page, or nil when the caller skipped counting.
+func presentTotal(paging *api.PagingMeta) *int64 {
+	if paging.Uncounted {
+		return nil
+	}
+	total := paging.Total
+	return &total
+}
+
+// presentContinue returns the token that resumes the list after a page, or nil on the
+// last page.
+func presentContinue(paging *api.PagingMeta) *string {
+	if paging.Continue == "" {
+		return nil
+	}
+	token := paging.Continue
+	return &token
+}
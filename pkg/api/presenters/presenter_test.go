@@ -105,7 +105,7 @@ func TestSliceFilter(t *testing.T) {
 				Expect(result).ToNot(BeNil())
 				Expect(result.Page).To(Equal(int32(1)))
 				Expect(result.Size).To(Equal(int32(2)))
-				Expect(result.Total).To(Equal(int32(2)))
+				Expect(result.Total).To(HaveValue(Equal(int64(2))))
 				Expect(result.Items).To(HaveLen(2))
 
 				// Included fields
@@ -270,6 +270,22 @@ func TestSliceFilter(t *testing.T) {
 				Expect(elem1).ToNot(HaveKey("last_transition_time"))
 			},
 		},
+		{
+			name:   "condition selector applies to every item",
+			fields: []string{"id", "status.conditions[type=Reconciled].status", "spec.region"},
+			model:  createTestClusterList(),
+			validate: func(result *ProjectionList, err *errors.ServiceError) {
+				Expect(err).To(BeNil())
+				Expect(result.Items).To(HaveLen(2))
+				for _, item := range result.Items {
+					conditions := item["status"].(map[string]interface{})["conditions"].([]interface{})
+					Expect(conditions).To(Equal([]interface{}{
+						map[string]interface{}{"status": openapi.ResourceConditionStatus("True")},
+					}))
+				}
+				Expect(result.Items[1]["spec"]).To(Equal(map[string]interface{}{"region": "eu-west-1"}))
+			},
+		},
 		{
 			name:   "time sub-field of slice element",
 			fields: []string{"id", "status.conditions.created_time"},
@@ -433,7 +449,7 @@ func TestSliceFilter(t *testing.T) {
 				Expect(result).ToNot(BeNil())
 				Expect(result.Page).To(Equal(int32(1)))
 				Expect(result.Size).To(Equal(int32(0)))
-				Expect(result.Total).To(Equal(int32(0)))
+				Expect(result.Total).To(HaveValue(Equal(int64(0))))
 				Expect(result.Items).To(BeNil())
 			},
 		},
@@ -500,6 +516,137 @@ func createTestCluster() openapi.Cluster {
 	}
 }
 
+func TestFilterSingle_NestedPaths(t *testing.T) {
+	cluster := createTestCluster()
+	cluster.Spec = openapi.ClusterSpec{
+		"region":  "us-east-1",
+		"release": map[string]interface{}{"version": "4.16", "channel": "stable"},
+		"pools":   []interface{}{map[string]interface{}{"name": "a", "size": 3}, map[string]interface{}{"name": "b"}},
+	}
+	(*cluster.Labels)["app.kubernetes.io/name"] = "api"
+
+	tests := []struct {
+		validate func(result map[string]interface{}, err *errors.ServiceError)
+		name     string
+		fields   []string
+	}{
+		{
+			name:   "spec path",
+			fields: []string{"id", "spec.release.version"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(err).To(BeNil())
+				Expect(result["spec"]).To(Equal(map[string]interface{}{
+					"release": map[string]interface{}{"version": "4.16"},
+				}))
+			},
+		},
+		{
+			name:   "spec paths merge and apply to array elements",
+			fields: []string{"id", "spec.region", "spec.pools.size", "spec.missing"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(err).To(BeNil())
+				Expect(result["spec"]).To(Equal(map[string]interface{}{
+					"region": "us-east-1",
+					"pools":  []interface{}{map[string]interface{}{"size": float64(3)}},
+				}))
+			},
+		},
+		{
+			name:   "label keys with dots",
+			fields: []string{"id", "labels.app.kubernetes.io/name", "labels.env"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(err).To(BeNil())
+				Expect(result["labels"]).To(Equal(map[string]interface{}{"app.kubernetes.io/name": "api", "env": "prod"}))
+			},
+		},
+		{
+			name:   "missing path is omitted",
+			fields: []string{"id", "spec.release.notes"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(err).To(BeNil())
+				Expect(result).ToNot(HaveKey("spec"))
+			},
+		},
+		{
+			name:   "condition selector",
+			fields: []string{"id", "status.conditions[type=Progressing]"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(err).To(BeNil())
+				conditions := result["status"].(map[string]interface{})["conditions"].([]interface{})
+				Expect(conditions).To(HaveLen(1))
+				condition := conditions[0].(map[string]interface{})
+				Expect(condition["type"]).To(Equal("Progressing"))
+				Expect(condition["status"]).To(Equal(openapi.ResourceConditionStatus("False")))
+				Expect(*condition["message"].(*string)).To(Equal(testMessageComponentsUnavailable))
+			},
+		},
+		{
+			name:   "condition selector with sub-field",
+			fields: []string{"id", "status.conditions[type=Reconciled].status"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(err).To(BeNil())
+				conditions := result["status"].(map[string]interface{})["conditions"].([]interface{})
+				Expect(conditions).To(Equal([]interface{}{
+					map[string]interface{}{"status": openapi.ResourceConditionStatus("True")},
+				}))
+			},
+		},
+		{
+			name:   "condition selector matching nothing",
+			fields: []string{"id", "status.conditions[type=Available]"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(err).To(BeNil())
+				conditions := result["status"].(map[string]interface{})["conditions"].([]interface{})
+				Expect(conditions).To(BeEmpty())
+			},
+		},
+		{
+			name:   "unknown selector field",
+			fields: []string{"id", "status.conditions[kind=Reconciled]"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(result).To(BeNil())
+				Expect(err).ToNot(BeNil())
+				Expect(err.Reason).To(ContainSubstring("status.conditions[kind=Reconciled]"))
+			},
+		},
+		{
+			name:   "unknown field within selected elements",
+			fields: []string{"id", "status.conditions[type=Reconciled].bogus"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(result).To(BeNil())
+				Expect(err).ToNot(BeNil())
+				Expect(err.Reason).To(ContainSubstring("status.conditions[type=Reconciled].bogus"))
+			},
+		},
+		{
+			name:   "malformed selector",
+			fields: []string{"id", "status.conditions[type]"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(result).To(BeNil())
+				Expect(err).ToNot(BeNil())
+				Expect(err.Reason).To(ContainSubstring("status.conditions[type]"))
+			},
+		},
+		{
+			name:   "empty map path segment",
+			fields: []string{"id", "spec..region"},
+			validate: func(result map[string]interface{}, err *errors.ServiceError) {
+				Expect(result).To(BeNil())
+				Expect(err).ToNot(BeNil())
+				Expect(err.Reason).To(ContainSubstring("spec..region"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			result, err := FilterSingle(tt.fields, cluster)
+			tt.validate(result, err)
+		})
+	}
+}
+
 func TestFilterSingle(t *testing.T) {
 	tests := []struct {
 		model    interface{}
@@ -74,6 +74,7 @@ func PresentResource(r *api.Resource) openapi.Resource {
 		DeletedTime: r.DeletedTime,
 		Status: openapi.ResourceStatus{
 			Conditions: presentResourceConditions(r.Conditions),
+			Fields:     presentStatusFields(r.StatusFields),
 		},
 	}
 
@@ -108,10 +109,11 @@ func PresentResourceList(resources api.ResourceList, paging *api.PagingMeta) ope
 		items = append(items, PresentResource(resources[i]))
 	}
 	return openapi.ResourceList{
-		Items: items,
-		Page:  int32(paging.Page), //nolint:gosec
-		Size:  int32(paging.Size), //nolint:gosec
-		Total: paging.Total,
+		Items:    items,
+		Page:     int32(paging.Page), //nolint:gosec
+		Size:     int32(paging.Size), //nolint:gosec
+		Total:    presentTotal(paging),
+		Continue: presentContinue(paging),
 	}
 }
 
@@ -152,6 +154,15 @@ func presentResourceConditions(conditions []api.ResourceCondition) []openapi.Res
 	return result
 }
 
+// presentStatusFields returns the projected status fields, or nil when there are none.
+func presentStatusFields(fields datatypes.JSON) *map[string]interface{} {
+	var m map[string]interface{}
+	if len(fields) == 0 || json.Unmarshal(fields, &m) != nil || len(m) == 0 {
+		return nil
+	}
+	return &m
+}
+
 func convertLabelsToModel(labels *map[string]string) ([]api.ResourceLabel, error) {
 	if labels == nil || len(*labels) == 0 {
 		return nil, nil
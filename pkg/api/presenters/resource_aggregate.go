@@ -0,0 +1,47 @@
+package presenters
+
+import (
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+)
+
+// ResourceAggregate is the API representation served by GET /{plural}:aggregate and
+// GET /resources:aggregate.
+type ResourceAggregate struct {
+	Kind      string       `json:"kind"`
+	GroupBy   []string     `json:"group_by"`
+	Groups    []GroupCount `json:"groups"`
+	Total     int64        `json:"total"`
+	Truncated bool         `json:"truncated"`
+}
+
+// GroupCount is the number of resources sharing Values, keyed by groupBy field. A null
+// value groups the resources without that label, condition or spec field.
+type GroupCount struct {
+	Values map[string]*string `json:"values"`
+	Count  int64              `json:"count"`
+}
+
+// PresentResourceAggregate converts resource group counts to their API representation.
+func PresentResourceAggregate(a *api.ResourceAggregate) ResourceAggregate {
+	groupBy := a.GroupBy
+	if groupBy == nil {
+		groupBy = []string{}
+	}
+	groups := make([]GroupCount, 0, len(a.Groups))
+	for _, g := range a.Groups {
+		values := make(map[string]*string, len(groupBy))
+		for i, field := range groupBy {
+			if i < len(g.Values) {
+				values[field] = g.Values[i]
+			}
+		}
+		groups = append(groups, GroupCount{Values: values, Count: g.Count})
+	}
+	return ResourceAggregate{
+		Kind:      "ResourceAggregate",
+		GroupBy:   groupBy,
+		Groups:    groups,
+		Total:     a.Total,
+		Truncated: a.Truncated,
+	}
+}
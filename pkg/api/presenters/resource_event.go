@@ -0,0 +1,70 @@
+package presenters
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+)
+
+// ResourceEvent is the API representation of an audit event served by
+// GET /{plural}/{id}/history.
+type ResourceEvent struct {
+	CreatedTime time.Time       `json:"created_time"`
+	OwnerID     *string         `json:"owner_id,omitempty"`
+	Adapter     *string         `json:"adapter,omitempty"`
+	Reason      *string         `json:"reason,omitempty"`
+	ID          string          `json:"id"`
+	ResourceID  string          `json:"resource_id"`
+	Kind        string          `json:"kind"`
+	Action      string          `json:"action"`
+	Actor       string          `json:"actor"`
+	Changes     json.RawMessage `json:"changes"`
+	Generation  int32           `json:"generation"`
+}
+
+// ResourceEventList is a page of audit events. Total is omitted when the caller skipped
+// counting, and Continue on the last page.
+type ResourceEventList struct {
+	Continue *string         `json:"continue,omitempty"`
+	Total    *int64          `json:"total,omitempty"`
+	Items    []ResourceEvent `json:"items"`
+	Page     int32           `json:"page"`
+	Size     int32           `json:"size"`
+}
+
+// PresentResourceEvent converts an audit event to its API representation.
+func PresentResourceEvent(e *api.ResourceEvent) ResourceEvent {
+	changes := json.RawMessage(e.Changes)
+	if len(changes) == 0 {
+		changes = json.RawMessage("[]")
+	}
+	return ResourceEvent{
+		ID:          e.ID,
+		ResourceID:  e.ResourceID,
+		Kind:        e.Kind,
+		OwnerID:     e.OwnerID,
+		Action:      e.Action,
+		Actor:       e.Actor,
+		Adapter:     e.Adapter,
+		Reason:      e.Reason,
+		Changes:     changes,
+		Generation:  e.Generation,
+		CreatedTime: e.CreatedTime,
+	}
+}
+
+// PresentResourceEventList converts a page of audit events and its paging metadata.
+func PresentResourceEventList(events api.ResourceEventList, paging *api.PagingMeta) ResourceEventList {
+	items := make([]ResourceEvent, 0, len(events))
+	for _, e := range events {
+		items = append(items, PresentResourceEvent(e))
+	}
+	return ResourceEventList{
+		Items:    items,
+		Page:     int32(paging.Page), //nolint:gosec
+		Size:     int32(paging.Size), //nolint:gosec
+		Total:    presentTotal(paging),
+		Continue: presentContinue(paging),
+	}
+}
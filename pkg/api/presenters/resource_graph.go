@@ -0,0 +1,77 @@
+package presenters
+
+import (
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+)
+
+// ResourceNode identifies a resource in the referencers and graph responses.
+type ResourceNode struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	Href string `json:"href,omitempty"`
+}
+
+// ReferencerList is the API representation served by GET /{plural}/{id}/referencers:
+// the resources referencing a resource, grouped by ref type.
+type ReferencerList struct {
+	Items map[string][]ResourceNode `json:"items"`
+	Kind  string                    `json:"kind"`
+	Total int                       `json:"total"`
+}
+
+// ResourceGraph is the API representation served by GET /resources/{id}/graph.
+type ResourceGraph struct {
+	Kind      string         `json:"kind"`
+	Root      string         `json:"root"`
+	Nodes     []ResourceNode `json:"nodes"`
+	Edges     []GraphEdge    `json:"edges"`
+	Depth     int            `json:"depth"`
+	Truncated bool           `json:"truncated"`
+}
+
+// GraphEdge is a directed edge of a ResourceGraph. Type is "owns" or "references".
+type GraphEdge struct {
+	Type    string `json:"type"`
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	RefType string `json:"ref_type,omitempty"`
+}
+
+// PresentReferencerList groups referencers by the ref type they reference through.
+// A resource referencing through several ref types is listed under each of them.
+func PresentReferencerList(referencers []api.ResourceSummary) ReferencerList {
+	items := make(map[string][]ResourceNode)
+	for _, r := range referencers {
+		items[r.RefType] = append(items[r.RefType], presentResourceNode(r))
+	}
+	return ReferencerList{
+		Kind:  "ReferencerList",
+		Items: items,
+		Total: len(referencers),
+	}
+}
+
+// PresentResourceGraph converts a resource graph to its API representation.
+func PresentResourceGraph(g *api.ResourceGraph) ResourceGraph {
+	nodes := make([]ResourceNode, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes = append(nodes, presentResourceNode(n))
+	}
+	edges := make([]GraphEdge, 0, len(g.Edges))
+	for _, e := range g.Edges {
+		edges = append(edges, GraphEdge{Type: e.Type, Source: e.SourceID, Target: e.TargetID, RefType: e.RefType})
+	}
+	return ResourceGraph{
+		Kind:      "ResourceGraph",
+		Root:      g.RootID,
+		Depth:     g.Depth,
+		Nodes:     nodes,
+		Edges:     edges,
+		Truncated: g.Truncated,
+	}
+}
+
+func presentResourceNode(r api.ResourceSummary) ResourceNode {
+	return ResourceNode{ID: r.ID, Kind: r.Kind, Name: r.Name, Href: r.Href}
+}
@@ -0,0 +1,67 @@
+package presenters
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+)
+
+// ResourceRevision is the API representation of a revision served by
+// GET /{plural}/{id}/revisions.
+type ResourceRevision struct {
+	CreatedTime time.Time       `json:"created_time"`
+	ResourceID  string          `json:"resource_id"`
+	Kind        string          `json:"kind"`
+	CreatedBy   string          `json:"created_by"`
+	Spec        json.RawMessage `json:"spec"`
+	Labels      json.RawMessage `json:"labels"`
+	References  json.RawMessage `json:"references"`
+	Generation  int32           `json:"generation"`
+}
+
+// ResourceRevisionList is a page of revisions. Total is omitted when the caller skipped
+// counting, and Continue on the last page.
+type ResourceRevisionList struct {
+	Continue *string            `json:"continue,omitempty"`
+	Total    *int64             `json:"total,omitempty"`
+	Items    []ResourceRevision `json:"items"`
+	Page     int32              `json:"page"`
+	Size     int32              `json:"size"`
+}
+
+// PresentResourceRevision converts a revision to its API representation.
+func PresentResourceRevision(r *api.ResourceRevision) ResourceRevision {
+	return ResourceRevision{
+		ResourceID:  r.ResourceID,
+		Kind:        r.Kind,
+		Generation:  r.Generation,
+		Spec:        jsonObjectOrEmpty(r.Spec),
+		Labels:      jsonObjectOrEmpty(r.Labels),
+		References:  jsonObjectOrEmpty(r.References),
+		CreatedBy:   r.CreatedBy,
+		CreatedTime: r.CreatedTime,
+	}
+}
+
+// PresentResourceRevisionList converts a page of revisions and its paging metadata.
+func PresentResourceRevisionList(revisions api.ResourceRevisionList, paging *api.PagingMeta) ResourceRevisionList {
+	items := make([]ResourceRevision, 0, len(revisions))
+	for _, r := range revisions {
+		items = append(items, PresentResourceRevision(r))
+	}
+	return ResourceRevisionList{
+		Items:    items,
+		Page:     int32(paging.Page), //nolint:gosec
+		Size:     int32(paging.Size), //nolint:gosec
+		Total:    presentTotal(paging),
+		Continue: presentContinue(paging),
+	}
+}
+
+func jsonObjectOrEmpty(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return json.RawMessage("{}")
+	}
+	return json.RawMessage(data)
+}
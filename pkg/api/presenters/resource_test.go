@@ -116,6 +116,29 @@ func TestPresentResource_StatusConditionsJSONEmptyArray(t *testing.T) {
 	Expect(string(body)).To(ContainSubstring(`"status":{"conditions":[]}`))
 }
 
+func TestPresentResource_StatusFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	now := time.Now()
+	resource := &api.Resource{
+		Meta:         api.Meta{ID: "cluster-id", CreatedTime: now, UpdatedTime: now},
+		Kind:         "Cluster",
+		Name:         "test-cluster",
+		Spec:         datatypes.JSON(`{}`),
+		StatusFields: datatypes.JSON(`{"api_url":"https://api.example.com","node_count":3}`),
+		CreatedBy:    "user@test.com",
+		UpdatedBy:    "user@test.com",
+	}
+
+	resp := PresentResource(resource)
+	Expect(resp.Status.Fields).NotTo(BeNil())
+	Expect(*resp.Status.Fields).To(HaveKeyWithValue("api_url", "https://api.example.com"))
+	Expect(*resp.Status.Fields).To(HaveKeyWithValue("node_count", float64(3)))
+
+	resource.StatusFields = datatypes.JSON(`{}`)
+	Expect(PresentResource(resource).Status.Fields).To(BeNil())
+}
+
 func TestPresentResource_WithOwner(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -339,7 +362,29 @@ func TestPresentResourceList(t *testing.T) {
 	Expect(result.Items).To(HaveLen(2))
 	Expect(result.Page).To(Equal(int32(1)))
 	Expect(result.Size).To(Equal(int32(2)))
-	Expect(result.Total).To(Equal(int64(2)))
+	Expect(result.Total).To(HaveValue(Equal(int64(2))))
+}
+
+func TestPresentList_Paging(t *testing.T) {
+	RegisterTestingT(t)
+
+	body, err := json.Marshal(PresentResourceEventList(api.ResourceEventList{}, &api.PagingMeta{Page: 1, Total: 4}))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(body).To(MatchJSON(`{"items":[],"page":1,"size":0,"total":4}`))
+
+	paging := &api.PagingMeta{Page: 1, Continue: "token", Uncounted: true}
+	body, err = json.Marshal(PresentResourceEventList(api.ResourceEventList{}, paging))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(body).To(MatchJSON(`{"items":[],"page":1,"size":0,"continue":"token"}`))
+
+	list := PresentResourceList(api.ResourceList{}, paging)
+	Expect(list.Continue).To(HaveValue(Equal("token")))
+	Expect(list.Total).To(BeNil())
+
+	projected, svcErr := SliceFilter([]string{"id"}, list)
+	Expect(svcErr).To(BeNil())
+	Expect(projected.Continue).To(HaveValue(Equal("token")))
+	Expect(projected.Total).To(BeNil())
 }
 func TestConvertResource_InvalidLabel(t *testing.T) {
 	RegisterTestingT(t)
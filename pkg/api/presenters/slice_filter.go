@@ -9,11 +9,14 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
 )
 
+// ProjectionList is a list page whose items keep only the requested fields. Total is
+// omitted when the caller skipped counting, and Continue on the last page.
 type ProjectionList struct {
-	Items []map[string]interface{} `json:"items"`
-	Page  int32                    `json:"page"`
-	Size  int32                    `json:"size"`
-	Total int32                    `json:"total"`
+	Continue *string                  `json:"continue,omitempty"`
+	Total    *int64                   `json:"total,omitempty"`
+	Items    []map[string]interface{} `json:"items"`
+	Page     int32                    `json:"page"`
+	Size     int32                    `json:"size"`
 }
 
 // SliceFilter returns a projected list containing requested fields from each item
@@ -33,10 +36,11 @@ func SliceFilter(fields2Store []string, model interface{}) (*ProjectionList, *er
 
 	// Initialize result structure
 	result := &ProjectionList{
-		Page:  int32(reflectValue.FieldByName("Page").Int()),  //nolint:gosec
-		Size:  int32(reflectValue.FieldByName("Size").Int()),  //nolint:gosec
-		Total: int32(reflectValue.FieldByName("Total").Int()), //nolint:gosec
-		Items: nil,
+		Page:     int32(reflectValue.FieldByName("Page").Int()), //nolint:gosec
+		Size:     int32(reflectValue.FieldByName("Size").Int()), //nolint:gosec
+		Total:    projectionTotal(reflectValue.FieldByName("Total")),
+		Continue: projectionContinue(reflectValue.FieldByName("Continue")),
+		Items:    nil,
 	}
 
 	field := reflectValue.FieldByName("Items").Interface()
@@ -137,6 +141,9 @@ func validate(model interface{}, in map[string]bool, prefix string) *errors.Serv
 				elemType = elemType.Elem()
 			}
 			if elemType.Kind() == reflect.Struct && elemType != reflect.TypeOf(time.Time{}) {
+				if err := validateSelectors(in, prefixedName, elemType); err != nil {
+					return err
+				}
 				sliceFieldPrefix := prefixedName + "."
 				subIn := make(map[string]bool)
 				for k := range in {
@@ -168,6 +175,10 @@ func validate(model interface{}, in map[string]bool, prefix string) *errors.Serv
 				prefixedName = fmt.Sprintf("%s.%s", prefix, name)
 			}
 			delete(in, prefixedName)
+			// map keys are data, so any dotted path into a map is a valid projection
+			if isMapType(t.Type) {
+				removeMapPaths(in, prefixedName)
+			}
 		}
 	}
 
@@ -273,7 +284,8 @@ func structToMap(item interface{}, in map[string]bool, prefix string) map[string
 			}
 			starSelectorKey := nextPrefix + ".*"
 			parentStar := prefix + ".*"
-			requested := in[nextPrefix] || in[starSelectorKey] || in[parentStar]
+			selectors := requestedSelectors(in, nextPrefix)
+			requested := in[nextPrefix] || in[starSelectorKey] || in[parentStar] || len(selectors) > 0
 			if !requested {
 				subPrefix := nextPrefix + "."
 				for k := range in {
@@ -313,13 +325,14 @@ func structToMap(item interface{}, in map[string]bool, prefix string) map[string
 						}
 						continue
 					}
-					slice := structToMap(elem.Interface(), elemIn, nextPrefix)
+					slice := structToMap(elem.Interface(), selectElement(elem, elemIn, nextPrefix, selectors), nextPrefix)
 					if len(slice) == 0 {
 						continue
 					}
 					result = append(result, slice)
 				}
-				if len(result) > 0 {
+				// a selector matching no element still answers with an empty list
+				if len(result) > 0 || len(selectors) > 0 {
 					res[name] = result
 				}
 			}
@@ -334,6 +347,10 @@ func structToMap(item interface{}, in map[string]bool, prefix string) map[string
 				prefixedStar := fmt.Sprintf("%s.*", prefix)
 				if _, ok := in[prefixedStar]; ok {
 					res[name] = field
+				} else if isMapType(t.Type) {
+					if projected, found := projectMap(field, requestedPaths(in, prefixedName)); found {
+						res[name] = projected
+					}
 				}
 			}
 		}
@@ -367,3 +384,27 @@ func FilterSingle(fields2Store []string, resource interface{}) (map[string]inter
 	// Convert resource to filtered map
 	return structToMap(resource, in, ""), nil
 }
+
+// projectionTotal reads the Total of a list, an integer of any size that may be optional.
+func projectionTotal(field reflect.Value) *int64 {
+	if !field.IsValid() {
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	total := field.Int()
+	return &total
+}
+
+// projectionContinue reads the optional Continue token of a list.
+func projectionContinue(field reflect.Value) *string {
+	if !field.IsValid() || field.Kind() != reflect.Ptr || field.IsNil() {
+		return nil
+	}
+	token := field.Elem().String()
+	return &token
+}
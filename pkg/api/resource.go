@@ -20,18 +20,24 @@ type Resource struct {
 	OwnerKind   *string    `json:"owner_kind,omitempty" gorm:"size:100"`
 	DeletedBy   *string    `json:"deleted_by,omitempty" gorm:"size:255"`
 	DeletedTime *time.Time `json:"deleted_time,omitempty"`
+	// When the conditions or status fields last changed. Status writes leave UpdatedTime
+	// alone, so the ETag folds this in to cover them.
+	StatusUpdatedTime *time.Time `json:"-"`
 	Meta
-	Kind       string              `json:"kind" gorm:"size:100;not null"`
-	Name       string              `json:"name" gorm:"size:100;not null"`
-	Href       string              `json:"href,omitempty" gorm:"size:500"`
-	CreatedBy  string              `json:"created_by" gorm:"size:255;not null"`
-	UpdatedBy  string              `json:"updated_by" gorm:"size:255;not null"`
-	Labels     []ResourceLabel     `json:"-" gorm:"foreignKey:ResourceID;references:ID"`
-	Spec       datatypes.JSON      `json:"spec" gorm:"type:jsonb;not null"`
-	Tenancy    datatypes.JSON      `json:"tenancy" gorm:"type:jsonb;not null;default:'{}'"`
-	Conditions []ResourceCondition `json:"-" gorm:"foreignKey:ResourceID;references:ID"`
-	References []ResourceReference `json:"-" gorm:"foreignKey:SourceID;references:ID"`
-	Generation int32               `json:"generation" gorm:"default:1;not null"`
+	Kind      string          `json:"kind" gorm:"size:100;not null"`
+	Name      string          `json:"name" gorm:"size:100;not null"`
+	Href      string          `json:"href,omitempty" gorm:"size:500"`
+	CreatedBy string          `json:"created_by" gorm:"size:255;not null"`
+	UpdatedBy string          `json:"updated_by" gorm:"size:255;not null"`
+	Labels    []ResourceLabel `json:"-" gorm:"foreignKey:ResourceID;references:ID"`
+	Spec      datatypes.JSON  `json:"spec" gorm:"type:jsonb;not null"`
+	Tenancy   datatypes.JSON  `json:"tenancy" gorm:"type:jsonb;not null;default:'{}'"`
+	// Values projected from adapter statuses by the kind's status_fields rules. Kept out
+	// of the JSON form so the resource seen by CEL expressions is unaffected by them.
+	StatusFields datatypes.JSON      `json:"-" gorm:"type:jsonb;not null;default:'{}'"`
+	Conditions   []ResourceCondition `json:"-" gorm:"foreignKey:ResourceID;references:ID"`
+	References   []ResourceReference `json:"-" gorm:"foreignKey:SourceID;references:ID"`
+	Generation   int32               `json:"generation" gorm:"default:1;not null"`
 }
 
 // ReferenceMap is the API-level representation of resource references,
@@ -50,6 +56,16 @@ func (r Resource) TableName() string {
 	return "resources"
 }
 
+// TenantScoped is implemented by models whose rows are owned by a tenant.
+// Generic list queries over these models are filtered by the caller's tenancy.
+type TenantScoped interface {
+	TenancyColumn() string
+}
+
+func (r Resource) TenancyColumn() string {
+	return "tenancy"
+}
+
 func (r *Resource) BeforeCreate(tx *gorm.DB) error {
 	if r.ID == "" {
 		id, err := NewID()
@@ -59,7 +75,9 @@ func (r *Resource) BeforeCreate(tx *gorm.DB) error {
 		r.ID = id
 	}
 
-	now := time.Now()
+	// Truncate to the database's precision so the in-memory timestamps (and the
+	// ETag derived from them) match what a later read returns.
+	now := time.Now().Truncate(time.Microsecond)
 	if r.CreatedTime.IsZero() {
 		r.CreatedTime = now
 	}
@@ -90,10 +108,20 @@ func (r *Resource) BeforeCreate(tx *gorm.DB) error {
 }
 
 func (r *Resource) BeforeUpdate(tx *gorm.DB) error {
-	r.UpdatedTime = time.Now()
+	r.UpdatedTime = time.Now().Truncate(time.Microsecond)
 	return nil
 }
 
+// ETag returns a strong entity tag for the resource's current version. Generation alone
+// does not move on label-only changes, so the update time is folded in as well, and the
+// status update time once adapters have reported.
+func (r *Resource) ETag() string {
+	if r.StatusUpdatedTime == nil {
+		return fmt.Sprintf(`"%d-%d"`, r.Generation, r.UpdatedTime.UnixMicro())
+	}
+	return fmt.Sprintf(`"%d-%d-%d"`, r.Generation, r.UpdatedTime.UnixMicro(), r.StatusUpdatedTime.UnixMicro())
+}
+
 func (r *Resource) MarkDeleted(by string, t time.Time) {
 	r.DeletedTime = &t
 	r.DeletedBy = &by
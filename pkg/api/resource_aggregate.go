@@ -0,0 +1,19 @@
+package api
+
+// GroupCount is the number of rows sharing the same values of a grouped count's
+// expressions. Values are in the order of the expressions; a nil value groups the rows
+// for which the expression is NULL.
+type GroupCount struct {
+	Values []*string
+	Count  int64
+}
+
+// ResourceAggregate counts resources per distinct value of the GroupBy fields. Total
+// is the number of matching resources. Truncated reports that only the largest groups
+// were returned, so Total exceeds the sum of their counts.
+type ResourceAggregate struct {
+	GroupBy   []string
+	Groups    []GroupCount
+	Total     int64
+	Truncated bool
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Change types emitted on watch streams.
+const (
+	ChangeAdded    = "ADDED"
+	ChangeModified = "MODIFIED"
+	ChangeDeleted  = "DELETED"
+)
+
+// ResourceChange is one entry in the resource change log. Object holds the resource as
+// presented by the API at the time of the change, so a DELETED entry still carries the
+// last state of a row that no longer exists.
+type ResourceChange struct {
+	CreatedTime time.Time      `json:"created_time" gorm:"->"`
+	OwnerID     *string        `json:"owner_id,omitempty" gorm:"size:255"`
+	Type        string         `json:"type" gorm:"size:16;not null"`
+	Kind        string         `json:"kind" gorm:"size:100;not null"`
+	ResourceID  string         `json:"resource_id" gorm:"size:255;not null"`
+	Tenancy     datatypes.JSON `json:"tenancy" gorm:"type:jsonb;not null"`
+	Object      datatypes.JSON `json:"object" gorm:"type:jsonb;not null"`
+	ID          int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	TxID        int64          `json:"-" gorm:"column:txid;->"`
+}
+
+func (ResourceChange) TableName() string {
+	return "resource_changes"
+}
+
+func (ResourceChange) TenancyColumn() string {
+	return "tenancy"
+}
+
+// Position returns the change-log position just past this change.
+func (c *ResourceChange) Position() ChangePosition {
+	return ChangePosition{TxID: c.TxID, ID: c.ID}
+}
+
+// ChangePosition is a point in the resource change log, ordered by committing
+// transaction and then by insertion. The zero value is the start of the log.
+type ChangePosition struct {
+	TxID int64
+	ID   int64
+}
+
+// String renders the position as the opaque token handed to watch clients.
+func (p ChangePosition) String() string {
+	return fmt.Sprintf("%d-%d", p.TxID, p.ID)
+}
+
+// IsZero reports whether p is the start of the log.
+func (p ChangePosition) IsZero() bool {
+	return p.TxID == 0 && p.ID == 0
+}
+
+// Before reports whether p sorts before other.
+func (p ChangePosition) Before(other ChangePosition) bool {
+	return p.TxID < other.TxID || (p.TxID == other.TxID && p.ID < other.ID)
+}
+
+// ParseChangePosition parses a token produced by ChangePosition.String.
+func ParseChangePosition(token string) (ChangePosition, error) {
+	txPart, idPart, ok := strings.Cut(token, "-")
+	if !ok {
+		return ChangePosition{}, fmt.Errorf("invalid position %q", token)
+	}
+	txID, err := strconv.ParseInt(txPart, 10, 64)
+	if err != nil || txID < 0 {
+		return ChangePosition{}, fmt.Errorf("invalid position %q", token)
+	}
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil || id < 0 {
+		return ChangePosition{}, fmt.Errorf("invalid position %q", token)
+	}
+	return ChangePosition{TxID: txID, ID: id}, nil
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Audit actions recorded in the resource event log.
+const (
+	AuditCreate        = "CREATE"
+	AuditPatch         = "PATCH"
+	AuditDelete        = "DELETE"
+	AuditForceDelete   = "FORCE_DELETE"
+	AuditAdapterStatus = "ADAPTER_STATUS"
+)
+
+// ResourceEvent is one entry in the append-only audit log of resource mutations.
+// Changes holds a JSON array of FieldChange describing what the mutation changed.
+type ResourceEvent struct {
+	CreatedTime time.Time      `json:"created_time"`
+	OwnerID     *string        `json:"owner_id,omitempty" gorm:"size:255"`
+	Adapter     *string        `json:"adapter,omitempty" gorm:"size:255"`
+	Reason      *string        `json:"reason,omitempty"`
+	ID          string         `json:"id" gorm:"primaryKey;size:36"`
+	ResourceID  string         `json:"resource_id" gorm:"size:255;not null"`
+	Kind        string         `json:"kind" gorm:"size:100;not null"`
+	Action      string         `json:"action" gorm:"size:32;not null"`
+	Actor       string         `json:"actor" gorm:"size:255;not null"`
+	Changes     datatypes.JSON `json:"changes" gorm:"type:jsonb;not null"`
+	Tenancy     datatypes.JSON `json:"tenancy" gorm:"type:jsonb;not null"`
+	Generation  int32          `json:"generation" gorm:"not null"`
+}
+
+type ResourceEventList []*ResourceEvent
+
+func (ResourceEvent) TableName() string {
+	return "resource_events"
+}
+
+func (ResourceEvent) TenancyColumn() string {
+	return "tenancy"
+}
+
+func (e *ResourceEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		id, err := NewID()
+		if err != nil {
+			return fmt.Errorf("failed to generate resource event ID: %w", err)
+		}
+		e.ID = id
+	}
+	// Stamp the time here rather than with now(), which is fixed for the whole
+	// transaction and would give every event of a cascading delete the same time.
+	if e.CreatedTime.IsZero() {
+		e.CreatedTime = time.Now().Truncate(time.Microsecond)
+	}
+	return nil
+}
+
+// FieldChange is a single changed field in an audit event. Path names the field as
+// TSL does, e.g. "spec.replicas", "labels.env" or "references.wif_config". Old is
+// omitted when the field was added and New when it was removed.
+type FieldChange struct {
+	Path string          `json:"path"`
+	Old  json.RawMessage `json:"old,omitempty"`
+	New  json.RawMessage `json:"new,omitempty"`
+}
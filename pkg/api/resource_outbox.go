@@ -0,0 +1,25 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// OutboxEvent is a CloudEvent waiting in the transactional outbox for delivery. Event
+// holds the complete structured-mode CloudEvent exactly as it is sent to the sink.
+type OutboxEvent struct {
+	NextAttemptTime time.Time      `json:"next_attempt_time" gorm:"->"`
+	CreatedTime     time.Time      `json:"created_time" gorm:"->"`
+	LastError       *string        `json:"last_error,omitempty"`
+	EventID         string         `json:"event_id" gorm:"size:36;not null"`
+	Kind            string         `json:"kind" gorm:"size:100;not null"`
+	ResourceID      string         `json:"resource_id" gorm:"size:255;not null"`
+	Event           datatypes.JSON `json:"event" gorm:"type:jsonb;not null"`
+	ID              int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	Attempts        int            `json:"attempts" gorm:"->"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "resource_outbox"
+}
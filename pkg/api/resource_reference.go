@@ -13,8 +13,38 @@ func (ResourceReference) TableName() string {
 	return "resource_references"
 }
 
-// ResourceSummary carries kind + name for error messages (e.g. FindReferencers 409).
+// ResourceSummary identifies a resource holding an inbound reference (see FindReferencers).
+// Kind and Name feed 409 messages; ID and RefType resolve the on-target-delete policy.
+// Graph nodes use it without a RefType.
 type ResourceSummary struct {
-	Kind string
-	Name string
+	ID      string
+	Kind    string
+	Name    string
+	Href    string
+	RefType string
+}
+
+// Graph edge types.
+const (
+	GraphEdgeOwns       = "owns"
+	GraphEdgeReferences = "references"
+)
+
+// ResourceGraph is the neighbourhood of a resource: its ownership tree and the
+// references into and out of it, walked breadth-first up to Depth edges from RootID.
+type ResourceGraph struct {
+	RootID    string
+	Nodes     []ResourceSummary
+	Edges     []GraphEdge
+	Depth     int
+	Truncated bool
+}
+
+// GraphEdge is a directed edge of a ResourceGraph: owner to child for GraphEdgeOwns,
+// referencer to target for GraphEdgeReferences, which also carries the RefType.
+type GraphEdge struct {
+	Type     string
+	SourceID string
+	TargetID string
+	RefType  string
 }
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ResourceRevision is the user-writable state of a resource at one generation: its spec,
+// its labels as a key/value object and its references as a ReferenceMap.
+type ResourceRevision struct {
+	CreatedTime time.Time      `json:"created_time"`
+	ResourceID  string         `json:"resource_id" gorm:"primaryKey;size:255"`
+	Kind        string         `json:"kind" gorm:"size:100;not null"`
+	CreatedBy   string         `json:"created_by" gorm:"size:255;not null"`
+	Spec        datatypes.JSON `json:"spec" gorm:"type:jsonb;not null"`
+	Labels      datatypes.JSON `json:"labels" gorm:"type:jsonb;not null"`
+	References  datatypes.JSON `json:"references" gorm:"column:refs;type:jsonb;not null"`
+	Tenancy     datatypes.JSON `json:"tenancy" gorm:"type:jsonb;not null"`
+	Generation  int32          `json:"generation" gorm:"primaryKey;autoIncrement:false"`
+}
+
+type ResourceRevisionList []*ResourceRevision
+
+func (ResourceRevision) TableName() string {
+	return "resource_revisions"
+}
+
+func (ResourceRevision) TenancyColumn() string {
+	return "tenancy"
+}
+
+func (r *ResourceRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.CreatedTime.IsZero() {
+		r.CreatedTime = time.Now().Truncate(time.Microsecond)
+	}
+	return nil
+}
+
+// Patch returns a patch that replaces the spec, labels and references of a resource with
+// those of the revision. Labels and references are always set, so ones added after the
+// revision are removed.
+func (r *ResourceRevision) Patch() (*ResourcePatch, error) {
+	patch := &ResourcePatch{
+		Spec:       map[string]interface{}{},
+		Labels:     map[string]string{},
+		References: ReferenceMap{},
+	}
+	if len(r.Spec) > 0 {
+		if err := json.Unmarshal(r.Spec, &patch.Spec); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal revision spec: %w", err)
+		}
+	}
+	if len(r.Labels) > 0 {
+		if err := json.Unmarshal(r.Labels, &patch.Labels); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal revision labels: %w", err)
+		}
+	}
+	if len(r.References) > 0 {
+		if err := json.Unmarshal(r.References, &patch.References); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal revision references: %w", err)
+		}
+	}
+	return patch, nil
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestResourceRevision_Patch(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := &ResourceRevision{
+		Spec:       []byte(`{"region":"us-east-1","nodes":{"replicas":3}}`),
+		Labels:     []byte(`{"env":"prod"}`),
+		References: []byte(`{"wif_config":[{"id":"wif-1","kind":"WifConfig"}]}`),
+	}
+
+	patch, err := r.Patch()
+	Expect(err).To(BeNil())
+	Expect(patch.Spec).To(HaveKeyWithValue("region", "us-east-1"))
+	Expect(patch.Labels).To(Equal(map[string]string{"env": "prod"}))
+	Expect(patch.References).To(HaveKey("wif_config"))
+	Expect(*patch.References["wif_config"][0].Id).To(Equal("wif-1"))
+	Expect(patch.References["wif_config"][0].Kind).To(Equal("WifConfig"))
+}
+
+func TestResourceRevision_Patch_ClearsLaterLabelsAndReferences(t *testing.T) {
+	RegisterTestingT(t)
+
+	patch, err := (&ResourceRevision{Spec: []byte(`{}`), Labels: []byte(`{}`), References: []byte(`{}`)}).Patch()
+	Expect(err).To(BeNil())
+	// Empty, not nil: a nil member would leave the resource's current values in place.
+	Expect(patch.Labels).NotTo(BeNil())
+	Expect(patch.Labels).To(BeEmpty())
+	Expect(patch.References).NotTo(BeNil())
+	Expect(patch.References).To(BeEmpty())
+}
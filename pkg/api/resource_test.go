@@ -75,7 +75,8 @@ func TestResource_BeforeCreate_Timestamps(t *testing.T) {
 	RegisterTestingT(t)
 	setupTestRegistry()
 
-	before := time.Now()
+	// Timestamps are truncated to database (microsecond) precision.
+	before := time.Now().Truncate(time.Microsecond)
 	r := &Resource{Name: "test", Kind: "Channel"}
 
 	err := r.BeforeCreate(nil)
@@ -188,7 +189,7 @@ func TestResource_BeforeUpdate_UpdatesTimestamp(t *testing.T) {
 	r := &Resource{Name: "test", Kind: "Channel"}
 	r.UpdatedTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	before := time.Now()
+	before := time.Now().Truncate(time.Microsecond)
 	err := r.BeforeUpdate(nil)
 	Expect(err).To(BeNil())
 	Expect(r.UpdatedTime.After(before) || r.UpdatedTime.Equal(before)).To(BeTrue())
@@ -237,3 +238,24 @@ func TestResource_TableName(t *testing.T) {
 	r := Resource{}
 	Expect(r.TableName()).To(Equal("resources"))
 }
+
+func TestResource_ETag(t *testing.T) {
+	RegisterTestingT(t)
+
+	updated := time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)
+	r := &Resource{Meta: Meta{UpdatedTime: updated}, Generation: 3}
+	Expect(r.ETag()).To(Equal(`"3-1767323045000006"`))
+
+	// Label-only updates keep the generation but move the update time.
+	r.UpdatedTime = updated.Add(time.Second)
+	Expect(r.ETag()).NotTo(Equal(`"3-1767323045000006"`))
+
+	r.UpdatedTime = updated
+	r.IncrementGeneration()
+	Expect(r.ETag()).To(Equal(`"4-1767323045000006"`))
+
+	// Status reports move neither, but record their own time.
+	reported := updated.Add(time.Minute)
+	r.StatusUpdatedTime = &reported
+	Expect(r.ETag()).To(Equal(`"4-1767323045000006-1767323105000006"`))
+}
@@ -0,0 +1,383 @@
+// Package apidoc builds the OpenAPI document that describes the running API.
+//
+// The core spec embedded in pkg/api/openapi only covers the resources it was
+// written for. Entity kinds are configured per deployment, so the document served
+// at /openapi is the core spec extended at startup with schemas and paths for every
+// kind in the registry.
+package apidoc
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/openapi"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
+)
+
+const componentSchemaPrefix = "#/components/schemas/"
+
+// Build returns the core spec extended with every kind in the registry. Spec schemas
+// are taken from the deployment schema loaded by validator, so each kind's spec is
+// typed as it is validated. validator may be nil, leaving the specs of kinds the core
+// spec does not describe as free-form objects.
+func Build(validator *validators.SchemaValidator) (*openapi3.T, error) {
+	base, err := openapi.GetSpec()
+	if err != nil {
+		return nil, fmt.Errorf("load embedded OpenAPI specification: %w", err)
+	}
+	return Extend(base, validator.ComponentSchemas(), registry.All())
+}
+
+// Extend returns a copy of base with components and paths added for descriptors.
+//
+// specSchemas replace the base schemas named by a descriptor's SpecSchemaName, and any
+// of them missing from base are added so references between them resolve. Kinds that
+// base has a schema for are otherwise left as base describes them. Every other kind
+// gets {Kind}, {Kind}CreateRequest, {Kind}PatchRequest, and {Kind}List schemas and the
+// routes RegisterEntityRoutes serves: the collection, the item with its force-delete
+// and statuses sub-routes and, for child kinds, the same routes nested under every
+// chain of ancestors plus the listing under each ancestor above the parent, skipping
+// any path base already describes. History, revisions, rollback, referencers,
+// aggregates, and graph are not described.
+func Extend(
+	base *openapi3.T, specSchemas openapi3.Schemas, descriptors []registry.EntityDescriptor,
+) (*openapi3.T, error) {
+	doc, err := clone(base)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Components == nil {
+		doc.Components = &openapi3.Components{}
+	}
+	if doc.Components.Schemas == nil {
+		doc.Components.Schemas = openapi3.Schemas{}
+	}
+	if doc.Paths == nil {
+		doc.Paths = openapi3.NewPaths()
+	}
+
+	descriptors = slices.Clone(descriptors)
+	slices.SortFunc(descriptors, func(a, b registry.EntityDescriptor) int {
+		return cmp.Compare(a.Kind, b.Kind)
+	})
+	byKind := make(map[string]registry.EntityDescriptor, len(descriptors))
+	for _, d := range descriptors {
+		byKind[d.Kind] = d
+	}
+
+	addSpecSchemas(doc, specSchemas, descriptors)
+	for _, d := range descriptors {
+		if doc.Components.Schemas[d.Kind] != nil {
+			continue // described by the core spec
+		}
+		addKindSchemas(doc, d)
+		ancestors, ancestorErr := ancestorsOf(d, byKind)
+		if ancestorErr != nil {
+			return nil, ancestorErr
+		}
+		for depth := 1; depth <= len(ancestors); depth++ {
+			addKindPaths(doc, d, ancestors[:depth])
+		}
+		for i := 1; i < len(ancestors); i++ {
+			addDescendantPaths(doc, d, ancestors[i])
+		}
+		addKindPaths(doc, d, nil)
+	}
+	return doc, nil
+}
+
+// ancestorsOf returns the kinds up d's ParentKind chain, nearest first.
+func ancestorsOf(
+	d registry.EntityDescriptor, byKind map[string]registry.EntityDescriptor,
+) ([]registry.EntityDescriptor, error) {
+	var ancestors []registry.EntityDescriptor
+	seen := map[string]bool{d.Kind: true}
+	for child := d; child.ParentKind != ""; {
+		parent, ok := byKind[child.ParentKind]
+		if !ok {
+			return nil, fmt.Errorf("entity kind %q has unknown parent kind %q", child.Kind, child.ParentKind)
+		}
+		if seen[parent.Kind] {
+			return nil, fmt.Errorf("entity kind %q is part of a parent kind cycle", d.Kind)
+		}
+		seen[parent.Kind] = true
+		ancestors = append(ancestors, parent)
+		child = parent
+	}
+	return ancestors, nil
+}
+
+// clone deep-copies a document by round-tripping it through JSON.
+func clone(base *openapi3.T) (*openapi3.T, error) {
+	data, err := base.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal OpenAPI specification: %w", err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("copy OpenAPI specification: %w", err)
+	}
+	return doc, nil
+}
+
+func addSpecSchemas(doc *openapi3.T, specSchemas openapi3.Schemas, descriptors []registry.EntityDescriptor) {
+	for _, d := range descriptors {
+		if schema := specSchemas[d.SpecSchemaName]; d.SpecSchemaName != "" && schema != nil {
+			doc.Components.Schemas[d.SpecSchemaName] = schema
+		}
+	}
+	for name, schema := range specSchemas {
+		if doc.Components.Schemas[name] == nil {
+			doc.Components.Schemas[name] = schema
+		}
+	}
+}
+
+func addKindSchemas(doc *openapi3.T, d registry.EntityDescriptor) {
+	addSchema(doc, d.Kind, extendSchema("Resource", kindProperties(doc, d, false)))
+
+	create := kindProperties(doc, d, true)
+	create.Required = append(create.Required, "spec")
+	addSchema(doc, d.Kind+"CreateRequest", extendSchema("ResourceCreateRequest", create))
+
+	patch := kindProperties(doc, d, false)
+	delete(patch.Properties, "kind")
+	addSchema(doc, d.Kind+"PatchRequest", extendSchema("ResourcePatchRequest", patch))
+
+	items := openapi3.NewArraySchema()
+	items.Items = componentRef(d.Kind)
+	addSchema(doc, d.Kind+"List", extendSchema("ResourceList", openapi3.NewObjectSchema().WithProperty("items", items)))
+}
+
+func addSchema(doc *openapi3.T, name string, schema *openapi3.Schema) {
+	if doc.Components.Schemas[name] == nil {
+		doc.Components.Schemas[name] = openapi3.NewSchemaRef("", schema)
+	}
+}
+
+// extendSchema narrows a generic resource schema with kind-specific properties.
+func extendSchema(base string, properties *openapi3.Schema) *openapi3.Schema {
+	return &openapi3.Schema{
+		AllOf: openapi3.SchemaRefs{componentRef(base), openapi3.NewSchemaRef("", properties)},
+	}
+}
+
+// kindProperties types the kind, spec, and references of a resource of kind d. When
+// forCreate is set, reference types with a minimum are required.
+func kindProperties(doc *openapi3.T, d registry.EntityDescriptor, forCreate bool) *openapi3.Schema {
+	properties := openapi3.NewObjectSchema().
+		WithProperty("kind", openapi3.NewStringSchema().WithEnum(d.Kind))
+
+	if d.SpecSchemaName != "" && doc.Components.Schemas[d.SpecSchemaName] != nil {
+		properties.WithPropertyRef("spec", componentRef(d.SpecSchemaName))
+	} else {
+		properties.WithProperty("spec", openapi3.NewObjectSchema())
+	}
+
+	if len(d.References) == 0 {
+		return properties
+	}
+	references := openapi3.NewObjectSchema()
+	for _, ref := range d.References {
+		targets := openapi3.NewArraySchema().WithMinItems(int64(ref.Min))
+		targets.Items = componentRef("ObjectReference")
+		targets.Description = fmt.Sprintf("References to %s resources", ref.TargetKind)
+		if ref.Max > 0 {
+			targets.WithMaxItems(int64(ref.Max))
+		}
+		references.WithProperty(ref.RefType, targets)
+		if forCreate && ref.Min > 0 {
+			references.Required = append(references.Required, ref.RefType)
+		}
+	}
+	if forCreate && len(references.Required) > 0 {
+		properties.Required = append(properties.Required, "references")
+	}
+	return properties.WithProperty("references", references)
+}
+
+// addKindPaths describes the routes of kind d. With ancestors set, nearest first, they
+// are the routes nested under that chain of ancestors; otherwise they are the flat
+// routes, where a child kind cannot be created.
+func addKindPaths(doc *openapi3.T, d registry.EntityDescriptor, ancestors []registry.EntityDescriptor) {
+	prefix := "/" + d.Plural
+	suffix := ""
+	var scope openapi3.Parameters
+	if len(ancestors) > 0 {
+		prefix = ""
+		for level := len(ancestors); level >= 1; level-- {
+			ancestor := ancestors[level-1]
+			name := ancestorParameter(level)
+			prefix += "/" + ancestor.Plural + "/{" + name + "}"
+			description := "ID of the ancestor " + ancestor.Kind
+			if level == 1 {
+				description = "ID of the parent " + ancestor.Kind
+			}
+			scope = append(scope, pathParameter(name, description))
+		}
+		prefix += "/" + d.Plural
+		for _, ancestor := range ancestors {
+			suffix += "Of" + ancestor.Kind
+		}
+	}
+	itemScope := append(slices.Clone(scope), pathParameter("id", "ID of the "+d.Kind))
+	item := prefix + "/{id}"
+
+	collection := &openapi3.PathItem{Parameters: scope}
+	collection.Get = operation(d, "get"+pluralName(d)+suffix, "List "+d.Plural)
+	collection.Get.Parameters = listParameters()
+	collection.Get.AddResponse(200, jsonResponse("The page of "+d.Plural, d.Kind+"List"))
+	if d.ParentKind == "" || len(ancestors) > 0 {
+		collection.Post = operation(d, "post"+d.Kind+suffix, "Create a "+d.Kind)
+		collection.Post.Parameters = openapi3.Parameters{idempotencyKeyParameter()}
+		collection.Post.RequestBody = jsonRequestBody(d.Kind + "CreateRequest")
+		collection.Post.AddResponse(201, jsonResponse("The created "+d.Kind, d.Kind))
+	}
+	setPath(doc, prefix, collection)
+
+	resource := &openapi3.PathItem{Parameters: itemScope}
+	resource.Get = operation(d, "get"+d.Kind+"ById"+suffix, "Get a "+d.Kind)
+	resource.Get.AddResponse(200, jsonResponse("The "+d.Kind, d.Kind))
+	resource.Patch = operation(d, "patch"+d.Kind+"ById"+suffix, "Update a "+d.Kind)
+	resource.Patch.Parameters = openapi3.Parameters{ifMatchParameter()}
+	resource.Patch.RequestBody = jsonRequestBody(d.Kind + "PatchRequest")
+	resource.Patch.AddResponse(200, jsonResponse("The updated "+d.Kind, d.Kind))
+	resource.Delete = operation(d, "delete"+d.Kind+"ById"+suffix, "Delete a "+d.Kind)
+	resource.Delete.Parameters = openapi3.Parameters{ifMatchParameter()}
+	resource.Delete.AddResponse(202, jsonResponse("The "+d.Kind+", marked for deletion", d.Kind))
+	setPath(doc, item, resource)
+
+	forceDelete := &openapi3.PathItem{Parameters: itemScope}
+	forceDelete.Post = operation(d, "forceDelete"+d.Kind+suffix, "Remove a "+d.Kind+" without waiting for adapters")
+	forceDelete.Post.Parameters = openapi3.Parameters{idempotencyKeyParameter()}
+	forceDelete.Post.RequestBody = jsonRequestBody("ForceDeleteRequest")
+	forceDelete.Post.AddResponse(204, openapi3.NewResponse().WithDescription("The "+d.Kind+" was removed"))
+	setPath(doc, item+"/force-delete", forceDelete)
+
+	statuses := &openapi3.PathItem{Parameters: itemScope}
+	statuses.Get = operation(d, "get"+d.Kind+"Statuses"+suffix, "List the adapter statuses of a "+d.Kind)
+	statuses.Get.Parameters = listParameters()
+	statuses.Get.AddResponse(200, jsonResponse("The page of adapter statuses", "AdapterStatusList"))
+	statuses.Put = operation(d, "put"+d.Kind+"Statuses"+suffix, "Report an adapter status for a "+d.Kind)
+	statuses.Put.RequestBody = jsonRequestBody("AdapterStatusCreateRequest")
+	statuses.Put.AddResponse(201, jsonResponse("The recorded adapter status", "AdapterStatus"))
+	setPath(doc, item+"/statuses", statuses)
+}
+
+// addDescendantPaths describes the listing of kind d under ancestor, a kind above its
+// parent, across the intermediate owners.
+func addDescendantPaths(doc *openapi3.T, d, ancestor registry.EntityDescriptor) {
+	collection := &openapi3.PathItem{Parameters: openapi3.Parameters{
+		pathParameter("ancestor_id", "ID of the ancestor "+ancestor.Kind),
+	}}
+	collection.Get = operation(d, "get"+pluralName(d)+"Of"+ancestor.Kind, "List the "+d.Plural+" of a "+ancestor.Kind)
+	collection.Get.Parameters = listParameters()
+	collection.Get.AddResponse(200, jsonResponse("The page of "+d.Plural, d.Kind+"List"))
+	setPath(doc, "/"+ancestor.Plural+"/{ancestor_id}/"+d.Plural, collection)
+}
+
+// ancestorParameter names the path parameter holding the ID of the ancestor level
+// generations above a resource, as handlers.AncestorPathValue does.
+func ancestorParameter(level int) string {
+	if level == 1 {
+		return "parent_id"
+	}
+	return fmt.Sprintf("ancestor_%d_id", level)
+}
+
+// setPath adds a path unless the document already describes it, under any parameter names.
+func setPath(doc *openapi3.T, path string, item *openapi3.PathItem) {
+	if doc.Paths.Find(path) != nil {
+		return
+	}
+	doc.Paths.Set(path, item)
+}
+
+// operation returns an operation of kind d whose errors are problem details.
+func operation(d registry.EntityDescriptor, id, summary string) *openapi3.Operation {
+	op := openapi3.NewOperation()
+	op.OperationID = id
+	op.Summary = summary
+	op.Tags = []string{d.Kind}
+	op.AddResponse(0, openapi3.NewResponse().
+		WithDescription("An error, described as RFC 9457 problem details").
+		WithContent(openapi3.Content{
+			"application/problem+json": openapi3.NewMediaType().WithSchemaRef(componentRef("ProblemDetails")),
+		}))
+	return op
+}
+
+func jsonResponse(description, schema string) *openapi3.Response {
+	return openapi3.NewResponse().WithDescription(description).WithJSONSchemaRef(componentRef(schema))
+}
+
+func jsonRequestBody(schema string) *openapi3.RequestBodyRef {
+	return &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(componentRef(schema)),
+	}
+}
+
+func listParameters() openapi3.Parameters {
+	return openapi3.Parameters{
+		queryParameter("page", "Page number, starting at 1", openapi3.NewIntegerSchema()),
+		queryParameter("size", "Number of items per page", openapi3.NewIntegerSchema()),
+		queryParameter("search", "TSL search expression", openapi3.NewStringSchema()),
+		queryParameter("labelSelector", "Kubernetes-style label selector, e.g. `env=prod,tier in (web)`",
+			openapi3.NewStringSchema()),
+		queryParameter("order", "Sort order, e.g. `created_time desc` or `labels.region,spec.replicas desc`",
+			openapi3.NewStringSchema()),
+		queryParameter("fields",
+			"Comma-separated fields to return, e.g. `name,spec.release.version,status.conditions[type=Reconciled]`",
+			openapi3.NewStringSchema()),
+		queryParameter("continue", "Continue token from the previous page", openapi3.NewStringSchema()),
+		queryParameter("count", "Whether to compute the total", openapi3.NewBoolSchema()),
+		queryParameter("ref_type", "Only resources with a reference of this type", openapi3.NewStringSchema()),
+		queryParameter("ref_target_id", "Only resources referencing this ID", openapi3.NewStringSchema()),
+	}
+}
+
+func pathParameter(name, description string) *openapi3.ParameterRef {
+	return &openapi3.ParameterRef{
+		Value: openapi3.NewPathParameter(name).WithDescription(description).WithSchema(openapi3.NewStringSchema()),
+	}
+}
+
+func queryParameter(name, description string, schema *openapi3.Schema) *openapi3.ParameterRef {
+	return &openapi3.ParameterRef{
+		Value: openapi3.NewQueryParameter(name).WithDescription(description).WithSchema(schema),
+	}
+}
+
+func ifMatchParameter() *openapi3.ParameterRef {
+	return &openapi3.ParameterRef{
+		Value: openapi3.NewHeaderParameter("If-Match").
+			WithDescription("Apply only if the resource's ETag matches").
+			WithSchema(openapi3.NewStringSchema()),
+	}
+}
+
+func idempotencyKeyParameter() *openapi3.ParameterRef {
+	return &openapi3.ParameterRef{
+		Value: openapi3.NewHeaderParameter("Idempotency-Key").
+			WithDescription("Key under which the response is recorded and replayed for retries").
+			WithSchema(openapi3.NewStringSchema().WithMaxLength(255)),
+	}
+}
+
+func componentRef(name string) *openapi3.SchemaRef {
+	return openapi3.NewSchemaRef(componentSchemaPrefix+name, nil)
+}
+
+// pluralName is the plural used in operation IDs: "wifconfigs" of kind WifConfig
+// becomes "WifConfigs".
+func pluralName(d registry.EntityDescriptor) string {
+	if rest, ok := strings.CutPrefix(d.Plural, strings.ToLower(d.Kind)); ok {
+		return d.Kind + rest
+	}
+	return strings.ToUpper(d.Plural[:1]) + d.Plural[1:]
+}
@@ -0,0 +1,261 @@
+package apidoc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+)
+
+const testBaseSpec = `
+openapi: 3.0.0
+info:
+  title: Test Core Spec
+  version: 1.0.0
+paths:
+  /clusters/{cluster_id}:
+    get:
+      operationId: getClusterById
+      parameters:
+        - name: cluster_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: The cluster
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Cluster'
+components:
+  schemas:
+    Resource:
+      type: object
+      properties:
+        id:
+          type: string
+        kind:
+          type: string
+        spec:
+          type: object
+    ResourceCreateRequest:
+      type: object
+      properties:
+        kind:
+          type: string
+        name:
+          type: string
+    ResourcePatchRequest:
+      type: object
+    ResourceList:
+      type: object
+      properties:
+        items:
+          type: array
+          items:
+            $ref: '#/components/schemas/Resource'
+    Cluster:
+      type: object
+    ClusterSpec:
+      type: object
+    ObjectReference:
+      type: object
+      properties:
+        id:
+          type: string
+    ProblemDetails:
+      type: object
+    ForceDeleteRequest:
+      type: object
+    AdapterStatus:
+      type: object
+    AdapterStatusCreateRequest:
+      type: object
+    AdapterStatusList:
+      type: object
+`
+
+const testSpecSchemas = `
+openapi: 3.0.0
+info:
+  title: Test Deployment Schema
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ChannelSpec:
+      type: object
+      required: [display_name]
+      properties:
+        display_name:
+          type: string
+        mirror:
+          $ref: '#/components/schemas/Mirror'
+    Mirror:
+      type: object
+`
+
+var testDescriptors = []registry.EntityDescriptor{
+	{Kind: "Cluster", Plural: "clusters", SpecSchemaName: "ClusterSpec"},
+	{Kind: "Channel", Plural: "channels", SpecSchemaName: "ChannelSpec"},
+	{
+		Kind:       "Version",
+		Plural:     "versions",
+		ParentKind: "Channel",
+		References: []registry.ReferenceDescriptor{
+			{RefType: "image", TargetKind: "Image", Min: 1, Max: 1},
+		},
+	},
+}
+
+func loadTestSpec(t *testing.T, data string) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(data))
+	Expect(err).NotTo(HaveOccurred())
+	return doc
+}
+
+func extendTestSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	base := loadTestSpec(t, testBaseSpec)
+	specs := loadTestSpec(t, testSpecSchemas)
+	doc, err := Extend(base, specs.Components.Schemas, testDescriptors)
+	Expect(err).NotTo(HaveOccurred())
+	return doc
+}
+
+func TestExtend_ProducesValidDocument(t *testing.T) {
+	RegisterTestingT(t)
+
+	data, err := extendTestSpec(t).MarshalJSON()
+	Expect(err).NotTo(HaveOccurred())
+
+	reloaded, err := openapi3.NewLoader().LoadFromData(data)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(reloaded.Validate(context.Background())).To(Succeed())
+}
+
+func TestExtend_AddsPathsPerKind(t *testing.T) {
+	RegisterTestingT(t)
+
+	doc := extendTestSpec(t)
+
+	channels := doc.Paths.Value("/channels")
+	Expect(channels).NotTo(BeNil())
+	Expect(channels.Get.OperationID).To(Equal("getChannels"))
+	Expect(channels.Post.OperationID).To(Equal("postChannel"))
+	Expect(channels.Post.RequestBody.Value.Content.Get("application/json").Schema.Ref).
+		To(Equal("#/components/schemas/ChannelCreateRequest"))
+	Expect(doc.Paths.Value("/channels/{id}/force-delete").Post).NotTo(BeNil())
+
+	nested := doc.Paths.Value("/channels/{parent_id}/versions")
+	Expect(nested).NotTo(BeNil())
+	Expect(nested.Post.OperationID).To(Equal("postVersionOfChannel"))
+	Expect(doc.Paths.Value("/channels/{parent_id}/versions/{id}/statuses").Put).NotTo(BeNil())
+
+	flat := doc.Paths.Value("/versions")
+	Expect(flat).NotTo(BeNil())
+	Expect(flat.Get).NotTo(BeNil())
+	Expect(flat.Post).To(BeNil(), "child kinds are created under their parent")
+	Expect(doc.Paths.Value("/versions/{id}").Patch.OperationID).To(Equal("patchVersionById"))
+}
+
+func TestExtend_LeavesCoreKindsAlone(t *testing.T) {
+	RegisterTestingT(t)
+
+	doc := extendTestSpec(t)
+
+	Expect(doc.Paths.Value("/clusters")).To(BeNil())
+	Expect(doc.Paths.Value("/clusters/{id}")).To(BeNil())
+	Expect(doc.Paths.Value("/clusters/{cluster_id}")).NotTo(BeNil())
+	Expect(doc.Components.Schemas).NotTo(HaveKey("ClusterCreateRequest"))
+}
+
+func TestExtend_TypesSpecAndReferences(t *testing.T) {
+	RegisterTestingT(t)
+
+	doc := extendTestSpec(t)
+
+	Expect(doc.Components.Schemas["ChannelSpec"].Value.Required).To(ConsistOf("display_name"))
+	Expect(doc.Components.Schemas).To(HaveKey("Mirror"), "schemas referenced by spec schemas are copied")
+
+	channel := doc.Components.Schemas["Channel"].Value
+	Expect(channel.AllOf).To(HaveLen(2))
+	Expect(channel.AllOf[0].Ref).To(Equal("#/components/schemas/Resource"))
+	properties := channel.AllOf[1].Value.Properties
+	Expect(properties["kind"].Value.Enum).To(ConsistOf("Channel"))
+	Expect(properties["spec"].Ref).To(Equal("#/components/schemas/ChannelSpec"))
+
+	create := doc.Components.Schemas["VersionCreateRequest"].Value.AllOf[1].Value
+	Expect(create.Required).To(ConsistOf("references", "spec"))
+	Expect(create.Properties["spec"].Value.Type.Is(openapi3.TypeObject)).To(BeTrue())
+	image := create.Properties["references"].Value.Properties["image"].Value
+	Expect(image.MinItems).To(Equal(uint64(1)))
+	Expect(*image.MaxItems).To(Equal(uint64(1)))
+	Expect(create.Properties["references"].Value.Required).To(ConsistOf("image"))
+
+	patch := doc.Components.Schemas["VersionPatchRequest"].Value.AllOf[1].Value
+	Expect(patch.Properties).NotTo(HaveKey("kind"))
+	Expect(patch.Required).To(BeEmpty())
+}
+
+func TestExtend_DoesNotModifyBase(t *testing.T) {
+	RegisterTestingT(t)
+
+	base := loadTestSpec(t, testBaseSpec)
+	_, err := Extend(base, nil, testDescriptors)
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(base.Paths.Value("/channels")).To(BeNil())
+	Expect(base.Components.Schemas).NotTo(HaveKey("Channel"))
+}
+
+func TestExtend_DeepHierarchy(t *testing.T) {
+	RegisterTestingT(t)
+
+	doc, err := Extend(loadTestSpec(t, testBaseSpec), nil, []registry.EntityDescriptor{
+		{Kind: "Region", Plural: "regions"},
+		{Kind: "Zone", Plural: "zones", ParentKind: "Region"},
+		{Kind: "Rack", Plural: "racks", ParentKind: "Zone"},
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	deep := doc.Paths.Value("/regions/{ancestor_2_id}/zones/{parent_id}/racks")
+	Expect(deep).NotTo(BeNil())
+	Expect(deep.Post.OperationID).To(Equal("postRackOfZoneOfRegion"))
+	Expect(deep.Parameters).To(HaveLen(2))
+	Expect(doc.Paths.Value("/regions/{ancestor_2_id}/zones/{parent_id}/racks/{id}/statuses")).NotTo(BeNil())
+	Expect(doc.Paths.Value("/zones/{parent_id}/racks/{id}")).NotTo(BeNil())
+
+	descendants := doc.Paths.Value("/regions/{ancestor_id}/racks")
+	Expect(descendants).NotTo(BeNil())
+	Expect(descendants.Get.OperationID).To(Equal("getRacksOfRegion"))
+	Expect(descendants.Post).To(BeNil())
+
+	data, err := doc.MarshalJSON()
+	Expect(err).NotTo(HaveOccurred())
+	reloaded, err := openapi3.NewLoader().LoadFromData(data)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(reloaded.Validate(context.Background())).To(Succeed())
+}
+
+func TestExtend_UnknownParentKind(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := Extend(loadTestSpec(t, testBaseSpec), nil, []registry.EntityDescriptor{
+		{Kind: "Version", Plural: "versions", ParentKind: "Channel"},
+	})
+	Expect(err).To(MatchError(ContainSubstring(`unknown parent kind "Channel"`)))
+}
+
+func TestPluralName(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(pluralName(registry.EntityDescriptor{Kind: "WifConfig", Plural: "wifconfigs"})).To(Equal("WifConfigs"))
+	Expect(pluralName(registry.EntityDescriptor{Kind: "Policy", Plural: "policies"})).To(Equal("Policies"))
+}
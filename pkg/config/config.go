@@ -11,6 +11,7 @@ type ApplicationConfig struct {
 	Database *DatabaseConfig             `mapstructure:"database" json:"database" validate:"required"`
 	Logging  *LoggingConfig              `mapstructure:"logging" json:"logging" validate:"required"`
 	Tracing  *TracingConfig              `mapstructure:"tracing" json:"tracing" validate:"required"`
+	Events   *EventsConfig               `mapstructure:"events" json:"events" validate:"required"`
 	Entities []registry.EntityDescriptor `mapstructure:"entities" json:"entities"`
 }
 
@@ -24,5 +25,6 @@ func NewApplicationConfig() *ApplicationConfig {
 		Database: NewDatabaseConfig(),
 		Logging:  NewLoggingConfig(),
 		Tracing:  NewTracingConfig(),
+		Events:   NewEventsConfig(),
 	}
 }
@@ -33,6 +33,9 @@ func DumpConfig(config *ApplicationConfig) string {
   Tracing:
     Enabled: %t
     ServiceName: %s
+  Events:
+    Enabled: %t
+    Sink: %s
   Metrics:
     BindAddress: %s
   Health:
@@ -54,6 +57,8 @@ func DumpConfig(config *ApplicationConfig) string {
 		config.Logging.Format,
 		config.Tracing.Enabled,
 		config.Tracing.ServiceName,
+		config.Events.Enabled,
+		config.Events.Sink,
 		config.Metrics.BindAddress(),
 		config.Health.BindAddress(),
 		len(config.Entities),
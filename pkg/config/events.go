@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Event sink types
+const (
+	EventSinkWebhook = "webhook"
+	EventSinkFile    = "file"
+)
+
+// EventsConfig holds CloudEvents publishing configuration. When enabled, resource writes
+// queue events in the outbox table and a dispatcher delivers them to the sink.
+type EventsConfig struct {
+	Sink         string             `mapstructure:"sink" json:"sink"`
+	Source       string             `mapstructure:"source" json:"source"`
+	File         EventFileConfig    `mapstructure:"file" json:"file"`
+	Webhook      EventWebhookConfig `mapstructure:"webhook" json:"webhook"`
+	Retry        EventRetryConfig   `mapstructure:"retry" json:"retry"`
+	PollInterval time.Duration      `mapstructure:"poll_interval" json:"poll_interval"`
+	BatchSize    int                `mapstructure:"batch_size" json:"batch_size"`
+	Enabled      bool               `mapstructure:"enabled" json:"enabled"`
+}
+
+// EventWebhookConfig configures the HTTP webhook sink
+type EventWebhookConfig struct {
+	URL     string        `mapstructure:"url" json:"url"`
+	Timeout time.Duration `mapstructure:"timeout" json:"timeout"`
+}
+
+// EventFileConfig configures the file sink, which appends one event per line
+type EventFileConfig struct {
+	Path string `mapstructure:"path" json:"path"`
+}
+
+// EventRetryConfig bounds the exponential backoff between delivery attempts
+type EventRetryConfig struct {
+	InitialBackoff time.Duration `mapstructure:"initial_backoff" json:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff" json:"max_backoff"`
+}
+
+// Validate validates events configuration. Nothing is checked while events are disabled.
+func (c *EventsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Source == "" {
+		return fmt.Errorf("events source is required when events are enabled")
+	}
+	switch c.Sink {
+	case EventSinkWebhook:
+		u, err := url.Parse(c.Webhook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("events webhook url must be an absolute http(s) URL, got %q", c.Webhook.URL)
+		}
+		if c.Webhook.Timeout < 1*time.Second {
+			return fmt.Errorf("events webhook timeout must be at least 1 second, got %v", c.Webhook.Timeout)
+		}
+	case EventSinkFile:
+		if c.File.Path == "" {
+			return fmt.Errorf("events file path is required when sink is %q", EventSinkFile)
+		}
+	default:
+		return fmt.Errorf("events sink must be one of [%s %s], got %q", EventSinkWebhook, EventSinkFile, c.Sink)
+	}
+	if c.PollInterval < 100*time.Millisecond {
+		return fmt.Errorf("events poll interval must be at least 100ms, got %v", c.PollInterval)
+	}
+	if c.BatchSize < 1 || c.BatchSize > 1000 {
+		return fmt.Errorf("events batch size must be between 1 and 1000, got %d", c.BatchSize)
+	}
+	if c.Retry.InitialBackoff < 100*time.Millisecond {
+		return fmt.Errorf("events retry initial backoff must be at least 100ms, got %v", c.Retry.InitialBackoff)
+	}
+	if c.Retry.MaxBackoff < c.Retry.InitialBackoff {
+		return fmt.Errorf("events retry max backoff (%v) must not be less than initial backoff (%v)",
+			c.Retry.MaxBackoff, c.Retry.InitialBackoff)
+	}
+	return nil
+}
+
+// NewEventsConfig returns default EventsConfig values
+// These defaults can be overridden by config file, env vars, or CLI flags
+func NewEventsConfig() *EventsConfig {
+	return &EventsConfig{
+		Enabled:      false,
+		Sink:         EventSinkWebhook,
+		Source:       "hyperfleet-api",
+		PollInterval: 1 * time.Second,
+		BatchSize:    100,
+		Webhook: EventWebhookConfig{
+			Timeout: 10 * time.Second,
+		},
+		Retry: EventRetryConfig{
+			InitialBackoff: 1 * time.Second,
+			MaxBackoff:     5 * time.Minute,
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEventsConfig_Validate(t *testing.T) {
+	RegisterTestingT(t)
+
+	webhook := func(mutate func(*EventsConfig)) EventsConfig {
+		c := *NewEventsConfig()
+		c.Enabled = true
+		c.Webhook.URL = "https://events.example.com/hooks/hyperfleet"
+		if mutate != nil {
+			mutate(&c)
+		}
+		return c
+	}
+
+	cases := []struct {
+		name      string
+		expectErr string
+		config    EventsConfig
+	}{
+		{
+			name:   "defaults are valid while disabled",
+			config: *NewEventsConfig(),
+		},
+		{
+			name:   "webhook sink with url passes",
+			config: webhook(nil),
+		},
+		{
+			name: "file sink with path passes",
+			config: webhook(func(c *EventsConfig) {
+				c.Sink = EventSinkFile
+				c.File.Path = "/var/run/hyperfleet/events.jsonl"
+			}),
+		},
+		{
+			name:      "webhook sink without url fails",
+			config:    webhook(func(c *EventsConfig) { c.Webhook.URL = "" }),
+			expectErr: "webhook url must be an absolute http(s) URL",
+		},
+		{
+			name:      "webhook sink with relative url fails",
+			config:    webhook(func(c *EventsConfig) { c.Webhook.URL = "/hooks" }),
+			expectErr: "webhook url must be an absolute http(s) URL",
+		},
+		{
+			name:      "file sink without path fails",
+			config:    webhook(func(c *EventsConfig) { c.Sink = EventSinkFile }),
+			expectErr: "file path is required",
+		},
+		{
+			name:      "unknown sink fails",
+			config:    webhook(func(c *EventsConfig) { c.Sink = "kafka" }),
+			expectErr: "sink must be one of",
+		},
+		{
+			name:      "empty source fails",
+			config:    webhook(func(c *EventsConfig) { c.Source = "" }),
+			expectErr: "source is required",
+		},
+		{
+			name:      "batch size out of range fails",
+			config:    webhook(func(c *EventsConfig) { c.BatchSize = 0 }),
+			expectErr: "batch size must be between 1 and 1000",
+		},
+		{
+			name:      "poll interval too short fails",
+			config:    webhook(func(c *EventsConfig) { c.PollInterval = 10 * time.Millisecond }),
+			expectErr: "poll interval must be at least 100ms",
+		},
+		{
+			name:      "max backoff below initial fails",
+			config:    webhook(func(c *EventsConfig) { c.Retry.MaxBackoff = 500 * time.Millisecond }),
+			expectErr: "max backoff",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			err := tc.config.Validate()
+			if tc.expectErr != "" {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(tc.expectErr))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
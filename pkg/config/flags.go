@@ -25,6 +25,12 @@ func AddServerFlags(cmd *cobra.Command) {
 		"Path to OpenAPI schema for spec validation (API will fail to start if file is missing or invalid)")
 	cmd.Flags().Duration("server-read-timeout", defaults.Timeouts.Read, "HTTP server read timeout")
 	cmd.Flags().Duration("server-write-timeout", defaults.Timeouts.Write, "HTTP server write timeout")
+	cmd.Flags().Duration("server-watch-heartbeat", defaults.Watch.Heartbeat,
+		"Interval between keep-alive bookmarks on idle watch streams")
+	cmd.Flags().Duration("server-watch-retention", defaults.Watch.Retention,
+		"How long resource changes are kept for resuming watch streams")
+	cmd.Flags().Duration("server-idempotency-ttl", defaults.Idempotency.TTL,
+		"How long responses to requests with an Idempotency-Key are kept for replay")
 	cmd.Flags().String("server-https-cert-file", defaults.TLS.CertFile, "Path to TLS certificate file")
 	cmd.Flags().String("server-https-key-file", defaults.TLS.KeyFile, "Path to TLS key file")
 	cmd.Flags().Bool("server-https-enabled", defaults.TLS.Enabled, "Enable HTTPS rather than HTTP")
@@ -170,6 +170,12 @@ func (l *ConfigLoader) validateConfig(config *ApplicationConfig) error {
 		if valErr := config.Server.Timeouts.Validate(); valErr != nil {
 			return fmt.Errorf("server timeouts validation failed: %w", valErr)
 		}
+		if valErr := config.Server.Watch.Validate(); valErr != nil {
+			return fmt.Errorf("server watch validation failed: %w", valErr)
+		}
+		if valErr := config.Server.Idempotency.Validate(); valErr != nil {
+			return fmt.Errorf("server idempotency validation failed: %w", valErr)
+		}
 		if valErr := config.Server.TLS.Validate(); valErr != nil {
 			return fmt.Errorf("server TLS validation failed: %w", valErr)
 		}
@@ -196,6 +202,9 @@ func (l *ConfigLoader) validateConfig(config *ApplicationConfig) error {
 		if valErr := config.Tracing.Validate(); valErr != nil {
 			return fmt.Errorf("tracing config validation failed: %w", valErr)
 		}
+		if valErr := config.Events.Validate(); valErr != nil {
+			return fmt.Errorf("events config validation failed: %w", valErr)
+		}
 		return nil
 	}
 
@@ -296,6 +305,9 @@ func (l *ConfigLoader) bindAllEnvVars() {
 	l.bindEnv("server.openapi_schema_path")
 	l.bindEnv("server.timeouts.read")
 	l.bindEnv("server.timeouts.write")
+	l.bindEnv("server.watch.heartbeat")
+	l.bindEnv("server.watch.retention")
+	l.bindEnv("server.idempotency.ttl")
 	l.bindEnv("server.tls.enabled")
 	l.bindEnv("server.tls.cert_file")
 	l.bindEnv("server.tls.key_file")
@@ -359,6 +371,18 @@ func (l *ConfigLoader) bindAllEnvVars() {
 		panic(fmt.Sprintf("bind env %q: %v", "tracing.service_name", err))
 	}
 
+	// Events config
+	l.bindEnv("events.enabled")
+	l.bindEnv("events.sink")
+	l.bindEnv("events.source")
+	l.bindEnv("events.poll_interval")
+	l.bindEnv("events.batch_size")
+	l.bindEnv("events.webhook.url")
+	l.bindEnv("events.webhook.timeout")
+	l.bindEnv("events.file.path")
+	l.bindEnv("events.retry.initial_backoff")
+	l.bindEnv("events.retry.max_backoff")
+
 	// Entities: config-file-only (complex list-of-struct type).
 	// No env var or CLI flag bindings — loaded exclusively via YAML config.
 }
@@ -379,6 +403,9 @@ func (l *ConfigLoader) bindFlags(cmd *cobra.Command) {
 	l.bindPFlag("server.openapi_schema_path", cmd.Flags().Lookup("server-openapi-schema-path"))
 	l.bindPFlag("server.timeouts.read", cmd.Flags().Lookup("server-read-timeout"))
 	l.bindPFlag("server.timeouts.write", cmd.Flags().Lookup("server-write-timeout"))
+	l.bindPFlag("server.watch.heartbeat", cmd.Flags().Lookup("server-watch-heartbeat"))
+	l.bindPFlag("server.watch.retention", cmd.Flags().Lookup("server-watch-retention"))
+	l.bindPFlag("server.idempotency.ttl", cmd.Flags().Lookup("server-idempotency-ttl"))
 	l.bindPFlag("server.tls.cert_file", cmd.Flags().Lookup("server-https-cert-file"))
 	l.bindPFlag("server.tls.key_file", cmd.Flags().Lookup("server-https-key-file"))
 	l.bindPFlag("server.tls.enabled", cmd.Flags().Lookup("server-https-enabled"))
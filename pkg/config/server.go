@@ -15,14 +15,16 @@ import (
 // ServerConfig holds HTTP/HTTPS server configuration
 // Follows HyperFleet Configuration Standard
 type ServerConfig struct {
-	Hostname          string         `mapstructure:"hostname" json:"hostname" validate:"omitempty,hostname|ip"`
-	Host              string         `mapstructure:"host" json:"host" validate:"required,hostname|ip"`
-	OpenAPISchemaPath string         `mapstructure:"openapi_schema_path" json:"openapi_schema_path"`
-	TLS               TLSConfig      `mapstructure:"tls" json:"tls" validate:"required"`
-	JWT               JWTConfig      `mapstructure:"jwt" json:"jwt" validate:"required"`
-	Tenant            TenantConfig   `mapstructure:"tenant" json:"tenant" validate:"required"`
-	Timeouts          TimeoutsConfig `mapstructure:"timeouts" json:"timeouts" validate:"required"`
-	Port              int            `mapstructure:"port" json:"port" validate:"required,min=1,max=65535"`
+	Hostname          string            `mapstructure:"hostname" json:"hostname" validate:"omitempty,hostname|ip"`
+	Host              string            `mapstructure:"host" json:"host" validate:"required,hostname|ip"`
+	OpenAPISchemaPath string            `mapstructure:"openapi_schema_path" json:"openapi_schema_path"`
+	TLS               TLSConfig         `mapstructure:"tls" json:"tls" validate:"required"`
+	JWT               JWTConfig         `mapstructure:"jwt" json:"jwt" validate:"required"`
+	Tenant            TenantConfig      `mapstructure:"tenant" json:"tenant" validate:"required"`
+	Timeouts          TimeoutsConfig    `mapstructure:"timeouts" json:"timeouts" validate:"required"`
+	Watch             WatchConfig       `mapstructure:"watch" json:"watch" validate:"required"`
+	Idempotency       IdempotencyConfig `mapstructure:"idempotency" json:"idempotency" validate:"required"`
+	Port              int               `mapstructure:"port" json:"port" validate:"required,min=1,max=65535"`
 }
 
 // TimeoutsConfig holds HTTP timeout configuration
@@ -42,6 +44,36 @@ func (c *TimeoutsConfig) Validate() error {
 	return nil
 }
 
+// WatchConfig holds watch stream configuration
+type WatchConfig struct {
+	Heartbeat time.Duration `mapstructure:"heartbeat" json:"heartbeat" validate:"required"`
+	Retention time.Duration `mapstructure:"retention" json:"retention" validate:"required"`
+}
+
+// Validate validates watch durations
+func (c *WatchConfig) Validate() error {
+	if c.Heartbeat < 1*time.Second {
+		return fmt.Errorf("watch heartbeat must be at least 1 second, got %v", c.Heartbeat)
+	}
+	if c.Retention < 1*time.Minute {
+		return fmt.Errorf("watch retention must be at least 1 minute, got %v", c.Retention)
+	}
+	return nil
+}
+
+// IdempotencyConfig holds Idempotency-Key configuration
+type IdempotencyConfig struct {
+	TTL time.Duration `mapstructure:"ttl" json:"ttl" validate:"required"`
+}
+
+// Validate validates the idempotency key TTL
+func (c *IdempotencyConfig) Validate() error {
+	if c.TTL < 1*time.Minute {
+		return fmt.Errorf("idempotency ttl must be at least 1 minute, got %v", c.TTL)
+	}
+	return nil
+}
+
 // TLSConfig holds TLS configuration
 type TLSConfig struct {
 	CertFile string `mapstructure:"cert_file" json:"cert_file" validate:"omitempty,filepath"`
@@ -186,6 +218,13 @@ func NewServerConfig() *ServerConfig {
 			Read:  5 * time.Second,
 			Write: 30 * time.Second,
 		},
+		Watch: WatchConfig{
+			Heartbeat: 15 * time.Second,
+			Retention: 24 * time.Hour,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL: 24 * time.Hour,
+		},
 		TLS: TLSConfig{
 			Enabled:  false,
 			CertFile: "",
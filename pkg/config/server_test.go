@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 )
@@ -328,3 +329,35 @@ func TestTimeoutsConfig_Validate(t *testing.T) {
 		Expect(cfg.Validate()).To(HaveOccurred())
 	})
 }
+
+func TestWatchConfig_Validate(t *testing.T) {
+	RegisterTestingT(t)
+
+	t.Run("defaults pass", func(t *testing.T) {
+		RegisterTestingT(t)
+		cfg := NewServerConfig().Watch
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	t.Run("heartbeat too short fails", func(t *testing.T) {
+		RegisterTestingT(t)
+		cfg := WatchConfig{Heartbeat: 500 * time.Millisecond, Retention: time.Hour}
+		Expect(cfg.Validate()).To(HaveOccurred())
+	})
+
+	t.Run("retention too short fails", func(t *testing.T) {
+		RegisterTestingT(t)
+		cfg := WatchConfig{Heartbeat: 15 * time.Second, Retention: 30 * time.Second}
+		Expect(cfg.Validate()).To(HaveOccurred())
+	})
+}
+
+func TestIdempotencyConfig_Validate(t *testing.T) {
+	RegisterTestingT(t)
+
+	cfg := NewServerConfig().Idempotency
+	Expect(cfg.Validate()).To(Succeed())
+
+	cfg = IdempotencyConfig{TTL: 30 * time.Second}
+	Expect(cfg.Validate()).To(HaveOccurred())
+}
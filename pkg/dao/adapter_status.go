@@ -17,9 +17,6 @@ type AdapterStatusDao interface {
 	DeleteByResource(ctx context.Context, resourceType, resourceID string) error
 	FindByResource(ctx context.Context, resourceType, resourceID string) (api.AdapterStatusList, error)
 	FindByResourceIDs(ctx context.Context, resourceType string, resourceIDs []string) (api.AdapterStatusList, error)
-	FindByResourcePaginated(
-		ctx context.Context, resourceType, resourceID string, offset, limit int,
-	) (api.AdapterStatusList, int64, error)
 	FindByResourceAndAdapter(
 		ctx context.Context, resourceType, resourceID, adapter string,
 	) (*api.AdapterStatus, error)
@@ -149,29 +146,6 @@ func (d *sqlAdapterStatusDao) FindByResourceIDs(
 	return statuses, nil
 }
 
-func (d *sqlAdapterStatusDao) FindByResourcePaginated(
-	ctx context.Context, resourceType, resourceID string, offset, limit int,
-) (api.AdapterStatusList, int64, error) {
-	g2 := d.sessionFactory.New(ctx)
-	statuses := api.AdapterStatusList{}
-	var total int64
-
-	// Base query
-	query := g2.Where("resource_type = ? AND resource_id = ?", resourceType, resourceID)
-
-	// Get total count for pagination metadata
-	if err := query.Model(&api.AdapterStatus{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Apply pagination using OFFSET and LIMIT
-	if err := query.Offset(offset).Limit(limit).Find(&statuses).Error; err != nil {
-		return nil, 0, err
-	}
-
-	return statuses, total, nil
-}
-
 func (d *sqlAdapterStatusDao) FindByResourceAndAdapter(
 	ctx context.Context, resourceType, resourceID, adapter string,
 ) (*api.AdapterStatus, error) {
@@ -2,11 +2,16 @@ package dao
 
 import (
 	"context"
+	"database/sql"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/jinzhu/inflection"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
 )
 
@@ -27,15 +32,19 @@ type GenericDao interface {
 
 	GetInstanceDao(ctx context.Context, model interface{}) GenericDao
 	Preload(preload string)
-	OrderBy(orderBy string)
+	OrderBy(orderBy string, values ...any)
 	Joins(sql string)
 	Group(sql string)
 	Where(where Where)
 	Count(model interface{}, total *int64) error
+	CountGroups(groups []Where, limit int) ([]api.GroupCount, error)
 	Validate(resourceList interface{}) error
 
 	GetTableName() string
 	GetTableRelation(fieldName string) (TableRelation, bool)
+	GetPrimaryKeys() []string
+	GetColumnValue(row interface{}, column string) (interface{}, bool)
+	GetExpressionValues(row interface{}, expressions []Where) ([]*string, error)
 }
 
 var _ GenericDao = &sqlGenericDao{}
@@ -73,8 +82,12 @@ func (d *sqlGenericDao) Preload(preload string) {
 	d.g2 = d.g2.Preload(preload)
 }
 
-func (d *sqlGenericDao) OrderBy(orderBy string) {
-	d.g2 = d.g2.Order(orderBy)
+func (d *sqlGenericDao) OrderBy(orderBy string, values ...any) {
+	if len(values) == 0 {
+		d.g2 = d.g2.Order(orderBy)
+		return
+	}
+	d.g2 = d.g2.Order(clause.OrderBy{Expression: clause.Expr{SQL: orderBy, Vars: values, WithoutParentheses: true}})
 }
 
 func (d *sqlGenericDao) Joins(sql string) {
@@ -102,6 +115,56 @@ func (d *sqlGenericDao) Count(model interface{}, total *int64) error {
 	return g2.Count(total).Error
 }
 
+// CountGroups counts the rows matching the current conditions per distinct value of
+// groups, each a parameterized SQL expression yielding text. It returns at most limit
+// groups, largest first and then ordered by their values, so a limit drops the smallest.
+func (d *sqlGenericDao) CountGroups(groups []Where, limit int) ([]api.GroupCount, error) {
+	selects := make([]string, 0, len(groups)+1)
+	positions := make([]string, 0, len(groups))
+	var values []any
+	for i, group := range groups {
+		selects = append(selects, group.sql)
+		positions = append(positions, strconv.Itoa(i+1))
+		values = append(values, group.values...)
+	}
+	selects = append(selects, "COUNT(*)")
+
+	// Group and order by position so that each expression's parameters are bound once.
+	g2 := d.g2.Select(strings.Join(selects, ", "), values...)
+	if len(positions) > 0 {
+		g2 = g2.Clauses(clause.GroupBy{Columns: []clause.Column{{Name: strings.Join(positions, ", "), Raw: true}}}).
+			Order("COUNT(*) DESC, " + strings.Join(positions, ", "))
+	}
+	rows, err := g2.Limit(limit).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []api.GroupCount
+	for rows.Next() {
+		groupValues := make([]sql.NullString, len(groups))
+		dest := make([]any, 0, len(groups)+1)
+		for i := range groupValues {
+			dest = append(dest, &groupValues[i])
+		}
+		var count int64
+		dest = append(dest, &count)
+		if scanErr := rows.Scan(dest...); scanErr != nil {
+			return nil, scanErr
+		}
+
+		group := api.GroupCount{Values: make([]*string, len(groups)), Count: count}
+		for i, v := range groupValues {
+			if v.Valid {
+				group.Values[i] = &v.String
+			}
+		}
+		counts = append(counts, group)
+	}
+	return counts, rows.Err()
+}
+
 // Gorm finishers (Take, First, Last, etc.) are not idempotent
 // Use a new session to execute these checks
 func (d *sqlGenericDao) Validate(resourceList interface{}) error {
@@ -115,6 +178,74 @@ func (d *sqlGenericDao) GetTableName() string {
 	return db.GetTableName(d.g2)
 }
 
+// GetPrimaryKeys returns the primary key columns of the model
+func (d *sqlGenericDao) GetPrimaryKeys() []string {
+	if d.g2.Statement.Parse(d.g2.Statement.Model) != nil || d.g2.Statement.Schema == nil {
+		return nil
+	}
+	return d.g2.Statement.Schema.PrimaryFieldDBNames
+}
+
+// GetColumnValue reads the value of a column from a row of the model, such as an element
+// of a fetched list. It reports false when the model has no such column.
+func (d *sqlGenericDao) GetColumnValue(row interface{}, column string) (interface{}, bool) {
+	if d.g2.Statement.Parse(d.g2.Statement.Model) != nil || d.g2.Statement.Schema == nil {
+		return nil, false
+	}
+	field := d.g2.Statement.Schema.LookUpField(column)
+	if field == nil || field.DBName != column {
+		return nil, false
+	}
+	value, _ := field.ValueOf(d.g2.Statement.Context, reflect.Indirect(reflect.ValueOf(row)))
+	return value, true
+}
+
+// GetExpressionValues reads the text value of each expression, a parameterized SQL
+// expression over the model's table, for a row of the model, such as an element of a
+// fetched list. NULL values are nil.
+func (d *sqlGenericDao) GetExpressionValues(row interface{}, expressions []Where) ([]*string, error) {
+	selects := make([]string, len(expressions))
+	var values []any
+	for i, expression := range expressions {
+		selects[i] = "CAST(" + expression.sql + " AS text)"
+		values = append(values, expression.values...)
+	}
+
+	g2 := d.g2.Session(&gorm.Session{NewDB: true}).Unscoped().Model(d.g2.Statement.Model).
+		Select(strings.Join(selects, ", "), values...)
+	for _, pk := range d.GetPrimaryKeys() {
+		value, _ := d.GetColumnValue(row, pk)
+		g2 = g2.Where(d.GetTableName()+"."+pk+" = ?", value)
+	}
+	rows, err := g2.Limit(1).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if rowsErr := rows.Err(); rowsErr != nil {
+			return nil, rowsErr
+		}
+		return nil, gorm.ErrRecordNotFound
+	}
+	scanned := make([]sql.NullString, len(expressions))
+	dest := make([]any, len(expressions))
+	for i := range scanned {
+		dest[i] = &scanned[i]
+	}
+	if scanErr := rows.Scan(dest...); scanErr != nil {
+		return nil, scanErr
+	}
+	result := make([]*string, len(expressions))
+	for i, v := range scanned {
+		if v.Valid {
+			result[i] = &v.String
+		}
+	}
+	return result, nil
+}
+
 // extract the relation from the api model
 func (d *sqlGenericDao) GetTableRelation(fieldName string) (TableRelation, bool) {
 	// try singular
@@ -0,0 +1,97 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+)
+
+type IdempotencyKeyDao interface {
+	// Claim inserts key, or takes over its row if that has expired, and reports whether
+	// it did. While another transaction holds an uncommitted claim on the same key, Claim
+	// blocks until that transaction ends.
+	Claim(ctx context.Context, key *api.IdempotencyKey) (bool, error)
+
+	// Get returns the row of a key.
+	Get(ctx context.Context, owner, key string) (*api.IdempotencyKey, error)
+
+	// Complete stores the response of a claimed key.
+	Complete(ctx context.Context, key *api.IdempotencyKey) error
+
+	// Release removes a claimed key.
+	Release(ctx context.Context, owner, key string) error
+
+	// DeleteExpired removes keys that expired before now and returns how many were removed.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+var _ IdempotencyKeyDao = &sqlIdempotencyKeyDao{}
+
+type sqlIdempotencyKeyDao struct {
+	sessionFactory db.SessionFactory
+}
+
+func NewIdempotencyKeyDao(sessionFactory db.SessionFactory) IdempotencyKeyDao {
+	return &sqlIdempotencyKeyDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlIdempotencyKeyDao) Claim(ctx context.Context, key *api.IdempotencyKey) (bool, error) {
+	g2 := d.sessionFactory.New(ctx)
+	result := g2.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "owner"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"request_hash", "status_code", "response_headers", "response_body", "created_time", "expires_time",
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "idempotency_keys.expires_time <= ?", Vars: []interface{}{key.CreatedTime}},
+		}},
+	}).Create(key)
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (d *sqlIdempotencyKeyDao) Get(ctx context.Context, owner, key string) (*api.IdempotencyKey, error) {
+	g2 := d.sessionFactory.New(ctx)
+	var row api.IdempotencyKey
+	if err := g2.Where("owner = ? AND key = ?", owner, key).Take(&row).Error; err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (d *sqlIdempotencyKeyDao) Complete(ctx context.Context, key *api.IdempotencyKey) error {
+	g2 := d.sessionFactory.New(ctx)
+	err := g2.Model(&api.IdempotencyKey{}).
+		Where("owner = ? AND key = ?", key.Owner, key.Key).
+		UpdateColumns(map[string]interface{}{
+			"status_code":      key.StatusCode,
+			"response_headers": key.ResponseHeaders,
+			"response_body":    key.ResponseBody,
+		}).Error
+	if err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlIdempotencyKeyDao) Release(ctx context.Context, owner, key string) error {
+	g2 := d.sessionFactory.New(ctx)
+	return g2.Where("owner = ? AND key = ?", owner, key).Delete(&api.IdempotencyKey{}).Error
+}
+
+func (d *sqlIdempotencyKeyDao) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	g2 := d.sessionFactory.New(ctx)
+	result := g2.Where("expires_time <= ?", now).Delete(&api.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
 )
 
@@ -33,7 +34,7 @@ func (g *genericDaoMock) Preload(preload string) {
 	g.preload = preload
 }
 
-func (g *genericDaoMock) OrderBy(orderBy string) {
+func (g *genericDaoMock) OrderBy(orderBy string, values ...any) {
 	g.orderBy = orderBy
 }
 
@@ -55,6 +56,11 @@ func (g *genericDaoMock) Count(model interface{}, total *int64) error {
 	return nil
 }
 
+func (g *genericDaoMock) CountGroups(groups []dao.Where, limit int) ([]api.GroupCount, error) {
+	// Mock implementation - returns no groups
+	return nil, nil
+}
+
 func (g *genericDaoMock) Validate(resourceList interface{}) error {
 	// Mock implementation - returns no error
 	return nil
@@ -69,3 +75,18 @@ func (g *genericDaoMock) GetTableRelation(fieldName string) (dao.TableRelation,
 	// Mock implementation - returns empty relation and false
 	return dao.TableRelation{}, false
 }
+
+func (g *genericDaoMock) GetPrimaryKeys() []string {
+	// Mock implementation - returns the id column every model has
+	return []string{"id"}
+}
+
+func (g *genericDaoMock) GetColumnValue(row interface{}, column string) (interface{}, bool) {
+	// Mock implementation - returns no value and false
+	return nil, false
+}
+
+func (g *genericDaoMock) GetExpressionValues(row interface{}, expressions []dao.Where) ([]*string, error) {
+	// Mock implementation - returns a NULL value per expression
+	return make([]*string, len(expressions)), nil
+}
@@ -2,7 +2,9 @@ package mocks
 
 import (
 	"context"
+	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
@@ -131,6 +133,10 @@ func (d *resourceDaoMock) FindReferencers(_ context.Context, _ string) ([]api.Re
 	return nil, nil
 }
 
+func (d *resourceDaoMock) ListReferencers(_ context.Context, _ string) ([]api.ResourceSummary, error) {
+	return nil, nil
+}
+
 func (d *resourceDaoMock) ClearTargetReferences(_ context.Context, _ string) error {
 	return nil
 }
@@ -138,3 +144,23 @@ func (d *resourceDaoMock) ClearTargetReferences(_ context.Context, _ string) err
 func (d *resourceDaoMock) FindSourceIDsByRef(_ context.Context, _, _ string) ([]string, error) {
 	return nil, nil
 }
+
+func (d *resourceDaoMock) UpdateStatusFields(_ context.Context, id string, fields datatypes.JSON) error {
+	for _, r := range d.resources {
+		if r.ID == id {
+			r.StatusFields = fields
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (d *resourceDaoMock) UpdateStatusTime(_ context.Context, id string, t time.Time) error {
+	for _, r := range d.resources {
+		if r.ID == id {
+			r.StatusUpdatedTime = &t
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
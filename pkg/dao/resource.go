@@ -3,7 +3,9 @@ package dao
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm/clause"
 
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
@@ -25,8 +27,11 @@ type ResourceDao interface {
 	GetByID(ctx context.Context, id string) (*api.Resource, error)
 	ReplaceReferences(ctx context.Context, sourceID string, refs []api.ResourceReference) error
 	FindReferencers(ctx context.Context, targetID string) ([]api.ResourceSummary, error)
+	ListReferencers(ctx context.Context, targetID string) ([]api.ResourceSummary, error)
 	ClearTargetReferences(ctx context.Context, targetID string) error
 	FindSourceIDsByRef(ctx context.Context, refType, targetID string) ([]string, error)
+	UpdateStatusFields(ctx context.Context, id string, fields datatypes.JSON) error
+	UpdateStatusTime(ctx context.Context, id string, t time.Time) error
 }
 
 var _ ResourceDao = &sqlResourceDao{}
@@ -42,7 +47,7 @@ func NewResourceDao(sessionFactory db.SessionFactory) ResourceDao {
 func (d *sqlResourceDao) Get(ctx context.Context, kind, id string) (*api.Resource, error) {
 	g2 := d.sessionFactory.New(ctx)
 	var resource api.Resource
-	if err := g2.Preload("Conditions").Preload("Labels").Preload("References").
+	if err := g2.Scopes(tenantScoped(ctx)).Preload("Conditions").Preload("Labels").Preload("References").
 		Take(&resource, "kind = ? AND id = ?", kind, id).Error; err != nil {
 		return nil, err
 	}
@@ -52,7 +57,7 @@ func (d *sqlResourceDao) Get(ctx context.Context, kind, id string) (*api.Resourc
 func (d *sqlResourceDao) GetForUpdate(ctx context.Context, kind, id string) (*api.Resource, error) {
 	g2 := d.sessionFactory.New(ctx)
 	var resource api.Resource
-	if err := g2.Clauses(clause.Locking{Strength: "UPDATE"}).
+	if err := g2.Scopes(tenantScoped(ctx)).Clauses(clause.Locking{Strength: "UPDATE"}).
 		Preload("Conditions").Preload("Labels").Preload("References").
 		Take(&resource, "kind = ? AND id = ?", kind, id).Error; err != nil {
 		return nil, err
@@ -63,7 +68,7 @@ func (d *sqlResourceDao) GetForUpdate(ctx context.Context, kind, id string) (*ap
 func (d *sqlResourceDao) GetByOwner(ctx context.Context, kind, id, ownerID string) (*api.Resource, error) {
 	g2 := d.sessionFactory.New(ctx)
 	var resource api.Resource
-	if err := g2.Preload("Conditions").Preload("Labels").Preload("References").
+	if err := g2.Scopes(tenantScoped(ctx)).Preload("Conditions").Preload("Labels").Preload("References").
 		Take(&resource, "kind = ? AND id = ? AND owner_id = ?", kind, id, ownerID).Error; err != nil {
 		return nil, err
 	}
@@ -143,10 +148,12 @@ func (d *sqlResourceDao) FindByKind(ctx context.Context, kind string) (api.Resou
 	return resources, nil
 }
 
+// FindByKindAndOwner returns the kind resources owned by ownerID that the caller's tenant
+// can see.
 func (d *sqlResourceDao) FindByKindAndOwner(ctx context.Context, kind, ownerID string) (api.ResourceList, error) {
 	g2 := d.sessionFactory.New(ctx)
 	var resources api.ResourceList
-	if err := g2.Preload("Labels").Preload("Conditions").Preload("References").
+	if err := g2.Scopes(tenantScoped(ctx)).Preload("Labels").Preload("Conditions").Preload("References").
 		Where("kind = ? AND owner_id = ?", kind, ownerID).Find(&resources).Error; err != nil {
 		return nil, err
 	}
@@ -156,13 +163,15 @@ func (d *sqlResourceDao) FindByKindAndOwner(ctx context.Context, kind, ownerID s
 func (d *sqlResourceDao) GetByID(ctx context.Context, id string) (*api.Resource, error) {
 	g2 := d.sessionFactory.New(ctx)
 	var resource api.Resource
-	if err := g2.Preload("Conditions").Preload("Labels").Preload("References").
+	if err := g2.Scopes(tenantScoped(ctx)).Preload("Conditions").Preload("Labels").Preload("References").
 		Take(&resource, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
+// FindByKindAndOwnerForUpdate is not tenant-scoped: it drives cascade deletes of an
+// owner the caller was already allowed to load, and children must never be left orphaned.
 func (d *sqlResourceDao) FindByKindAndOwnerForUpdate(
 	ctx context.Context, kind, ownerID string,
 ) (api.ResourceList, error) {
@@ -196,17 +205,38 @@ func (d *sqlResourceDao) ReplaceReferences(
 	return nil
 }
 
-// FindReferencers returns the list of resources that references targetID,
-// or nil if none exists. Used as an existence check for 409 conflict responses.
+// FindReferencers returns one entry per inbound reference to targetID, or nil if none
+// exists. A source referencing the target under several ref types appears once per type.
+// Not tenant-scoped: a referencer owned by another tenant must still block the delete.
 func (d *sqlResourceDao) FindReferencers(
 	ctx context.Context, targetID string,
 ) ([]api.ResourceSummary, error) {
 	g2 := d.sessionFactory.New(ctx)
 	var summaries []api.ResourceSummary
 	err := g2.Model(&api.ResourceReference{}).
-		Select("resources.kind, resources.name").
+		Select("resources.id, resources.kind, resources.name, resource_references.ref_type").
+		Joins("JOIN resources ON resource_references.source_id = resources.id").
+		Where("resource_references.target_id = ? AND resources.deleted_time IS NULL", targetID).
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// ListReferencers is FindReferencers for presentation: it only returns referencers
+// visible to the caller's tenancy, with their href, ordered by ref type, kind and name.
+func (d *sqlResourceDao) ListReferencers(
+	ctx context.Context, targetID string,
+) ([]api.ResourceSummary, error) {
+	g2 := d.sessionFactory.New(ctx)
+	var summaries []api.ResourceSummary
+	err := g2.Model(&api.ResourceReference{}).
+		Select("resources.id, resources.kind, resources.name, resources.href, resource_references.ref_type").
 		Joins("JOIN resources ON resource_references.source_id = resources.id").
+		Scopes(tenantScoped(ctx)).
 		Where("resource_references.target_id = ? AND resources.deleted_time IS NULL", targetID).
+		Order("resource_references.ref_type, resources.kind, resources.name").
 		Scan(&summaries).Error
 	if err != nil {
 		return nil, err
@@ -215,7 +245,7 @@ func (d *sqlResourceDao) FindReferencers(
 }
 
 // ClearTargetReferences removes all inbound references pointing at targetID.
-// Called by forceDeleteResourceTree before hard-deleting a referenced target,
+// Called once on-target-delete policies have been applied to every referencer,
 // because the target_id FK uses ON DELETE RESTRICT.
 func (d *sqlResourceDao) ClearTargetReferences(ctx context.Context, targetID string) error {
 	g2 := d.sessionFactory.New(ctx)
@@ -238,3 +268,28 @@ func (d *sqlResourceDao) FindSourceIDsByRef(
 	}
 	return ids, nil
 }
+
+// UpdateStatusFields writes the projected status fields of a resource. Like condition
+// updates, it leaves updated_time alone: status is not a change to the resource itself.
+// Status changes are recorded by UpdateStatusTime instead.
+func (d *sqlResourceDao) UpdateStatusFields(ctx context.Context, id string, fields datatypes.JSON) error {
+	g2 := d.sessionFactory.New(ctx)
+	if err := g2.Model(&api.Resource{}).Where("id = ?", id).
+		UpdateColumn("status_fields", fields).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateStatusTime records when the conditions or status fields of a resource last
+// changed. The resource's ETag includes it, so conditional reads see status changes.
+func (d *sqlResourceDao) UpdateStatusTime(ctx context.Context, id string, t time.Time) error {
+	g2 := d.sessionFactory.New(ctx)
+	if err := g2.Model(&api.Resource{}).Where("id = ?", id).
+		UpdateColumn("status_updated_time", t).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
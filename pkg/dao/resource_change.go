@@ -0,0 +1,137 @@
+package dao
+
+import (
+	"context"
+	stderrors "errors"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+)
+
+// ResourceChangesChannel is the LISTEN/NOTIFY channel signalled when a transaction
+// that recorded resource changes commits. The payload is the changed resource's kind.
+const ResourceChangesChannel = "resource_changes"
+
+// resourceChangeColumns reads txid as a plain integer; xid8 has no cast to bigint.
+const resourceChangeColumns = "id, txid::text::bigint AS txid, type, kind, resource_id, owner_id, " +
+	"tenancy, object, created_time"
+
+// stableChanges limits a query to rows whose transactions, and every transaction
+// before them, have finished. See the resource_changes migration for why.
+const stableChanges = "txid < pg_snapshot_xmin(pg_current_snapshot())"
+
+// ChangeFilter narrows a change-log read. Empty fields match everything.
+type ChangeFilter struct {
+	Kind    string
+	OwnerID string
+}
+
+type ResourceChangeDao interface {
+	// Record appends a change and queues a notification on ResourceChangesChannel.
+	// Both take effect when the surrounding transaction commits.
+	Record(ctx context.Context, change *api.ResourceChange) error
+
+	// FindAfter returns up to limit committed changes after position, oldest first,
+	// restricted to filter and the caller's tenancy.
+	FindAfter(
+		ctx context.Context, after api.ChangePosition, filter ChangeFilter, limit int,
+	) ([]*api.ResourceChange, error)
+
+	// Head returns the position of the latest committed change, or the zero position
+	// when the log is empty.
+	Head(ctx context.Context) (api.ChangePosition, error)
+
+	// Oldest returns the position of the oldest retained change. ok is false when the
+	// log is empty.
+	Oldest(ctx context.Context) (position api.ChangePosition, ok bool, err error)
+
+	// DeleteBefore removes changes recorded before cutoff and returns how many were removed.
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+var _ ResourceChangeDao = &sqlResourceChangeDao{}
+
+type sqlResourceChangeDao struct {
+	sessionFactory db.SessionFactory
+}
+
+func NewResourceChangeDao(sessionFactory db.SessionFactory) ResourceChangeDao {
+	return &sqlResourceChangeDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlResourceChangeDao) Record(ctx context.Context, change *api.ResourceChange) error {
+	g2 := d.sessionFactory.New(ctx)
+	if err := g2.Create(change).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	if err := g2.Exec("SELECT pg_notify(?, ?)", ResourceChangesChannel, change.Kind).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlResourceChangeDao) FindAfter(
+	ctx context.Context, after api.ChangePosition, filter ChangeFilter, limit int,
+) ([]*api.ResourceChange, error) {
+	g2 := d.sessionFactory.New(ctx).
+		Select(resourceChangeColumns).
+		Where("(txid, id) > (?::text::xid8, ?)", strconv.FormatInt(after.TxID, 10), after.ID).
+		Where(stableChanges)
+	if filter.Kind != "" {
+		g2 = g2.Where("kind = ?", filter.Kind)
+	}
+	if filter.OwnerID != "" {
+		g2 = g2.Where("owner_id = ?", filter.OwnerID)
+	}
+	if where, ok := TenancyWhere(ctx, "resource_changes.tenancy"); ok {
+		g2 = g2.Where(where.sql, where.values...)
+	}
+
+	var changes []*api.ResourceChange
+	if err := g2.Order("txid, id").Limit(limit).Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (d *sqlResourceChangeDao) Head(ctx context.Context) (api.ChangePosition, error) {
+	g2 := d.sessionFactory.New(ctx)
+	var change api.ResourceChange
+	err := g2.Select(resourceChangeColumns).Where(stableChanges).
+		Order("txid DESC, id DESC").Take(&change).Error
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return api.ChangePosition{}, nil
+	}
+	if err != nil {
+		return api.ChangePosition{}, err
+	}
+	return change.Position(), nil
+}
+
+func (d *sqlResourceChangeDao) Oldest(ctx context.Context) (api.ChangePosition, bool, error) {
+	g2 := d.sessionFactory.New(ctx)
+	var change api.ResourceChange
+	err := g2.Select(resourceChangeColumns).Order("txid, id").Take(&change).Error
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return api.ChangePosition{}, false, nil
+	}
+	if err != nil {
+		return api.ChangePosition{}, false, err
+	}
+	return change.Position(), true, nil
+}
+
+func (d *sqlResourceChangeDao) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	g2 := d.sessionFactory.New(ctx)
+	result := g2.Where("created_time < ?", cutoff).Delete(&api.ResourceChange{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
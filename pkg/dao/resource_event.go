@@ -0,0 +1,32 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+)
+
+type ResourceEventDao interface {
+	// Record appends an audit event. It takes effect when the surrounding transaction commits.
+	Record(ctx context.Context, event *api.ResourceEvent) error
+}
+
+var _ ResourceEventDao = &sqlResourceEventDao{}
+
+type sqlResourceEventDao struct {
+	sessionFactory db.SessionFactory
+}
+
+func NewResourceEventDao(sessionFactory db.SessionFactory) ResourceEventDao {
+	return &sqlResourceEventDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlResourceEventDao) Record(ctx context.Context, event *api.ResourceEvent) error {
+	g2 := d.sessionFactory.New(ctx)
+	if err := g2.Create(event).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
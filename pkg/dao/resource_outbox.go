@@ -0,0 +1,70 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+)
+
+type ResourceOutboxDao interface {
+	// Enqueue inserts an event. It takes effect when the surrounding transaction commits.
+	Enqueue(ctx context.Context, event *api.OutboxEvent) error
+
+	// FindDue returns up to limit events whose next attempt is due, oldest first.
+	FindDue(ctx context.Context, limit int) ([]*api.OutboxEvent, error)
+
+	// Delete removes a delivered event.
+	Delete(ctx context.Context, id int64) error
+
+	// MarkFailed records a failed delivery and defers the next attempt by retryAfter.
+	MarkFailed(ctx context.Context, id int64, retryAfter time.Duration, lastError string) error
+}
+
+var _ ResourceOutboxDao = &sqlResourceOutboxDao{}
+
+type sqlResourceOutboxDao struct {
+	sessionFactory db.SessionFactory
+}
+
+func NewResourceOutboxDao(sessionFactory db.SessionFactory) ResourceOutboxDao {
+	return &sqlResourceOutboxDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlResourceOutboxDao) Enqueue(ctx context.Context, event *api.OutboxEvent) error {
+	g2 := d.sessionFactory.New(ctx)
+	if err := g2.Create(event).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlResourceOutboxDao) FindDue(ctx context.Context, limit int) ([]*api.OutboxEvent, error) {
+	g2 := d.sessionFactory.New(ctx)
+	var events []*api.OutboxEvent
+	err := g2.Where("next_attempt_time <= now()").Order("id").Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (d *sqlResourceOutboxDao) Delete(ctx context.Context, id int64) error {
+	g2 := d.sessionFactory.New(ctx)
+	return g2.Where("id = ?", id).Delete(&api.OutboxEvent{}).Error
+}
+
+func (d *sqlResourceOutboxDao) MarkFailed(
+	ctx context.Context, id int64, retryAfter time.Duration, lastError string,
+) error {
+	g2 := d.sessionFactory.New(ctx)
+	return g2.Model(&api.OutboxEvent{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"attempts":          gorm.Expr("attempts + 1"),
+		"last_error":        lastError,
+		"next_attempt_time": gorm.Expr("now() + make_interval(secs => ?)", retryAfter.Seconds()),
+	}).Error
+}
@@ -0,0 +1,44 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+)
+
+type ResourceRevisionDao interface {
+	// Record stores the revision of a generation. It takes effect when the surrounding transaction commits.
+	Record(ctx context.Context, revision *api.ResourceRevision) error
+	Get(ctx context.Context, resourceID string, generation int32) (*api.ResourceRevision, error)
+}
+
+var _ ResourceRevisionDao = &sqlResourceRevisionDao{}
+
+type sqlResourceRevisionDao struct {
+	sessionFactory db.SessionFactory
+}
+
+func NewResourceRevisionDao(sessionFactory db.SessionFactory) ResourceRevisionDao {
+	return &sqlResourceRevisionDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlResourceRevisionDao) Record(ctx context.Context, revision *api.ResourceRevision) error {
+	g2 := d.sessionFactory.New(ctx)
+	if err := g2.Create(revision).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlResourceRevisionDao) Get(
+	ctx context.Context, resourceID string, generation int32,
+) (*api.ResourceRevision, error) {
+	g2 := d.sessionFactory.New(ctx)
+	var revision api.ResourceRevision
+	if err := g2.Take(&revision, "resource_id = ? AND generation = ?", resourceID, generation).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
@@ -0,0 +1,34 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/tenant"
+)
+
+// TenancyWhere returns the predicate restricting column to rows visible to the caller's
+// tenant, and false when the caller is unscoped. A tenant without dimensions matches nothing.
+func TenancyWhere(ctx context.Context, column string) (Where, bool) {
+	scope, ok := tenant.ScopeJSON(ctx)
+	if !ok {
+		return Where{}, false
+	}
+	if len(scope) == 0 {
+		return NewWhere("1 = 0", nil), true
+	}
+	return NewWhere(column+" @> ?::jsonb", []any{string(scope)}), true
+}
+
+// tenantScoped is a gorm scope applying TenancyWhere to resources.tenancy.
+// Foreign rows are filtered out rather than rejected, so they surface as not found.
+func tenantScoped(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(g2 *gorm.DB) *gorm.DB {
+		where, ok := TenancyWhere(ctx, "resources.tenancy")
+		if !ok {
+			return g2
+		}
+		return g2.Where(where.sql, where.values...)
+	}
+}
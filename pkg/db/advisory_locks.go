@@ -20,6 +20,12 @@ const (
 
 	// MigrationsLockID is the advisory lock ID used for migration coordination
 	MigrationsLockID = "migrations"
+
+	// OutboxDispatch lock type for delivering events from the resource outbox
+	OutboxDispatch LockType = "OutboxDispatch"
+
+	// OutboxDispatchLockID is the advisory lock ID that lets one replica dispatch at a time
+	OutboxDispatchLockID = "outbox"
 )
 
 // AdvisoryLock represents a postgres advisory lock
@@ -19,6 +19,12 @@ const (
 	advisoryLock advisoryLockKey = "advisoryLock"
 )
 
+type dryRunKey string
+
+const (
+	dryRun dryRunKey = "dryRun"
+)
+
 type advisoryLockMap map[string]*AdvisoryLock
 
 func (m advisoryLockMap) key(id string, lockType LockType) string {
@@ -86,6 +92,29 @@ func MarkForRollback(ctx context.Context, err error) {
 	logger.WithError(ctx, err).Info("Marked transaction for rollback")
 }
 
+// IsMarkedForRollback reports whether the transaction stored in ctx is flagged for
+// rollback. Postgres aborts a transaction at its first failed statement, so after a
+// failed write every further statement in it fails as well.
+func IsMarkedForRollback(ctx context.Context) bool {
+	tx, ok := dbContext.Transaction(ctx)
+	return ok && tx.MarkedForRollback()
+}
+
+// WithDryRun returns a context for a dry-run request and flags its transaction for
+// rollback, so that every write the request makes is discarded. Side effects outside
+// the database, such as metrics, check IsDryRun and are skipped.
+func WithDryRun(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, dryRun, true)
+	MarkForRollback(ctx, errors.New("dry run"))
+	return ctx
+}
+
+// IsDryRun reports whether ctx belongs to a dry-run request.
+func IsDryRun(ctx context.Context) bool {
+	v, _ := ctx.Value(dryRun).(bool)
+	return v
+}
+
 // NewAdvisoryLockContext returns a new context with AdvisoryLock stored in it.
 // Upon error, the original context is still returned along with an error.
 //
@@ -164,7 +164,14 @@ func waitForNotification(l *pq.Listener, callback func(id string)) {
 	for {
 		select {
 		case n := <-l.Notify:
-			logger.With(ctx, logger.FieldChannel, n.Channel).With(logger.FieldData, n.Extra).Info("Received data from channel")
+			// pq sends nil after re-establishing a dropped connection; notifications sent
+			// meanwhile are lost, so wake the callback to let it re-check its source.
+			if n == nil {
+				logger.Info(ctx, "Listener reconnected, notifications may have been missed")
+				callback("")
+				return
+			}
+			logger.With(ctx, logger.FieldChannel, n.Channel).With(logger.FieldData, n.Extra).Debug("Received data from channel")
 			callback(n.Extra)
 			return
 		case <-time.After(10 * time.Second):
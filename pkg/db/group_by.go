@@ -0,0 +1,50 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// groupByColumns are the resource columns an aggregate can group by directly.
+var groupByColumns = map[string]bool{
+	"kind":     true,
+	"owner_id": true,
+}
+
+// GroupByToSQL resolves a groupBy field of an aggregate request to the SQL expression
+// yielding its text value for a row of tableName. Fields map as in TSLToSQL: kind and
+// owner_id are columns, labels.<key> and status.conditions.<Type> are the label value
+// and condition status subqueries, and spec.<path> is a JSONB text extraction. The
+// value is NULL for rows without the label, condition or spec field.
+func GroupByToSQL(field, tableName string) (string, []any, *errors.ServiceError) {
+	ctx := &walkContext{cfg: WalkConfig{TableName: tableName}}
+
+	var sql string
+	var values []any
+	var svcErr *errors.ServiceError
+	switch {
+	case groupByColumns[field]:
+		sql = tableName + "." + field
+	case prefixLabels(field):
+		sql, values, svcErr = resolveLabelColumn(field, ctx)
+	case prefixStatusConditions(field):
+		// Only a condition's status is categorical; its subfields are not.
+		if strings.Count(field, ".") > 2 {
+			return "", nil, errors.BadRequest(
+				"cannot group by %s: use status.conditions.<Type> without a subfield", field,
+			)
+		}
+		sql, values, svcErr = resolveStatusConditionColumn(field, ctx)
+	case prefixSpec(field):
+		sql, values, svcErr = resolveSpecColumn(field, ctx)
+	default:
+		return "", nil, errors.BadRequest(
+			"cannot group by %s: use kind, owner_id, labels.<key>, spec.<path> or status.conditions.<Type>", field,
+		)
+	}
+	if svcErr != nil {
+		return "", nil, svcErr
+	}
+	return "CAST(" + sql + " AS text)", values, nil
+}
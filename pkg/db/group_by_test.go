@@ -0,0 +1,70 @@
+package db
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGroupByToSQL(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  string
+		sql    string
+		values []any
+	}{
+		{name: "kind", field: "kind", sql: "CAST(resources.kind AS text)"},
+		{name: "owner", field: "owner_id", sql: "CAST(resources.owner_id AS text)"},
+		{
+			name:   "label",
+			field:  "labels.region",
+			sql:    "CAST(" + labelValueSubquery("resources") + " AS text)",
+			values: []any{"region"},
+		},
+		{
+			name:  "condition",
+			field: "status.conditions.Reconciled",
+			sql: "CAST((SELECT rc.status FROM resource_conditions rc " +
+				"WHERE rc.resource_id = resources.id AND rc.type = ?) AS text)",
+			values: []any{"Reconciled"},
+		},
+		{name: "spec", field: "spec.release.channel", sql: "CAST(spec->'release'->>'channel' AS text)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			sql, values, svcErr := GroupByToSQL(tt.field, "resources")
+			Expect(svcErr).To(BeNil())
+			Expect(sql).To(Equal(tt.sql))
+			Expect(values).To(Equal(tt.values))
+		})
+	}
+}
+
+func TestGroupByToSQL_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		message string
+	}{
+		{"Unsupported field", "name", "cannot group by name"},
+		{"Empty label key", "labels.", "label key cannot be empty"},
+		{"Condition subfield", "status.conditions.Reconciled.observed_generation", "without a subfield"},
+		{"Invalid condition type", "status.conditions.reconciled", "must be PascalCase"},
+		{"Invalid spec path", "spec.Region", "spec field segment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			_, _, svcErr := GroupByToSQL(tt.field, "resources")
+			Expect(svcErr).ToNot(BeNil())
+			Expect(svcErr.HTTPCode).To(Equal(http.StatusBadRequest))
+			Expect(svcErr.Error()).To(ContainSubstring(tt.message))
+		})
+	}
+}
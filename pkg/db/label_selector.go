@@ -0,0 +1,164 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// MaxLabelSelectorLength bounds the length of a labelSelector list parameter.
+const MaxLabelSelectorLength = 4096
+
+// Label selector operators, as written in a Kubernetes label selector.
+const (
+	selectorEquals    = "="
+	selectorNotEquals = "!="
+	selectorIn        = "in"
+	selectorNotIn     = "notin"
+	selectorExists    = "exists"
+	selectorNotExists = "!"
+)
+
+// selectorKeyPattern and selectorValuePattern accept the characters of Kubernetes label
+// keys (with an optional DNS prefix) and values. Full key validation happens on write.
+var (
+	selectorKeyPattern   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+	selectorValuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$`)
+	selectorSetPattern   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// labelRequirement is one comma-separated clause of a label selector.
+type labelRequirement struct {
+	key      string
+	operator string
+	values   []string
+}
+
+// LabelSelectorToSQL translates a Kubernetes-style label selector, such as
+// "env=prod,tier in (web, api),!canary", into a parameterized SQL WHERE fragment on
+// tableName. It supports =, ==, !=, in (...), notin (...), key existence and !key, and
+// resolves each label through the same resource_labels subquery TSLToSQL uses, so the
+// fragment combines with a search. As in Kubernetes, != and notin also match resources
+// without the label.
+func LabelSelectorToSQL(selector, tableName string) (string, []any, *errors.ServiceError) {
+	if len(selector) > MaxLabelSelectorLength {
+		return "", nil, errors.BadRequest(
+			"labelSelector exceeds maximum length of %d characters", MaxLabelSelectorLength,
+		)
+	}
+	requirements, svcErr := parseLabelSelector(selector)
+	if svcErr != nil {
+		return "", nil, svcErr
+	}
+
+	label := labelValueSubquery(tableName)
+	clauses := make([]string, 0, len(requirements))
+	var args []any
+	for _, req := range requirements {
+		args = append(args, req.key)
+		switch req.operator {
+		case selectorEquals:
+			clauses = append(clauses, label+" = ?")
+			args = append(args, req.values[0])
+		case selectorNotEquals:
+			clauses = append(clauses, label+" IS DISTINCT FROM ?")
+			args = append(args, req.values[0])
+		case selectorIn, selectorNotIn:
+			placeholders := make([]string, len(req.values))
+			for i, v := range req.values {
+				placeholders[i] = "?"
+				args = append(args, v)
+			}
+			if req.operator == selectorIn {
+				clauses = append(clauses, fmt.Sprintf("%s IN (%s)", label, strings.Join(placeholders, ", ")))
+			} else {
+				clauses = append(clauses,
+					fmt.Sprintf("COALESCE(%s NOT IN (%s), TRUE)", label, strings.Join(placeholders, ", ")))
+			}
+		case selectorExists:
+			clauses = append(clauses, label+" IS NOT NULL")
+		case selectorNotExists:
+			clauses = append(clauses, label+" IS NULL")
+		}
+	}
+	return "(" + strings.Join(clauses, ") AND (") + ")", args, nil
+}
+
+// parseLabelSelector splits a selector into its requirements. Commas inside the
+// parentheses of a set-based requirement do not separate requirements.
+func parseLabelSelector(selector string) ([]labelRequirement, *errors.ServiceError) {
+	var requirements []labelRequirement
+	depth, start := 0, 0
+	for i := 0; i <= len(selector); i++ {
+		if i < len(selector) {
+			switch selector[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				if depth < 0 {
+					return nil, errors.BadRequest("invalid labelSelector: unbalanced parentheses")
+				}
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		req, svcErr := parseLabelRequirement(strings.TrimSpace(selector[start:i]))
+		if svcErr != nil {
+			return nil, svcErr
+		}
+		requirements = append(requirements, req)
+		start = i + 1
+	}
+	if depth != 0 {
+		return nil, errors.BadRequest("invalid labelSelector: unbalanced parentheses")
+	}
+	return requirements, nil
+}
+
+func parseLabelRequirement(clause string) (labelRequirement, *errors.ServiceError) {
+	var req labelRequirement
+	switch {
+	case clause == "":
+		return req, errors.BadRequest("invalid labelSelector: empty requirement")
+	case strings.HasPrefix(clause, "!") && !strings.Contains(clause, "="):
+		req = labelRequirement{key: strings.TrimSpace(clause[1:]), operator: selectorNotExists}
+	case strings.Contains(clause, "!="):
+		key, value, _ := strings.Cut(clause, "!=")
+		req = labelRequirement{key: strings.TrimSpace(key), operator: selectorNotEquals, values: []string{value}}
+	case strings.Contains(clause, "=="):
+		key, value, _ := strings.Cut(clause, "==")
+		req = labelRequirement{key: strings.TrimSpace(key), operator: selectorEquals, values: []string{value}}
+	case strings.Contains(clause, "="):
+		key, value, _ := strings.Cut(clause, "=")
+		req = labelRequirement{key: strings.TrimSpace(key), operator: selectorEquals, values: []string{value}}
+	default:
+		if m := selectorSetPattern.FindStringSubmatch(clause); m != nil {
+			req = labelRequirement{key: m[1], operator: m[2], values: strings.Split(m[3], ",")}
+			if strings.TrimSpace(m[3]) == "" {
+				return req, errors.BadRequest("invalid labelSelector: %q needs at least one value", clause)
+			}
+		} else {
+			req = labelRequirement{key: clause, operator: selectorExists}
+		}
+	}
+
+	if !selectorKeyPattern.MatchString(req.key) {
+		return req, errors.BadRequest("invalid labelSelector: %q is not a valid label key", req.key)
+	}
+	for i, v := range req.values {
+		req.values[i] = strings.TrimSpace(v)
+		if !selectorValuePattern.MatchString(req.values[i]) {
+			return req, errors.BadRequest("invalid labelSelector: %q is not a valid label value", req.values[i])
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLabelSelectorToSQL(t *testing.T) {
+	label := labelValueSubquery("resources")
+
+	tests := []struct {
+		name     string
+		selector string
+		sql      string
+		values   []any
+	}{
+		{
+			name:     "equality",
+			selector: "env=prod",
+			sql:      "(" + label + " = ?)",
+			values:   []any{"env", "prod"},
+		},
+		{
+			name:     "double equals",
+			selector: "env==prod",
+			sql:      "(" + label + " = ?)",
+			values:   []any{"env", "prod"},
+		},
+		{
+			name:     "inequality matches missing labels",
+			selector: "env!=prod",
+			sql:      "(" + label + " IS DISTINCT FROM ?)",
+			values:   []any{"env", "prod"},
+		},
+		{
+			name:     "in",
+			selector: "tier in (web, api)",
+			sql:      "(" + label + " IN (?, ?))",
+			values:   []any{"tier", "web", "api"},
+		},
+		{
+			name:     "notin matches missing labels",
+			selector: "tier notin (web)",
+			sql:      "(COALESCE(" + label + " NOT IN (?), TRUE))",
+			values:   []any{"tier", "web"},
+		},
+		{
+			name:     "exists",
+			selector: "example.com/team",
+			sql:      "(" + label + " IS NOT NULL)",
+			values:   []any{"example.com/team"},
+		},
+		{
+			name:     "does not exist",
+			selector: "!canary",
+			sql:      "(" + label + " IS NULL)",
+			values:   []any{"canary"},
+		},
+		{
+			name:     "empty value",
+			selector: "env=",
+			sql:      "(" + label + " = ?)",
+			values:   []any{"env", ""},
+		},
+		{
+			name:     "multiple requirements",
+			selector: "env=prod, tier in (web,api), !canary",
+			sql: "(" + label + " = ?) AND (" + label + " IN (?, ?)) AND (" +
+				label + " IS NULL)",
+			values: []any{"env", "prod", "tier", "web", "api", "canary"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			sql, values, svcErr := LabelSelectorToSQL(tt.selector, "resources")
+			Expect(svcErr).To(BeNil())
+			Expect(sql).To(Equal(tt.sql))
+			Expect(values).To(Equal(tt.values))
+		})
+	}
+}
+
+func TestLabelSelectorToSQL_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		message  string
+	}{
+		{"Empty requirement", "env=prod,,tier=web", "empty requirement"},
+		{"Trailing comma", "env=prod,", "empty requirement"},
+		{"Unclosed set", "tier in (web", "unbalanced parentheses"},
+		{"Unopened set", "tier in web)", "unbalanced parentheses"},
+		{"Empty set", "tier in ()", "needs at least one value"},
+		{"Invalid key", "env prod=x", "not a valid label key"},
+		{"Invalid value", "env=pro'd", "not a valid label value"},
+		{"Invalid set value", "tier in (web, a b)", "not a valid label value"},
+		{"Too long", strings.Repeat("a", MaxLabelSelectorLength+1), "maximum length"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			_, _, svcErr := LabelSelectorToSQL(tt.selector, "resources")
+			Expect(svcErr).ToNot(BeNil())
+			Expect(svcErr.HTTPCode).To(Equal(http.StatusBadRequest))
+			Expect(svcErr.Error()).To(ContainSubstring(tt.message))
+		})
+	}
+}
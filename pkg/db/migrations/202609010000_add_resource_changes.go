@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addResourceChanges creates the change log backing watch streams.
+//
+// Every resource write appends a row in the same transaction, so a change is visible
+// exactly when the write commits. Rows are read in (txid, id) order: BIGSERIAL ids are
+// handed out at insert time, not commit time, so a reader advancing by id alone could
+// skip a row whose transaction commits after a later id. Readers only consume rows whose
+// txid is below the oldest running transaction (pg_snapshot_xmin), and every row written
+// afterwards gets a txid at or above it, so a position never moves past a row that can
+// still appear.
+func addResourceChanges() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202609010000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`CREATE TABLE IF NOT EXISTS resource_changes (
+				id BIGSERIAL PRIMARY KEY,
+				txid XID8 NOT NULL DEFAULT pg_current_xact_id(),
+				type VARCHAR(16) NOT NULL,
+				kind VARCHAR(100) NOT NULL,
+				resource_id VARCHAR(255) NOT NULL,
+				owner_id VARCHAR(255),
+				tenancy JSONB NOT NULL DEFAULT '{}'::jsonb,
+				object JSONB NOT NULL,
+				created_time TIMESTAMPTZ NOT NULL DEFAULT now()
+			);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(
+				"CREATE INDEX IF NOT EXISTS idx_resource_changes_position " +
+					"ON resource_changes (txid, id);",
+			).Error; err != nil {
+				return err
+			}
+
+			return tx.Exec(
+				"CREATE INDEX IF NOT EXISTS idx_resource_changes_created_time " +
+					"ON resource_changes (created_time);",
+			).Error
+		},
+	}
+}
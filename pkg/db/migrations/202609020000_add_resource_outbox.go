@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addResourceOutbox creates the transactional outbox for CloudEvents.
+//
+// Resource writes insert the event in their own transaction, and the dispatcher deletes
+// a row only after the sink accepts it, so every committed change is delivered at least
+// once. next_attempt_time pushes a failed row back while it waits out its backoff.
+func addResourceOutbox() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202609020000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`CREATE TABLE IF NOT EXISTS resource_outbox (
+				id BIGSERIAL PRIMARY KEY,
+				event_id VARCHAR(36) NOT NULL,
+				kind VARCHAR(100) NOT NULL,
+				resource_id VARCHAR(255) NOT NULL,
+				event JSONB NOT NULL,
+				attempts INTEGER NOT NULL DEFAULT 0,
+				last_error TEXT,
+				next_attempt_time TIMESTAMPTZ NOT NULL DEFAULT now(),
+				created_time TIMESTAMPTZ NOT NULL DEFAULT now()
+			);`).Error; err != nil {
+				return err
+			}
+
+			return tx.Exec(
+				"CREATE INDEX IF NOT EXISTS idx_resource_outbox_next_attempt " +
+					"ON resource_outbox (next_attempt_time, id);",
+			).Error
+		},
+	}
+}
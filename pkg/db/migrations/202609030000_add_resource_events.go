@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addResourceEvents creates the append-only audit log behind /{plural}/{id}/history.
+//
+// Rows are written in the transaction of the mutation they describe and are never
+// updated. They carry no foreign key to resources so the history of a hard-deleted
+// resource outlives its row.
+func addResourceEvents() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202609030000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`CREATE TABLE IF NOT EXISTS resource_events (
+				id VARCHAR(36) PRIMARY KEY,
+				resource_id VARCHAR(255) NOT NULL,
+				kind VARCHAR(100) NOT NULL,
+				owner_id VARCHAR(255),
+				action VARCHAR(32) NOT NULL,
+				actor VARCHAR(255) NOT NULL,
+				adapter VARCHAR(255),
+				reason TEXT,
+				changes JSONB NOT NULL DEFAULT '[]'::jsonb,
+				tenancy JSONB NOT NULL DEFAULT '{}'::jsonb,
+				generation INTEGER NOT NULL,
+				created_time TIMESTAMPTZ NOT NULL
+			);`).Error; err != nil {
+				return err
+			}
+
+			return tx.Exec(
+				"CREATE INDEX IF NOT EXISTS idx_resource_events_resource " +
+					"ON resource_events (resource_id, created_time);",
+			).Error
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addResourceRevisions creates the spec history behind /{plural}/{id}/revisions.
+//
+// A row is written for every generation a create or patch produces, holding the
+// user-writable state of the resource at that generation so it can be rolled back to.
+// Unlike the audit log, revisions are removed together with their resource.
+func addResourceRevisions() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202609040000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`CREATE TABLE IF NOT EXISTS resource_revisions (
+				resource_id VARCHAR(255) NOT NULL,
+				generation INTEGER NOT NULL,
+				kind VARCHAR(100) NOT NULL,
+				spec JSONB NOT NULL,
+				labels JSONB NOT NULL DEFAULT '{}'::jsonb,
+				refs JSONB NOT NULL DEFAULT '{}'::jsonb,
+				tenancy JSONB NOT NULL DEFAULT '{}'::jsonb,
+				created_by VARCHAR(255) NOT NULL,
+				created_time TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (resource_id, generation),
+				FOREIGN KEY (resource_id) REFERENCES resources(id) ON DELETE CASCADE
+			);`).Error
+		},
+	}
+}
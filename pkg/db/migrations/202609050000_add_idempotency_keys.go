@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addIdempotencyKeys creates the store behind the Idempotency-Key header.
+//
+// A key is scoped to the caller that used it. The row is claimed in the transaction of
+// the request it guards, so a concurrent retry with the same key blocks on the primary
+// key until the first request commits or rolls back. Expired rows are pruned in the
+// background and may be reclaimed before then.
+func addIdempotencyKeys() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202609050000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`CREATE TABLE IF NOT EXISTS idempotency_keys (
+				owner VARCHAR(255) NOT NULL,
+				key VARCHAR(255) NOT NULL,
+				request_hash VARCHAR(64) NOT NULL,
+				status_code INTEGER NOT NULL DEFAULT 0,
+				response_headers JSONB,
+				response_body BYTEA,
+				created_time TIMESTAMPTZ NOT NULL,
+				expires_time TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (owner, key)
+			);`).Error; err != nil {
+				return err
+			}
+
+			return tx.Exec(
+				"CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires ON idempotency_keys (expires_time);",
+			).Error
+		},
+	}
+}
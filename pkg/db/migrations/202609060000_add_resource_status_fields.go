@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addResourceStatusFields stores the values that an entity kind's status_fields rules
+// project from adapter statuses, presented as status.fields.
+func addResourceStatusFields() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202609060000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(
+				"ALTER TABLE resources ADD COLUMN IF NOT EXISTS status_fields JSONB NOT NULL DEFAULT '{}'::jsonb;",
+			).Error
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addResourceStatusUpdatedTime records when a resource's conditions or status fields last
+// changed, so that its ETag moves on status reports.
+func addResourceStatusUpdatedTime() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202609070000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE resources ADD COLUMN IF NOT EXISTS status_updated_time TIMESTAMPTZ;").Error
+		},
+	}
+}
@@ -31,6 +31,13 @@ var MigrationList = []*gormigrate.Migration{
 	addConditionStatusIndex(),
 	addResourceTenancy(),
 	addScopeResourceNameByTenant(),
+	addResourceChanges(),
+	addResourceOutbox(),
+	addResourceEvents(),
+	addResourceRevisions(),
+	addIdempotencyKeys(),
+	addResourceStatusFields(),
+	addResourceStatusUpdatedTime(),
 }
 
 // Model represents the base model struct. All entities will have this struct embedded.
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// OrderKey is one key a list is sorted by: a SQL expression with its parameters. Column
+// names the model column the key reads, or is empty for a key on labels, conditions or
+// JSONB fields. Keys with NullsLast sort rows without a value last in either direction;
+// the others keep the Postgres default of NULLs last ascending and first descending.
+type OrderKey struct {
+	SQL       string
+	Column    string
+	Values    []any
+	Desc      bool
+	NullsLast bool
+}
+
+// Clause renders the key as an ORDER BY item.
+func (k OrderKey) Clause() string {
+	switch {
+	case k.Desc && k.NullsLast:
+		return k.SQL + " desc NULLS LAST"
+	case k.Desc:
+		return k.SQL + " desc"
+	default:
+		return k.SQL + " asc"
+	}
+}
+
+// NullsSortLast reports whether rows without a value sort after every value.
+func (k OrderKey) NullsSortLast() bool {
+	return !k.Desc || k.NullsLast
+}
+
+// IsExpressionOrder reports whether a cleaned order clause, as returned by ArgsToOrder,
+// sorts by a label, condition, spec or status field rather than a column.
+func IsExpressionOrder(clause string) bool {
+	field := strings.Fields(clause)[0]
+	return prefixLabels(field) || prefixStatusConditions(field) || prefixStatusFields(field) || prefixSpec(field)
+}
+
+// OrderToSQL resolves cleaned order clauses, as returned by ArgsToOrder, to the keys
+// that sort rows of tableName. Columns sort as themselves. labels.<key> and
+// status.conditions.<Type>[.<subfield>] resolve to the subqueries TSLToSQL uses and sort
+// resources without the label or condition last. spec.<path> and status.fields.<path>
+// sort by two keys, numeric values first and numerically, then the other values as
+// text, with resources lacking the field last.
+func OrderToSQL(order []string, tableName string) ([]OrderKey, *errors.ServiceError) {
+	ctx := &walkContext{cfg: WalkConfig{TableName: tableName}}
+	keys := make([]OrderKey, 0, len(order))
+	for _, clause := range order {
+		fields := strings.Fields(clause)
+		field, desc := fields[0], fields[1] == "desc"
+
+		var sql string
+		var values []any
+		var svcErr *errors.ServiceError
+		switch {
+		case prefixLabels(field):
+			sql, values, svcErr = resolveLabelColumn(field, ctx)
+		case prefixStatusConditions(field):
+			sql, values, svcErr = resolveStatusConditionColumn(field, ctx)
+		case prefixStatusFields(field):
+			sql, _, svcErr = resolveStatusFieldColumn(field, ctx)
+		case prefixSpec(field):
+			sql, _, svcErr = resolveSpecColumn(field, ctx)
+		default:
+			keys = append(keys, OrderKey{SQL: tableName + "." + field, Column: field, Desc: desc})
+			continue
+		}
+		if svcErr != nil {
+			return nil, svcErr
+		}
+
+		if isJSONBPath(sql) {
+			keys = append(keys, OrderKey{SQL: numericJSONBValue(sql), Desc: desc, NullsLast: true})
+		}
+		keys = append(keys, OrderKey{SQL: sql, Values: values, Desc: desc, NullsLast: true})
+	}
+	return keys, nil
+}
+
+// numericJSONBValue casts the text extraction of a JSONB path to numeric where the value
+// is a JSON number, and yields NULL otherwise, so that the cast never fails.
+func numericJSONBValue(path string) string {
+	i := strings.LastIndex(path, "->>")
+	return fmt.Sprintf(
+		"CASE WHEN jsonb_typeof(%s->%s) = 'number' THEN CAST(%s AS numeric) END",
+		path[:i], path[i+len("->>"):], path,
+	)
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestOrderToSQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		order []string
+		keys  []OrderKey
+	}{
+		{
+			name:  "columns",
+			order: []string{"name asc", "id desc"},
+			keys: []OrderKey{
+				{SQL: "resources.name", Column: "name"},
+				{SQL: "resources.id", Column: "id", Desc: true},
+			},
+		},
+		{
+			name:  "label",
+			order: []string{"labels.region desc"},
+			keys: []OrderKey{
+				{SQL: labelValueSubquery("resources"), Values: []any{"region"}, Desc: true, NullsLast: true},
+			},
+		},
+		{
+			name:  "condition subfield",
+			order: []string{"status.conditions.Ready.last_transition_time asc"},
+			keys: []OrderKey{{
+				SQL: "(SELECT rc.last_transition_time FROM resource_conditions rc " +
+					"WHERE rc.resource_id = resources.id AND rc.type = ?)",
+				Values:    []any{"Ready"},
+				NullsLast: true,
+			}},
+		},
+		{
+			name:  "spec path",
+			order: []string{"spec.scale.replicas desc"},
+			keys: []OrderKey{
+				{
+					SQL: "CASE WHEN jsonb_typeof(spec->'scale'->'replicas') = 'number' " +
+						"THEN CAST(spec->'scale'->>'replicas' AS numeric) END",
+					Desc:      true,
+					NullsLast: true,
+				},
+				{SQL: "spec->'scale'->>'replicas'", Desc: true, NullsLast: true},
+			},
+		},
+		{
+			name:  "status field",
+			order: []string{"status.fields.version asc"},
+			keys: []OrderKey{
+				{
+					SQL: "CASE WHEN jsonb_typeof(status_fields->'version') = 'number' " +
+						"THEN CAST(status_fields->>'version' AS numeric) END",
+					NullsLast: true,
+				},
+				{SQL: "status_fields->>'version'", NullsLast: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			keys, svcErr := OrderToSQL(tt.order, "resources")
+			Expect(svcErr).To(BeNil())
+			Expect(keys).To(Equal(tt.keys))
+		})
+	}
+}
+
+func TestOrderToSQL_Invalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, svcErr := OrderToSQL([]string{"status.conditions.Ready.message asc"}, "resources")
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(http.StatusBadRequest))
+}
+
+func TestOrderKeyClause(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(OrderKey{SQL: "resources.name"}.Clause()).To(Equal("resources.name asc"))
+	Expect(OrderKey{SQL: "resources.name", Desc: true}.Clause()).To(Equal("resources.name desc"))
+	Expect(OrderKey{SQL: "spec->>'a'", Desc: true, NullsLast: true}.Clause()).To(Equal("spec->>'a' desc NULLS LAST"))
+}
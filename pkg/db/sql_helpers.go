@@ -12,9 +12,13 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
 )
 
-// orderPattern matches valid order syntax: field name (letters, digits, underscore) followed by optional asc/desc.
-// This regex rejects SQL injection attempts (semicolons, parentheses, dashes, comments, etc).
-var orderPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\s+(asc|desc))?$`)
+// orderPattern matches valid order syntax: field name (letters, digits, underscore), or a labels.<key>,
+// status.conditions.<Type>[.<subfield>], status.fields.<path> or spec.<path> field, followed by optional asc/desc.
+// This regex rejects SQL injection attempts (semicolons, parentheses, quotes, comments, etc).
+var orderPattern = regexp.MustCompile(
+	`^([a-z_][a-z0-9_]*|labels\.[A-Za-z0-9][A-Za-z0-9._/-]*|status\.conditions\.[A-Z][A-Za-z0-9]*(\.[a-z_]+)?|` +
+		`(spec|status\.fields)(\.[a-z0-9_]+)+)(\s+(asc|desc))?$`,
+)
 
 // ArgsToOrder validates and cleans order arguments.
 // Returns a cleaned list of order clauses in the format ["field direction", ...]
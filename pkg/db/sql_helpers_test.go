@@ -114,6 +114,34 @@ func TestArgsToOrder(t *testing.T) {
 			input:    []string{"release_v2 asc"},
 			expected: []string{"release_v2 asc"},
 		},
+		{
+			name:     "label, condition and JSONB fields",
+			input:    []string{"labels.app.kubernetes.io/name", "status.conditions.Ready.last_updated_time desc"},
+			expected: []string{"labels.app.kubernetes.io/name asc", "status.conditions.Ready.last_updated_time desc"},
+		},
+		{
+			name:     "spec and status fields",
+			input:    []string{"spec.replicas desc", "status.fields.release.version"},
+			expected: []string{"spec.replicas desc", "status.fields.release.version asc"},
+		},
+		{
+			name:          "empty spec path",
+			input:         []string{"spec asc"},
+			expectError:   true,
+			errorContains: "invalid order format",
+		},
+		{
+			name:          "lowercase condition type",
+			input:         []string{"status.conditions.ready"},
+			expectError:   true,
+			errorContains: "invalid order format",
+		},
+		{
+			name:          "quote in label key",
+			input:         []string{"labels.a'b asc"},
+			expectError:   true,
+			errorContains: "invalid order format",
+		},
 		{
 			name:          "too many parts",
 			input:         []string{"name asc extra"},
@@ -1,7 +1,7 @@
 // sql_tsl.go implements a custom TSL-to-SQL walker instead of using the
 // library's built-in SQL emitter. We need this because label and condition
 // queries are resolved as scalar subqueries against separate tables
-// (resource_labels, resource_conditions), JSONB spec fields require dynamic
+// (resource_labels, resource_conditions), JSONB spec and status fields require dynamic
 // CAST wrapping for numeric comparisons, and certain operators (NOT on
 // labels/conditions) must be rejected at walk time to prevent semantically
 // broken SQL. The built-in walker has no hooks for any of this.
@@ -23,6 +23,8 @@ const (
 	resourceLabelsTable     = "resource_labels"
 	resourceConditionsTable = "resource_conditions"
 	conditionStatusField    = "status"
+	specColumn              = "spec"
+	statusFieldsColumn      = "status_fields"
 )
 
 // jsonbKeyPattern guards keys interpolated into JSONB paths (spec->>'%s', properties->>'%s').
@@ -63,6 +65,10 @@ func prefixStatusConditions(s string) bool {
 	return strings.HasPrefix(s, "status.conditions.")
 }
 
+func prefixStatusFields(s string) bool {
+	return strings.HasPrefix(s, "status.fields.")
+}
+
 func prefixSpec(s string) bool {
 	return strings.HasPrefix(s, "spec.")
 }
@@ -170,6 +176,10 @@ func resolveColumn(n *tsl.TSLNode, ctx *walkContext) (string, []any, *errors.Ser
 		}
 		return resolveStatusConditionColumn(name, ctx)
 	}
+	// status.fields...
+	if prefixStatusFields(name) {
+		return resolveStatusFieldColumn(name, ctx)
+	}
 	// spec...
 	if prefixSpec(name) {
 		return resolveSpecColumn(name, ctx)
@@ -184,10 +194,16 @@ func resolveLabelColumn(name string, ctx *walkContext) (string, []any, *errors.S
 	if key == "" {
 		return "", nil, errors.BadRequest("label key cannot be empty")
 	}
+	return labelValueSubquery(ctx.cfg.TableName), []any{key}, nil
+}
+
+// labelValueSubquery selects the value of a resource's label, whose key is its one
+// parameter, as a scalar subquery: NULL when the resource has no such label.
+func labelValueSubquery(tableName string) string {
 	return fmt.Sprintf(
 		"(SELECT value FROM %s WHERE %s.resource_id = %s.id AND %s.key = ?)",
-		resourceLabelsTable, resourceLabelsTable, ctx.cfg.TableName, resourceLabelsTable,
-	), []any{key}, nil
+		resourceLabelsTable, resourceLabelsTable, tableName, resourceLabelsTable,
+	)
 }
 
 func resolveStatusConditionColumn(name string, ctx *walkContext) (string, []any, *errors.ServiceError) {
@@ -234,15 +250,28 @@ func resolveStatusConditionColumn(name string, ctx *walkContext) (string, []any,
 
 func resolveSpecColumn(name string, _ *walkContext) (string, []any, *errors.ServiceError) {
 	specPath, _ := strings.CutPrefix(name, "spec.")
-	parts := strings.Split(specPath, ".")
+	return resolveJSONBPath(specColumn, specPath, "spec field segment")
+}
+
+// resolveStatusFieldColumn maps status.fields.<name> to the status_fields JSONB column,
+// where the values projected by an entity's status_fields rules are stored.
+func resolveStatusFieldColumn(name string, _ *walkContext) (string, []any, *errors.ServiceError) {
+	fieldPath, _ := strings.CutPrefix(name, "status.fields.")
+	return resolveJSONBPath(statusFieldsColumn, fieldPath, "status field segment")
+}
+
+// resolveJSONBPath emits the text extraction of a dotted path from a JSONB column,
+// e.g. spec->'release'->>'channel'.
+func resolveJSONBPath(column, path, fieldType string) (string, []any, *errors.ServiceError) {
+	parts := strings.Split(path, ".")
 	for _, part := range parts {
-		if validationErr := validateJSONBKey(part, "spec field segment"); validationErr != nil {
+		if validationErr := validateJSONBKey(part, fieldType); validationErr != nil {
 			return "", nil, validationErr
 		}
 	}
 
 	var field strings.Builder
-	fmt.Fprintf(&field, "spec")
+	field.WriteString(column)
 	for i, part := range parts {
 		if i == len(parts)-1 {
 			fmt.Fprintf(&field, "->>'%s'", part)
@@ -254,6 +283,12 @@ func resolveSpecColumn(name string, _ *walkContext) (string, []any, *errors.Serv
 	return field.String(), nil, nil
 }
 
+// isJSONBPath reports whether sql is a text extraction from a JSONB column, which must
+// be cast to compare numerically.
+func isJSONBPath(sql string) bool {
+	return strings.HasPrefix(sql, specColumn+"->") || strings.HasPrefix(sql, statusFieldsColumn+"->")
+}
+
 func resolveField(name string, ctx *walkContext) (string, []any, *errors.ServiceError) {
 	trimmedName := strings.TrimSpace(name)
 	fieldParts := strings.Split(trimmedName, ".")
@@ -358,12 +393,12 @@ func walkComparison(op tsl.TSLExpressionOp, ctx *walkContext) (string, []any, *e
 		return "", nil, errors.BadRequest("unsupported comparison operator: %s", op.Operator)
 	}
 
-	if strings.HasPrefix(leftSQL, "spec->") && len(rightArgs) > 0 {
+	if isJSONBPath(leftSQL) && len(rightArgs) > 0 {
 		if _, isNum := rightArgs[0].(float64); isNum {
 			leftSQL = fmt.Sprintf("CAST(%s AS numeric)", leftSQL)
 		}
 	}
-	if strings.HasPrefix(rightSQL, "spec->") && len(leftArgs) > 0 {
+	if isJSONBPath(rightSQL) && len(leftArgs) > 0 {
 		if _, isNum := leftArgs[0].(float64); isNum {
 			rightSQL = fmt.Sprintf("CAST(%s AS numeric)", rightSQL)
 		}
@@ -414,7 +449,7 @@ func walkIn(op tsl.TSLExpressionOp, ctx *walkContext) (string, []any, *errors.Se
 		rightArgs = append(rightArgs, a...)
 	}
 
-	if strings.HasPrefix(leftSQL, "spec->") && len(rightArgs) > 0 {
+	if isJSONBPath(leftSQL) && len(rightArgs) > 0 {
 		allNumeric := true
 		for _, arg := range rightArgs {
 			if _, isNum := arg.(float64); !isNum {
@@ -455,7 +490,7 @@ func walkBetween(op tsl.TSLExpressionOp, ctx *walkContext) (string, []any, *erro
 		return "", nil, err
 	}
 
-	if strings.HasPrefix(leftSQL, "spec->") && len(lowArgs) > 0 && len(highArgs) > 0 {
+	if isJSONBPath(leftSQL) && len(lowArgs) > 0 && len(highArgs) > 0 {
 		_, lowIsNum := lowArgs[0].(float64)
 		_, highIsNum := highArgs[0].(float64)
 		if lowIsNum && highIsNum {
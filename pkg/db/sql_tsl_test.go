@@ -132,6 +132,51 @@ func TestResolveSpecColumn(t *testing.T) {
 	}
 }
 
+func TestResolveStatusFieldColumn(t *testing.T) {
+	ctx := &walkContext{cfg: WalkConfig{TableName: "resources"}}
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "single key",
+			input:    "status.fields.api_url",
+			expected: "status_fields->>'api_url'",
+		},
+		{
+			name:     "2-level: status.fields.endpoints.console",
+			input:    "status.fields.endpoints.console",
+			expected: "status_fields->'endpoints'->>'console'",
+		},
+		{
+			name:        "invalid key with uppercase",
+			input:       "status.fields.apiURL",
+			expectError: true,
+		},
+		{
+			name:        "empty key",
+			input:       "status.fields.",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			field, _, err := resolveStatusFieldColumn(tt.input, ctx)
+			if tt.expectError {
+				Expect(err).ToNot(BeNil())
+			} else {
+				Expect(err).To(BeNil())
+				Expect(field).To(Equal(tt.expected))
+			}
+		})
+	}
+}
+
 func TestConditionStatusValidation(t *testing.T) {
 	tests := []struct {
 		status      string
@@ -265,6 +310,19 @@ func TestTSLToSQL_NumericCast(t *testing.T) {
 		Expect(values).To(Equal([]any{float64(1), "two"}))
 	})
 
+	t.Run("status field with numeric RHS — CAST applied", func(t *testing.T) {
+		RegisterTestingT(t)
+		sql, _ := walkHelper(t, "status.fields.node_count >= 3")
+		Expect(sql).To(Equal("CAST(status_fields->>'node_count' AS numeric) >= ?"))
+	})
+
+	t.Run("status field with string RHS — no CAST", func(t *testing.T) {
+		RegisterTestingT(t)
+		sql, values := walkHelper(t, "status.fields.api_url = 'https://api.example.com'")
+		Expect(sql).To(Equal("status_fields->>'api_url' = ?"))
+		Expect(values).To(Equal([]any{"https://api.example.com"}))
+	})
+
 	t.Run("AND tree: only spec+numeric nodes get CAST", func(t *testing.T) {
 		RegisterTestingT(t)
 		sql, _ := walkHelper(t, "spec.replicas > 9 AND generation > 1 AND spec.channel = 'dev'")
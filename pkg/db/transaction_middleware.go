@@ -6,6 +6,8 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -27,6 +29,15 @@ func isWriteMethod(method string) bool {
 	}
 }
 
+// isWatchRequest reports whether r opens a long-lived watch stream (GET ...?watch=true).
+func isWatchRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		return false
+	}
+	watch, err := strconv.ParseBool(strings.TrimSpace(r.URL.Query().Get("watch")))
+	return err == nil && watch
+}
+
 // TransactionMiddleware creates a database transaction for write operations only.
 //
 // Write methods (POST/PUT/PATCH/DELETE) get GORM transactions for ACID guarantees.
@@ -35,13 +46,17 @@ func isWriteMethod(method string) bool {
 //
 // Trade-off: List operations (COUNT + SELECT) may show inconsistent pagination
 // totals under concurrent deletes, but this is an acceptable cosmetic issue.
+// Clients that need a stable walk follow "continue" tokens, optionally with
+// count=false, which are unaffected.
 //
 // The requestTimeout is applied to all requests (read and write) to prevent
-// queries from blocking indefinitely when the database is under pressure.
+// queries from blocking indefinitely when the database is under pressure. Watch
+// streams are exempt: they stay open until the client disconnects, and each poll
+// they issue is short.
 func TransactionMiddleware(next http.Handler, connection SessionFactory, requestTimeout time.Duration) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		if requestTimeout > 0 {
+		if requestTimeout > 0 && !isWatchRequest(r) {
 			var cancel context.CancelFunc
 			ctx, cancel = context.WithTimeout(ctx, requestTimeout)
 			defer cancel()
@@ -119,6 +119,31 @@ func TestIsWriteMethod_NonStandardMethods(t *testing.T) {
 	}
 }
 
+func TestIsWatchRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		expected bool
+	}{
+		{"GET with watch=true", http.MethodGet, "/api/hyperfleet/v1/clusters?watch=true", true},
+		{"GET with watch=1", http.MethodGet, "/api/hyperfleet/v1/clusters?watch=1", true},
+		{"GET with watch=false", http.MethodGet, "/api/hyperfleet/v1/clusters?watch=false", false},
+		{"GET with invalid watch", http.MethodGet, "/api/hyperfleet/v1/clusters?watch=yes", false},
+		{"GET without watch", http.MethodGet, "/api/hyperfleet/v1/clusters", false},
+		{"POST with watch=true", http.MethodPost, "/api/hyperfleet/v1/clusters?watch=true", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+			if result := isWatchRequest(r); result != tt.expected {
+				t.Errorf("isWatchRequest(%s %s) = %v, want %v", tt.method, tt.target, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestTransactionMiddleware_DBUnavailable(t *testing.T) {
 	tests := []struct {
 		setupMock      func(sqlmock.Sqlmock)
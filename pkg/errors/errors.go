@@ -17,6 +17,7 @@ const (
 	ErrorTypeAuth             = ErrorTypeBase + "authentication-error"
 	ErrorTypePermissionDenied = ErrorTypeBase + "permission-denied"
 	ErrorTypeNotFound         = ErrorTypeBase + "not-found"
+	ErrorTypeGone             = ErrorTypeBase + "gone"
 	ErrorTypeConflict         = ErrorTypeBase + "conflict"
 	ErrorTypeRateLimit        = ErrorTypeBase + "rate-limit"
 	ErrorTypeInternal         = ErrorTypeBase + "internal-error"
@@ -49,6 +50,9 @@ const (
 	CodeNotFoundCluster  = "HYPERFLEET-NTF-002"
 	CodeNotFoundNodePool = "HYPERFLEET-NTF-003"
 
+	// Gone errors (GON) - 410
+	CodeGoneExpired = "HYPERFLEET-GON-001"
+
 	// Conflict errors (CNF) - 409
 	CodeConflictExists  = "HYPERFLEET-CNF-001"
 	CodeConflictVersion = "HYPERFLEET-CNF-002"
@@ -69,6 +73,7 @@ const (
 	CodeBadRequest      = "HYPERFLEET-VAL-005"
 	CodeMalformedBody   = "HYPERFLEET-VAL-006"
 	CodeSearchParseFail = "HYPERFLEET-VAL-007"
+	CodeIdempotencyKey  = "HYPERFLEET-VAL-008"
 	CodeNotImplemented  = "HYPERFLEET-INT-003"
 )
 
@@ -145,6 +150,10 @@ var errorDefinitions = map[string]errorDefinition{
 	CodeSearchParseFail: {
 		ErrorTypeValidation, "Invalid Search Query", "Failed to parse search query", http.StatusBadRequest,
 	},
+	CodeIdempotencyKey: {
+		ErrorTypeValidation, "Idempotency Key Reused",
+		"The idempotency key was already used for a different request", http.StatusUnprocessableEntity,
+	},
 
 	// Not Found errors (NTF) - 404
 	CodeNotFoundEndpoint: {
@@ -160,6 +169,11 @@ var errorDefinitions = map[string]errorDefinition{
 		ErrorTypeNotFound, "NodePool Not Found", "The specified node pool was not found", http.StatusNotFound,
 	},
 
+	// Gone errors (GON) - 410
+	CodeGoneExpired: {
+		ErrorTypeGone, "Gone", "The requested position is no longer available", http.StatusGone,
+	},
+
 	// Conflict errors (CNF) - 409
 	CodeConflictExists: {
 		ErrorTypeConflict, "Resource Conflict",
@@ -342,10 +356,18 @@ func Conflict(reason string, values ...interface{}) *ServiceError {
 	return New(CodeConflictExists, reason, values...)
 }
 
+func ConflictVersion(reason string, values ...interface{}) *ServiceError {
+	return New(CodeConflictVersion, reason, values...)
+}
+
 func ConflictState(reason string, values ...interface{}) *ServiceError {
 	return New(CodeConflictState, reason, values...)
 }
 
+func Gone(reason string, values ...interface{}) *ServiceError {
+	return New(CodeGoneExpired, reason, values...)
+}
+
 func Validation(reason string, values ...interface{}) *ServiceError {
 	return New(CodeValidationMultiple, reason, values...)
 }
@@ -370,6 +392,10 @@ func FailedToParseSearch(reason string, values ...interface{}) *ServiceError {
 	return New(CodeSearchParseFail, message, values...)
 }
 
+func IdempotencyKeyReused(reason string, values ...interface{}) *ServiceError {
+	return New(CodeIdempotencyKey, reason, values...)
+}
+
 func DatabaseAdvisoryLock(err error) *ServiceError {
 	// Log the full error server-side for debugging
 	ctx := context.Background()
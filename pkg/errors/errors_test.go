@@ -60,6 +60,22 @@ func TestConstructors(t *testing.T) {
 			expectedType:   ErrorTypeConflict,
 			expectedReason: "already exists",
 		},
+		{
+			name:           "ConflictVersion",
+			build:          func() *ServiceError { return ConflictVersion("etag %s is stale", `"1-2"`) },
+			expectedCode:   CodeConflictVersion,
+			expectedHTTP:   http.StatusConflict,
+			expectedType:   ErrorTypeConflict,
+			expectedReason: `etag "1-2" is stale`,
+		},
+		{
+			name:           "Gone",
+			build:          func() *ServiceError { return Gone("position %s has expired", "1-2") },
+			expectedCode:   CodeGoneExpired,
+			expectedHTTP:   http.StatusGone,
+			expectedType:   ErrorTypeGone,
+			expectedReason: "position 1-2 has expired",
+		},
 		{
 			name:           "Validation",
 			build:          func() *ServiceError { return Validation("field %s must be %s", "size", "positive") },
@@ -96,6 +112,14 @@ func TestConstructors(t *testing.T) {
 			expectedType:   ErrorTypeBadRequest,
 			expectedReason: "bad input",
 		},
+		{
+			name:           "IdempotencyKeyReused",
+			build:          func() *ServiceError { return IdempotencyKeyReused("key %q was used for another request", "k1") },
+			expectedCode:   CodeIdempotencyKey,
+			expectedHTTP:   http.StatusUnprocessableEntity,
+			expectedType:   ErrorTypeValidation,
+			expectedReason: `key "k1" was used for another request`,
+		},
 		{
 			name:           "FailedToParseSearch wraps reason",
 			build:          func() *ServiceError { return FailedToParseSearch("unexpected token") },
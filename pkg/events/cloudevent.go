@@ -0,0 +1,36 @@
+// Package events publishes resource changes as CloudEvents through a transactional outbox.
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	// SpecVersion is the CloudEvents specification version events conform to.
+	SpecVersion = "1.0"
+	// ContentType is the media type of a structured-mode CloudEvent.
+	ContentType = "application/cloudevents+json; charset=utf-8"
+	// typePrefix namespaces event types, e.g. com.redhat.hyperfleet.cluster.added.
+	typePrefix = "com.redhat.hyperfleet."
+)
+
+// Event is a CloudEvents 1.0 event in structured JSON mode. Data is the resource as the
+// API presents it, so consumers need not fetch it again.
+type Event struct {
+	Time            time.Time       `json:"time"`
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// EventType returns the CloudEvents type for a change of the given kind, for example
+// com.redhat.hyperfleet.nodepool.deleted for a DELETED NodePool.
+func EventType(kind, changeType string) string {
+	return typePrefix + strings.ToLower(kind) + "." + strings.ToLower(changeType)
+}
@@ -0,0 +1,118 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
+)
+
+// Dispatcher delivers outbox events to a sink. Delivery is at least once: an event is
+// deleted only after the sink accepts it, so a crash in between sends it again. Every
+// replica runs a dispatcher; the outbox advisory lock lets one of them dispatch at a time.
+// Events are sent in outbox id order. Ids are assigned at insert, not at commit, so that is
+// commit order only among the events of one resource, whose writes hold its row lock, and
+// only until a delivery has to be retried.
+type Dispatcher struct {
+	outboxDao      dao.ResourceOutboxDao
+	sessionFactory db.SessionFactory
+	sink           Sink
+	pollInterval   time.Duration
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	batchSize      int
+}
+
+func NewDispatcher(
+	outboxDao dao.ResourceOutboxDao, sessionFactory db.SessionFactory, sink Sink, cfg *config.EventsConfig,
+) *Dispatcher {
+	return &Dispatcher{
+		outboxDao:      outboxDao,
+		sessionFactory: sessionFactory,
+		sink:           sink,
+		pollInterval:   cfg.PollInterval,
+		initialBackoff: cfg.Retry.InitialBackoff,
+		maxBackoff:     cfg.Retry.MaxBackoff,
+		batchSize:      cfg.BatchSize,
+	}
+}
+
+// Run dispatches due events every poll interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.Dispatch(ctx)
+		}
+	}
+}
+
+// Dispatch delivers every event that is due, in batches, while holding the outbox lock.
+// Failed deliveries are rescheduled with exponential backoff and do not block later events.
+func (d *Dispatcher) Dispatch(ctx context.Context) {
+	// Peek without the lock first: an idle outbox is the common case, and taking the lock
+	// every poll would hold a connection and log on each tick.
+	if due, err := d.outboxDao.FindDue(ctx, 1); err != nil || len(due) == 0 {
+		if err != nil {
+			logger.WithError(ctx, err).Warn("Failed to read outbox")
+		}
+		return
+	}
+
+	lockCtx, lockOwner, err := db.NewAdvisoryLockContext(
+		ctx, d.sessionFactory, db.OutboxDispatchLockID, db.OutboxDispatch,
+	)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.WithError(ctx, err).Warn("Failed to acquire outbox lock")
+		}
+		return
+	}
+	defer db.Unlock(lockCtx, lockOwner)
+
+	for ctx.Err() == nil {
+		pending, err := d.outboxDao.FindDue(lockCtx, d.batchSize)
+		if err != nil {
+			logger.WithError(ctx, err).Warn("Failed to read outbox")
+			return
+		}
+		for _, event := range pending {
+			log := logger.With(ctx, "event_id", event.EventID, "kind", event.Kind, "resource_id", event.ResourceID)
+			if sendErr := d.sink.Send(lockCtx, event.Event); sendErr != nil {
+				retryAfter := d.backoff(event.Attempts)
+				log.With("attempts", event.Attempts+1, "retry_after", retryAfter.String()).
+					WithError(sendErr).Warn("Failed to deliver event")
+				if err := d.outboxDao.MarkFailed(lockCtx, event.ID, retryAfter, sendErr.Error()); err != nil {
+					log.WithError(err).Error("Failed to reschedule event")
+					return
+				}
+				continue
+			}
+			if err := d.outboxDao.Delete(lockCtx, event.ID); err != nil {
+				// The event was delivered but stays queued, so it will be sent again.
+				log.WithError(err).Error("Failed to remove delivered event from outbox")
+				return
+			}
+		}
+		if len(pending) < d.batchSize {
+			return
+		}
+	}
+}
+
+// backoff returns the delay before retrying an event that has already failed attempts
+// times: initialBackoff doubled per failure, capped at maxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.initialBackoff
+	for i := 0; i < attempts && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.maxBackoff)
+}
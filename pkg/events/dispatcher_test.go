@@ -0,0 +1,296 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/gomega"
+	postgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+)
+
+func TestDispatcher_Backoff(t *testing.T) {
+	RegisterTestingT(t)
+
+	d := &Dispatcher{initialBackoff: time.Second, maxBackoff: 30 * time.Second}
+	Expect(d.backoff(0)).To(Equal(1 * time.Second))
+	Expect(d.backoff(1)).To(Equal(2 * time.Second))
+	Expect(d.backoff(4)).To(Equal(16 * time.Second))
+	Expect(d.backoff(5)).To(Equal(30 * time.Second))
+	Expect(d.backoff(1000)).To(Equal(30 * time.Second))
+}
+
+// lockSessionFactory serves the advisory lock transaction from sqlmock.
+type lockSessionFactory struct {
+	gormDB *gorm.DB
+	sqlDB  *sql.DB
+}
+
+func (f *lockSessionFactory) Init(*config.DatabaseConfig)                             {}
+func (f *lockSessionFactory) New(_ context.Context) *gorm.DB                          { return f.gormDB }
+func (f *lockSessionFactory) CheckConnection() error                                  { return nil }
+func (f *lockSessionFactory) Close() error                                            { return nil }
+func (f *lockSessionFactory) ResetDB()                                                {}
+func (f *lockSessionFactory) NewListener(_ context.Context, _ string, _ func(string)) {}
+func (f *lockSessionFactory) GetAdvisoryLockTimeout() int                             { return 300 }
+func (f *lockSessionFactory) DirectDB() *sql.DB                                       { return f.sqlDB }
+
+// newLockSessionFactory expects the outbox lock to be taken and released once when locked
+// is set, and no database access at all otherwise.
+func newLockSessionFactory(t *testing.T, locked bool) *lockSessionFactory {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	if locked {
+		mock.ExpectBegin()
+		mock.ExpectExec("SET LOCAL statement_timeout").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("failed to open GORM: %v", err)
+	}
+
+	t.Cleanup(func() {
+		sqlDB.Close()
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unmet sqlmock expectations: %v", err)
+		}
+	})
+	return &lockSessionFactory{gormDB: gormDB, sqlDB: sqlDB}
+}
+
+// holdsOutboxLock reports whether ctx carries the outbox lock: only then can it be taken
+// again without a database connection.
+func holdsOutboxLock(ctx context.Context) bool {
+	_, _, err := db.NewAdvisoryLockContext(ctx, nil, db.OutboxDispatchLockID, db.OutboxDispatch)
+	return err == nil
+}
+
+type failedDelivery struct {
+	lastError  string
+	id         int64
+	retryAfter time.Duration
+}
+
+// fakeOutboxDao keeps the queued events in memory and records every change, and every
+// call made without the outbox lock.
+type fakeOutboxDao struct {
+	findErr       error
+	lockedFindErr error
+	deleteErr     error
+	markErr       error
+	events        []*api.OutboxEvent
+	deleted       []int64
+	failed        []failedDelivery
+	unlocked      []string
+}
+
+var _ dao.ResourceOutboxDao = &fakeOutboxDao{}
+
+func (f *fakeOutboxDao) record(ctx context.Context, call string) {
+	if !holdsOutboxLock(ctx) {
+		f.unlocked = append(f.unlocked, call)
+	}
+}
+
+func (f *fakeOutboxDao) Enqueue(_ context.Context, event *api.OutboxEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeOutboxDao) FindDue(ctx context.Context, limit int) ([]*api.OutboxEvent, error) {
+	f.record(ctx, "FindDue")
+	if f.findErr != nil {
+		return nil, f.findErr
+	}
+	if f.lockedFindErr != nil && holdsOutboxLock(ctx) {
+		return nil, f.lockedFindErr
+	}
+	return f.events[:min(limit, len(f.events))], nil
+}
+
+func (f *fakeOutboxDao) Delete(ctx context.Context, id int64) error {
+	f.record(ctx, "Delete")
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.deleted = append(f.deleted, id)
+	f.remove(id)
+	return nil
+}
+
+func (f *fakeOutboxDao) MarkFailed(ctx context.Context, id int64, retryAfter time.Duration, lastError string) error {
+	f.record(ctx, "MarkFailed")
+	if f.markErr != nil {
+		return f.markErr
+	}
+	f.failed = append(f.failed, failedDelivery{id: id, retryAfter: retryAfter, lastError: lastError})
+	// a rescheduled event is no longer due
+	f.remove(id)
+	return nil
+}
+
+func (f *fakeOutboxDao) remove(id int64) {
+	for i, event := range f.events {
+		if event.ID == id {
+			f.events = append(f.events[:i:i], f.events[i+1:]...)
+			return
+		}
+	}
+}
+
+// fakeSink accepts every event except those listed in reject.
+type fakeSink struct {
+	reject   map[string]bool
+	sent     []string
+	unlocked int
+}
+
+func (s *fakeSink) Send(ctx context.Context, event json.RawMessage) error {
+	if !holdsOutboxLock(ctx) {
+		s.unlocked++
+	}
+	s.sent = append(s.sent, string(event))
+	if s.reject[string(event)] {
+		return errors.New("sink unavailable")
+	}
+	return nil
+}
+
+func outboxEvent(id int64, attempts int) *api.OutboxEvent {
+	return &api.OutboxEvent{
+		ID:         id,
+		EventID:    fmt.Sprintf("evt-%d", id),
+		Kind:       "Cluster",
+		ResourceID: fmt.Sprintf("cls-%d", id),
+		Event:      []byte(eventBody(id)),
+		Attempts:   attempts,
+	}
+}
+
+func eventBody(id int64) string {
+	return fmt.Sprintf(`{"id":"evt-%d"}`, id)
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	storageErr := errors.New("connection reset")
+
+	tests := []struct {
+		dao            *fakeOutboxDao
+		reject         map[string]bool
+		name           string
+		expectSent     []string
+		expectDeleted  []int64
+		expectFailed   []failedDelivery
+		expectRemained int
+		expectLock     bool
+	}{
+		{
+			name:           "idle outbox takes no lock",
+			dao:            &fakeOutboxDao{},
+			expectLock:     false,
+			expectRemained: 0,
+		},
+		{
+			name:           "unreadable outbox takes no lock",
+			dao:            &fakeOutboxDao{findErr: storageErr, events: []*api.OutboxEvent{outboxEvent(1, 0)}},
+			expectLock:     false,
+			expectRemained: 1,
+		},
+		{
+			name: "delivered events are deleted in id order across batches",
+			dao: &fakeOutboxDao{events: []*api.OutboxEvent{
+				outboxEvent(1, 0), outboxEvent(2, 0), outboxEvent(3, 0),
+			}},
+			expectLock:     true,
+			expectSent:     []string{eventBody(1), eventBody(2), eventBody(3)},
+			expectDeleted:  []int64{1, 2, 3},
+			expectRemained: 0,
+		},
+		{
+			name:          "sink error reschedules the event with the next backoff",
+			dao:           &fakeOutboxDao{events: []*api.OutboxEvent{outboxEvent(1, 2), outboxEvent(2, 0)}},
+			reject:        map[string]bool{eventBody(1): true},
+			expectLock:    true,
+			expectSent:    []string{eventBody(1), eventBody(2)},
+			expectDeleted: []int64{2},
+			expectFailed: []failedDelivery{
+				{id: 1, retryAfter: 4 * time.Second, lastError: "sink unavailable"},
+			},
+			expectRemained: 0,
+		},
+		{
+			name: "delete error stops the batch",
+			dao: &fakeOutboxDao{
+				deleteErr: storageErr,
+				events:    []*api.OutboxEvent{outboxEvent(1, 0), outboxEvent(2, 0)},
+			},
+			expectLock:     true,
+			expectSent:     []string{eventBody(1)},
+			expectRemained: 2,
+		},
+		{
+			name: "reschedule error stops the batch",
+			dao: &fakeOutboxDao{
+				markErr: storageErr,
+				events:  []*api.OutboxEvent{outboxEvent(1, 0), outboxEvent(2, 0)},
+			},
+			reject:         map[string]bool{eventBody(1): true},
+			expectLock:     true,
+			expectSent:     []string{eventBody(1)},
+			expectRemained: 2,
+		},
+		{
+			name: "read error under the lock stops dispatching",
+			dao: &fakeOutboxDao{
+				lockedFindErr: storageErr,
+				events:        []*api.OutboxEvent{outboxEvent(1, 0)},
+			},
+			expectLock:     true,
+			expectRemained: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			sink := &fakeSink{reject: tt.reject}
+			cfg := &config.EventsConfig{
+				PollInterval: time.Second,
+				BatchSize:    2,
+				Retry:        config.EventRetryConfig{InitialBackoff: time.Second, MaxBackoff: time.Minute},
+			}
+			d := NewDispatcher(tt.dao, newLockSessionFactory(t, tt.expectLock), sink, cfg)
+
+			d.Dispatch(context.Background())
+
+			Expect(sink.sent).To(Equal(tt.expectSent))
+			Expect(tt.dao.deleted).To(Equal(tt.expectDeleted))
+			Expect(tt.dao.failed).To(Equal(tt.expectFailed))
+			Expect(tt.dao.events).To(HaveLen(tt.expectRemained))
+			// only the peek for due events runs without the lock
+			Expect(tt.dao.unlocked).To(Equal([]string{"FindDue"}))
+			Expect(sink.unlocked).To(BeZero())
+		})
+	}
+}
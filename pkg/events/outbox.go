@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+)
+
+// Outbox queues a CloudEvent for a resource change. Enqueue runs in the caller's
+// transaction, so an event is published exactly when the write that produced it commits.
+type Outbox interface {
+	Enqueue(ctx context.Context, changeType string, resource *api.Resource, object json.RawMessage) error
+}
+
+var _ Outbox = &sqlOutbox{}
+
+type sqlOutbox struct {
+	outboxDao dao.ResourceOutboxDao
+	source    string
+}
+
+func NewOutbox(outboxDao dao.ResourceOutboxDao, source string) Outbox {
+	return &sqlOutbox{outboxDao: outboxDao, source: source}
+}
+
+func (o *sqlOutbox) Enqueue(
+	ctx context.Context, changeType string, resource *api.Resource, object json.RawMessage,
+) error {
+	id, err := api.NewID()
+	if err != nil {
+		return err
+	}
+	subject := resource.Href
+	if subject == "" {
+		subject = resource.ID
+	}
+	event, err := json.Marshal(Event{
+		SpecVersion:     SpecVersion,
+		ID:              id,
+		Source:          o.source,
+		Type:            EventType(resource.Kind, changeType),
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            object,
+	})
+	if err != nil {
+		return err
+	}
+	return o.outboxDao.Enqueue(ctx, &api.OutboxEvent{
+		EventID:    id,
+		Kind:       resource.Kind,
+		ResourceID: resource.ID,
+		Event:      event,
+	})
+}
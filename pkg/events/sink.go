@@ -0,0 +1,130 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/config"
+)
+
+// Sink delivers a structured-mode CloudEvent. A nil error means the sink has accepted the
+// event and it will not be sent again.
+type Sink interface {
+	Send(ctx context.Context, event json.RawMessage) error
+}
+
+// NewSink builds the sink selected by cfg.
+func NewSink(cfg *config.EventsConfig) (Sink, error) {
+	switch cfg.Sink {
+	case config.EventSinkWebhook:
+		return NewWebhookSink(cfg.Webhook.URL, cfg.Webhook.Timeout), nil
+	case config.EventSinkFile:
+		return NewFileSink(cfg.File.Path), nil
+	default:
+		return nil, fmt.Errorf("unknown event sink %q", cfg.Sink)
+	}
+}
+
+// WebhookSink POSTs each event to a URL. Any 2xx response is an acknowledgement.
+type WebhookSink struct {
+	client *http.Client
+	url    string
+}
+
+var _ Sink = &WebhookSink{}
+
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		client: &http.Client{Timeout: timeout},
+		url:    url,
+	}
+}
+
+func (s *WebhookSink) Send(ctx context.Context, event json.RawMessage) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(event))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ContentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	// Drain a bounded amount so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// FileSink appends each event as one line of JSON to a file.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+var _ Sink = &FileSink{}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Send(_ context.Context, event json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	line := append(append([]byte(nil), bytes.TrimSpace(event)...), '\n')
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// MemorySink keeps delivered events in memory. It is meant for tests.
+type MemorySink struct {
+	events []json.RawMessage
+	mu     sync.Mutex
+}
+
+var _ Sink = &MemorySink{}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Send(_ context.Context, event json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, append(json.RawMessage(nil), event...))
+	return nil
+}
+
+// Events returns the events received so far, oldest first.
+func (s *MemorySink) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := make([]Event, 0, len(s.events))
+	for _, raw := range s.events {
+		var event Event
+		if err := json.Unmarshal(raw, &event); err == nil {
+			events = append(events, event)
+		}
+	}
+	return events
+}
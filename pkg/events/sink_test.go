@@ -0,0 +1,74 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+const testEvent = `{"specversion":"1.0","id":"e-1","source":"hyperfleet-api",` +
+	`"type":"com.redhat.hyperfleet.cluster.added"}`
+
+func TestWebhookSink_Send(t *testing.T) {
+	RegisterTestingT(t)
+
+	var contentType, body string
+	status := http.StatusAccepted
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL, 5*time.Second)
+	Expect(sink.Send(context.Background(), json.RawMessage(testEvent))).To(Succeed())
+	Expect(contentType).To(Equal(ContentType))
+	Expect(body).To(Equal(testEvent))
+
+	status = http.StatusServiceUnavailable
+	err := sink.Send(context.Background(), json.RawMessage(testEvent))
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("503"))
+}
+
+func TestFileSink_AppendsOneEventPerLine(t *testing.T) {
+	RegisterTestingT(t)
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink := NewFileSink(path)
+	Expect(sink.Send(context.Background(), json.RawMessage(testEvent))).To(Succeed())
+	Expect(sink.Send(context.Background(), json.RawMessage(testEvent+"\n"))).To(Succeed())
+
+	content, err := os.ReadFile(path)
+	Expect(err).NotTo(HaveOccurred())
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	Expect(lines).To(Equal([]string{testEvent, testEvent}))
+}
+
+func TestMemorySink_Events(t *testing.T) {
+	RegisterTestingT(t)
+
+	sink := NewMemorySink()
+	Expect(sink.Send(context.Background(), json.RawMessage(testEvent))).To(Succeed())
+
+	events := sink.Events()
+	Expect(events).To(HaveLen(1))
+	Expect(events[0].ID).To(Equal("e-1"))
+	Expect(events[0].Type).To(Equal("com.redhat.hyperfleet.cluster.added"))
+}
+
+func TestEventType(t *testing.T) {
+	RegisterTestingT(t)
+	Expect(EventType("NodePool", "DELETED")).To(Equal("com.redhat.hyperfleet.nodepool.deleted"))
+}
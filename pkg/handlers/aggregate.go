@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// parseGroupBy reads the comma-separated groupBy query parameter of an aggregate
+// request. The service validates the fields themselves.
+func parseGroupBy(r *http.Request) ([]string, *errors.ServiceError) {
+	v := strings.TrimSpace(r.URL.Query().Get("groupBy"))
+	if v == "" {
+		return nil, nil
+	}
+	fields := strings.Split(v, ",")
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+		if fields[i] == "" {
+			return nil, errors.Validation("groupBy must be a comma-separated list of fields")
+		}
+	}
+	return fields, nil
+}
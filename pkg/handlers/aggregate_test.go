@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+)
+
+func TestResourceHandler_Aggregate(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+
+	east, reconciled := "us-east", "False"
+	groupBy := []string{"labels.region", "status.conditions.Reconciled"}
+	handler, mockResourceSvc := newTestResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().Aggregate(gomock.Any(), "Channel", "", groupBy, gomock.Any()).
+		Return(&api.ResourceAggregate{
+			GroupBy: groupBy,
+			Groups: []api.GroupCount{
+				{Values: []*string{&east, &reconciled}, Count: 2},
+				{Values: []*string{nil, &reconciled}, Count: 1},
+			},
+			Total: 3,
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/hyperfleet/v1/channels:aggregate?groupBy=labels.region,%20status.conditions.Reconciled", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Aggregate(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+	Expect(rr.Body.String()).To(MatchJSON(`{
+		"kind": "ResourceAggregate",
+		"group_by": ["labels.region", "status.conditions.Reconciled"],
+		"groups": [
+			{"values": {"labels.region": "us-east", "status.conditions.Reconciled": "False"}, "count": 2},
+			{"values": {"labels.region": null, "status.conditions.Reconciled": "False"}, "count": 1}
+		],
+		"total": 3,
+		"truncated": false
+	}`))
+}
+
+func TestResourceHandler_Aggregate_InvalidGroupBy(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+
+	handler, _ := newTestResourceHandler(ctrl)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels:aggregate?groupBy=kind,,owner_id", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Aggregate(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusBadRequest), rr.Body.String())
+}
+
+func TestRootResourceHandler_Aggregate(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+
+	handler, mockResourceSvc, _ := newTestRootResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().Aggregate(gomock.Any(), "", "", nil, gomock.Any()).
+		Return(&api.ResourceAggregate{Groups: []api.GroupCount{{Values: []*string{}, Count: 7}}, Total: 7}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/resources:aggregate?search=name%3D'a'", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Aggregate(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+	Expect(rr.Body.String()).To(MatchJSON(`{
+		"kind": "ResourceAggregate",
+		"group_by": [],
+		"groups": [{"values": {}, "count": 7}],
+		"total": 7,
+		"truncated": false
+	}`))
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/openapi"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/presenters"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/response"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+)
+
+// batchRequest is the body of POST /resources:batch.
+type batchRequest struct {
+	Operations []batchOperationRequest `json:"operations"`
+}
+
+// batchOperationRequest is one operation of a batch. A create takes the resource and, for
+// a child kind, parent_id; a patch takes kind, id, and patch; a delete takes kind and id.
+// An ID starting with "$" names the ref of an earlier create in the same batch.
+type batchOperationRequest struct {
+	Resource *openapi.ResourceCreateRequest `json:"resource,omitempty"`
+	Patch    *openapi.ResourcePatchRequest  `json:"patch,omitempty"`
+	Op       string                         `json:"op"`
+	Kind     string                         `json:"kind,omitempty"`
+	Ref      string                         `json:"ref,omitempty"`
+	ID       string                         `json:"id,omitempty"`
+	ParentID string                         `json:"parent_id,omitempty"`
+	IfMatch  string                         `json:"if_match,omitempty"`
+}
+
+// batchOperationResult reports one operation. Status is the code the operation would have
+// had as a single request; when the batch fails, the failed operation carries its problem
+// and every other operation reports 424 Failed Dependency, as none of them took effect.
+type batchOperationResult struct {
+	Resource *openapi.Resource       `json:"resource,omitempty"`
+	Problem  *openapi.ProblemDetails `json:"problem,omitempty"`
+	Op       string                  `json:"op"`
+	Ref      string                  `json:"ref,omitempty"`
+	Status   int                     `json:"status"`
+}
+
+// Batch applies an ordered list of create, patch, and delete operations in the request's
+// transaction, all or nothing.
+func (h *RootResourceHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	if svcErr := decodeStrict(r, &req); svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	ops := make([]services.BatchOperation, 0, len(req.Operations))
+	for i := range req.Operations {
+		op, svcErr := h.convertBatchOperation(&req.Operations[i])
+		if svcErr != nil {
+			handleError(r, w, batchOperationError(i, svcErr))
+			return
+		}
+		ops = append(ops, op)
+	}
+
+	results, svcErr := h.service.Batch(r.Context(), ops)
+	if svcErr != nil && len(results) == 0 {
+		handleError(r, w, svcErr)
+		return
+	}
+	if svcErr != nil {
+		writeBatchFailure(w, r, ops, results)
+		return
+	}
+
+	presented := make([]batchOperationResult, 0, len(results))
+	for _, result := range results {
+		resource := presenters.PresentResource(result.Resource)
+		presented = append(presented, batchOperationResult{
+			Op:       result.Op,
+			Ref:      result.Ref,
+			Status:   batchStatus(result.Op),
+			Resource: &resource,
+		})
+	}
+	writeJSONResponse(w, r, http.StatusOK, map[string]interface{}{"results": presented})
+}
+
+// convertBatchOperation validates an operation the way the single-resource endpoint for it
+// does, and converts it for the service.
+func (h *RootResourceHandler) convertBatchOperation(
+	req *batchOperationRequest,
+) (services.BatchOperation, *errors.ServiceError) {
+	op := services.BatchOperation{
+		Op:       req.Op,
+		Kind:     req.Kind,
+		Ref:      req.Ref,
+		ID:       req.ID,
+		ParentID: req.ParentID,
+		IfMatch:  services.ParseETags(req.IfMatch),
+	}
+
+	switch req.Op {
+	case services.BatchCreate:
+		if req.Resource == nil {
+			return op, errors.Validation("resource is required")
+		}
+		if op.Kind == "" {
+			op.Kind = req.Resource.Kind
+		}
+		descriptor, ok := registry.Get(op.Kind)
+		if !ok {
+			return op, errors.Validation("Unknown entity kind: %s", op.Kind)
+		}
+		validateFuncs := []validate{
+			validateKind(req.Resource, "Kind", "kind", descriptor.Kind),
+			validateName(req.Resource, "Name", "name", descriptor.NameMinLen, descriptor.NameMaxLen),
+			validateSpec(req.Resource, "Spec", "spec"),
+			validateLabels(req.Resource, "Labels"),
+		}
+		for _, validateFunc := range validateFuncs {
+			if svcErr := validateFunc(); svcErr != nil {
+				return op, svcErr
+			}
+		}
+		if svcErr := validateSpecSchema(h.validator, descriptor.Plural, req.Resource.Spec); svcErr != nil {
+			return op, svcErr
+		}
+		resource, convErr := presenters.ConvertResource(req.Resource)
+		if convErr != nil {
+			return op, errors.GeneralError("failed to convert resource: %v", convErr)
+		}
+		op.Resource = resource
+		op.References = extractReferences(req.Resource.References)
+	case services.BatchPatch:
+		if req.Patch == nil {
+			return op, errors.Validation("patch is required")
+		}
+		descriptor, ok := registry.Get(op.Kind)
+		if !ok {
+			return op, errors.Validation("Unknown entity kind: %s", op.Kind)
+		}
+		validateFuncs := []validate{
+			validatePatchRequest(req.Patch),
+			validateLabels(req.Patch, "Labels"),
+		}
+		for _, validateFunc := range validateFuncs {
+			if svcErr := validateFunc(); svcErr != nil {
+				return op, svcErr
+			}
+		}
+		if req.Patch.Spec != nil {
+			if svcErr := validateSpecSchema(h.validator, descriptor.Plural, *req.Patch.Spec); svcErr != nil {
+				return op, svcErr
+			}
+		}
+		op.Patch = convertResourcePatch(req.Patch)
+	}
+	return op, nil
+}
+
+// writeBatchFailure writes the failed operation's problem, extended with the outcome of
+// every operation of the batch.
+func writeBatchFailure(
+	w http.ResponseWriter, r *http.Request, ops []services.BatchOperation, results []services.BatchResult,
+) {
+	failedIndex := len(results) - 1
+	failed := batchOperationError(failedIndex, results[failedIndex].Err)
+	traceID, _ := logger.GetRequestID(r.Context())
+	logger.With(r.Context(),
+		"code", failed.RFC9457Code,
+		"http_code", failed.HTTPCode,
+		"reason", failed.Reason).Info("Batch rolled back")
+
+	outcomes := make([]batchOperationResult, 0, len(ops))
+	for i, op := range ops {
+		outcome := batchOperationResult{Op: op.Op, Ref: op.Ref, Status: http.StatusFailedDependency}
+		if i == failedIndex {
+			problem := failed.AsProblemDetails(r.URL.Path, traceID)
+			outcome.Status = failed.HTTPCode
+			outcome.Problem = &problem
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	body, err := problemWithExtension(failed.AsProblemDetails(r.URL.Path, traceID), "operations", outcomes)
+	if err != nil {
+		handleError(r, w, errors.GeneralError("Unable to marshal batch failure: %s", err))
+		return
+	}
+	response.WriteProblemDetailsResponse(w, r, failed.HTTPCode, body)
+}
+
+// problemWithExtension adds an RFC 9457 extension member to problem details.
+func problemWithExtension(
+	problem openapi.ProblemDetails, name string, value interface{},
+) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(problem)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]json.RawMessage
+	if err = json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	if body[name], err = json.Marshal(value); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// batchOperationError names the operation an error belongs to. The error is copied, so
+// the service's results keep the original.
+func batchOperationError(index int, err *errors.ServiceError) *errors.ServiceError {
+	named := *err
+	named.Reason = fmt.Sprintf("operations[%d]: %s", index, err.Reason)
+	return &named
+}
+
+func batchStatus(op string) int {
+	switch op {
+	case services.BatchCreate:
+		return http.StatusCreated
+	case services.BatchDelete:
+		return http.StatusAccepted
+	default:
+		return http.StatusOK
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+)
+
+const testBatchBody = `{"operations": [
+	{"op": "create", "ref": "ch", "resource": {"kind": "Channel", "name": "stable", "spec": {}}},
+	{"op": "delete", "kind": "Channel", "id": "ch-9", "if_match": "\"3\""}
+]}`
+
+func registerBatchDescriptors(t *testing.T) {
+	registry.Register(channelDescriptor)
+	t.Cleanup(func() { registry.Reset() })
+}
+
+func serveBatch(handler *RootResourceHandler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/hyperfleet/v1/resources:batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler.Batch(rr, req)
+	return rr
+}
+
+func TestRootResourceHandler_Batch(t *testing.T) {
+	RegisterTestingT(t)
+	registerBatchDescriptors(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockSvc, _ := newTestRootResourceHandler(ctrl)
+	now := time.Now()
+	created := &api.Resource{
+		Meta: api.Meta{ID: "ch-1", CreatedTime: now, UpdatedTime: now},
+		Kind: "Channel",
+		Name: "stable",
+		Href: "/api/hyperfleet/v1/channels/ch-1",
+		Spec: []byte(`{}`),
+	}
+	mockSvc.EXPECT().Batch(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, ops []services.BatchOperation) ([]services.BatchResult, *errors.ServiceError) {
+			Expect(ops).To(HaveLen(2))
+			Expect(ops[0].Kind).To(Equal("Channel"))
+			Expect(ops[0].Resource.Name).To(Equal("stable"))
+			Expect(ops[1].IfMatch).To(Equal([]string{`"3"`}))
+			return []services.BatchResult{
+				{Op: services.BatchCreate, Ref: "ch", Resource: created},
+				{Op: services.BatchDelete, Resource: created},
+			}, nil
+		})
+
+	rr := serveBatch(handler, testBatchBody)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+
+	var body struct {
+		Results []batchOperationResult `json:"results"`
+	}
+	Expect(json.Unmarshal(rr.Body.Bytes(), &body)).To(Succeed())
+	Expect(body.Results).To(HaveLen(2))
+	Expect(body.Results[0].Status).To(Equal(http.StatusCreated))
+	Expect(body.Results[0].Ref).To(Equal("ch"))
+	Expect(body.Results[1].Status).To(Equal(http.StatusAccepted))
+}
+
+func TestRootResourceHandler_Batch_Failure(t *testing.T) {
+	RegisterTestingT(t)
+	registerBatchDescriptors(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockSvc, _ := newTestRootResourceHandler(ctrl)
+	notFound := errors.NotFound("Channel with id='ch-9' not found")
+	mockSvc.EXPECT().Batch(gomock.Any(), gomock.Any()).Return([]services.BatchResult{
+		{Op: services.BatchCreate, Ref: "ch", Resource: &api.Resource{Kind: "Channel"}},
+		{Op: services.BatchDelete, Err: notFound},
+	}, notFound)
+
+	rr := serveBatch(handler, testBatchBody)
+	Expect(rr.Code).To(Equal(http.StatusNotFound), rr.Body.String())
+	Expect(rr.Header().Get("Content-Type")).To(Equal("application/problem+json"))
+
+	var body struct {
+		Detail     string                 `json:"detail"`
+		Operations []batchOperationResult `json:"operations"`
+	}
+	Expect(json.Unmarshal(rr.Body.Bytes(), &body)).To(Succeed())
+	Expect(body.Detail).To(HavePrefix("operations[1]: "))
+	Expect(body.Operations).To(HaveLen(2))
+	Expect(body.Operations[0].Status).To(Equal(http.StatusFailedDependency))
+	Expect(body.Operations[0].Problem).To(BeNil())
+	Expect(body.Operations[1].Status).To(Equal(http.StatusNotFound))
+	Expect(body.Operations[1].Problem).ToNot(BeNil())
+}
+
+func TestRootResourceHandler_Batch_InvalidOperation(t *testing.T) {
+	RegisterTestingT(t)
+	registerBatchDescriptors(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, _, _ := newTestRootResourceHandler(ctrl)
+	rr := serveBatch(handler, `{"operations": [{"op": "patch", "kind": "Channel", "id": "ch-1"}]}`)
+	Expect(rr.Code).To(Equal(http.StatusBadRequest), rr.Body.String())
+	Expect(rr.Body.String()).To(ContainSubstring("operations[0]: patch is required"))
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// DryRunHeader is set on the response to a dry-run request.
+const DryRunHeader = "Dry-Run"
+
+// DryRunnable wraps a write handler so that requests with ?dryRun=true run in full,
+// with every validation, policy check and recomputation the real request would make,
+// but roll back the request's transaction instead of committing it. The response is
+// the would-be result, marked with the Dry-Run header. The rollback also discards the
+// Idempotency-Key claim of an Idempotent handler, so a dry run is never replayed.
+func DryRunnable(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dryRun, err := dryRunRequested(r)
+		if err != nil {
+			handleError(r, w, err)
+			return
+		}
+		if !dryRun {
+			next(w, r)
+			return
+		}
+		w.Header().Set(DryRunHeader, "true")
+		next(w, r.WithContext(db.WithDryRun(r.Context())))
+	}
+}
+
+// dryRunRequested reports whether a write request asked for a dry run (?dryRun=true).
+func dryRunRequested(r *http.Request) (bool, *errors.ServiceError) {
+	v := strings.TrimSpace(r.URL.Query().Get("dryRun"))
+	if v == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, errors.ValidationWithDetails("Invalid query parameters", []errors.ValidationDetail{{
+			Field:      "dryRun",
+			Value:      v,
+			Constraint: "format",
+			Message:    "must be a boolean",
+		}})
+	}
+	return dryRun, nil
+}
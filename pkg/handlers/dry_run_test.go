@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+)
+
+func TestDryRunnable(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectDryRun   bool
+	}{
+		{name: "Not requested", query: "", expectedStatus: http.StatusNoContent},
+		{name: "False", query: "?dryRun=false", expectedStatus: http.StatusNoContent},
+		{name: "True", query: "?dryRun=true", expectedStatus: http.StatusNoContent, expectDryRun: true},
+		{name: "Invalid", query: "?dryRun=maybe", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			called, dryRun := false, false
+			handler := DryRunnable(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				dryRun = db.IsDryRun(r.Context())
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/hyperfleet/v1/channels/ch-1"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			handler(rr, req)
+
+			Expect(rr.Code).To(Equal(tt.expectedStatus), rr.Body.String())
+			Expect(called).To(Equal(tt.expectedStatus == http.StatusNoContent))
+			Expect(dryRun).To(Equal(tt.expectDryRun))
+			if tt.expectDryRun {
+				Expect(rr.Header().Get(DryRunHeader)).To(Equal("true"))
+			} else {
+				Expect(rr.Header().Get(DryRunHeader)).To(BeEmpty())
+			}
+		})
+	}
+}
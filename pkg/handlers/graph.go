@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+)
+
+// parseGraphDepth reads the depth query parameter of a graph request, defaulting to
+// services.DefaultGraphDepth. The service checks the upper bound.
+func parseGraphDepth(r *http.Request) (int, *errors.ServiceError) {
+	v := r.URL.Query().Get("depth")
+	if v == "" {
+		return services.DefaultGraphDepth, nil
+	}
+	depth, err := strconv.Atoi(v)
+	if err != nil || depth < 1 {
+		return 0, errors.Validation("depth must be a positive integer")
+	}
+	return depth, nil
+}
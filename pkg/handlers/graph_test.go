@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+)
+
+func TestResourceHandler_Referencers(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+
+	handler, mockResourceSvc := newTestResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().ListReferencers(gomock.Any(), "Channel", "ch-123").Return([]api.ResourceSummary{
+		{ID: "v-1", Kind: "Version", Name: "4.16", Href: "/api/hyperfleet/v1/versions/v-1", RefType: "channel"},
+		{ID: "v-2", Kind: "Version", Name: "4.17", Href: "/api/hyperfleet/v1/versions/v-2", RefType: "channel"},
+		{ID: "c-1", Kind: "Cluster", Name: "prod", RefType: "upgrade_channel"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-123/referencers", nil)
+	req.SetPathValue("id", "ch-123")
+	rr := httptest.NewRecorder()
+
+	handler.Referencers(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+	Expect(rr.Body.String()).To(MatchJSON(`{
+		"kind": "ReferencerList",
+		"total": 3,
+		"items": {
+			"channel": [
+				{"id": "v-1", "kind": "Version", "name": "4.16", "href": "/api/hyperfleet/v1/versions/v-1"},
+				{"id": "v-2", "kind": "Version", "name": "4.17", "href": "/api/hyperfleet/v1/versions/v-2"}
+			],
+			"upgrade_channel": [{"id": "c-1", "kind": "Cluster", "name": "prod"}]
+		}
+	}`))
+}
+
+func TestRootResourceHandler_Graph(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+
+	handler, mockResourceSvc, _ := newTestRootResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().Graph(gomock.Any(), "w-1", 3).Return(&api.ResourceGraph{
+		RootID: "w-1",
+		Depth:  3,
+		Nodes: []api.ResourceSummary{
+			{ID: "w-1", Kind: "WifConfig", Name: "wif"},
+			{ID: "c-1", Kind: "Cluster", Name: "prod"},
+		},
+		Edges: []api.GraphEdge{
+			{Type: api.GraphEdgeReferences, SourceID: "c-1", TargetID: "w-1", RefType: "wif_config"},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/resources/w-1/graph?depth=3", nil)
+	req.SetPathValue("id", "w-1")
+	rr := httptest.NewRecorder()
+
+	handler.Graph(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+	Expect(rr.Body.String()).To(MatchJSON(`{
+		"kind": "ResourceGraph",
+		"root": "w-1",
+		"depth": 3,
+		"truncated": false,
+		"nodes": [
+			{"id": "w-1", "kind": "WifConfig", "name": "wif"},
+			{"id": "c-1", "kind": "Cluster", "name": "prod"}
+		],
+		"edges": [{"type": "references", "source": "c-1", "target": "w-1", "ref_type": "wif_config"}]
+	}`))
+}
+
+func TestRootResourceHandler_Graph_DefaultDepth(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+
+	handler, mockResourceSvc, _ := newTestRootResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().Graph(gomock.Any(), "w-1", services.DefaultGraphDepth).
+		Return(&api.ResourceGraph{RootID: "w-1", Depth: services.DefaultGraphDepth}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/resources/w-1/graph", nil)
+	req.SetPathValue("id", "w-1")
+	rr := httptest.NewRecorder()
+
+	handler.Graph(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+
+	var body map[string]interface{}
+	Expect(json.Unmarshal(rr.Body.Bytes(), &body)).To(Succeed())
+	Expect(body["nodes"]).To(BeEmpty())
+	Expect(body["edges"]).To(BeEmpty())
+}
+
+func TestRootResourceHandler_Graph_InvalidDepth(t *testing.T) {
+	for _, depth := range []string{"0", "-1", "deep"} {
+		t.Run(depth, func(t *testing.T) {
+			RegisterTestingT(t)
+			ctrl := gomock.NewController(t)
+
+			handler, _, _ := newTestRootResourceHandler(ctrl)
+			req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/resources/w-1/graph?depth="+depth, nil)
+			req.SetPathValue("id", "w-1")
+			rr := httptest.NewRecorder()
+
+			handler.Graph(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusBadRequest), rr.Body.String())
+		})
+	}
+}
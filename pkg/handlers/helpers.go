@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	goerrors "errors"
+	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/openapi"
@@ -14,6 +19,8 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
 )
 
 func writeJSONResponse(w http.ResponseWriter, r *http.Request, code int, payload interface{}) {
@@ -105,7 +112,7 @@ func handleError(r *http.Request, w http.ResponseWriter, err *errors.ServiceErro
 // Note: This function only validates the fields parameter, not pagination parameters,
 // to avoid rejecting irrelevant query params on single-resource GET endpoints.
 func applyFieldFilter(r *http.Request, presented interface{}) (interface{}, *errors.ServiceError) {
-	fields := ensureIDField(normalizeList(r.URL.Query()["fields"]))
+	fields := requestedFields(r)
 	if fields != nil {
 		filtered, filterErr := presenters.FilterSingle(fields, presented)
 		if filterErr != nil {
@@ -116,6 +123,28 @@ func applyFieldFilter(r *http.Request, presented interface{}) (interface{}, *err
 	return presented, nil
 }
 
+// validateSpecFields rejects requested fields under spec that the spec schema of kind
+// does not declare. Nothing is checked when kind is empty or has no schema loaded.
+func validateSpecFields(validator *validators.SchemaValidator, kind string, fields []string) *errors.ServiceError {
+	descriptor, ok := registry.Get(kind)
+	if !ok {
+		return nil
+	}
+	for _, field := range fields {
+		path, found := strings.CutPrefix(field, "spec.")
+		if found && !validator.HasSpecField(descriptor.Plural, strings.Split(path, ".")) {
+			return errors.Validation("The following field(s) doesn't exist in `spec`: %s", field)
+		}
+	}
+	return nil
+}
+
+// requestedFields returns the field paths of the ?fields query parameter with id added,
+// or nil when the full resource is requested.
+func requestedFields(r *http.Request) []string {
+	return ensureIDField(normalizeList(r.URL.Query()["fields"]))
+}
+
 func cleanTypeMismatchError(err error) *errors.ServiceError {
 	var typeErr *json.UnmarshalTypeError
 	if !goerrors.As(err, &typeErr) {
@@ -179,3 +208,44 @@ func childCreateRejection(descriptor registry.EntityDescriptor) *errors.ServiceE
 	svcErr.HTTPCode = http.StatusUnprocessableEntity
 	return svcErr
 }
+
+// setETag advertises the resource's current version for conditional requests.
+func setETag(w http.ResponseWriter, resource *api.Resource) {
+	w.Header().Set("ETag", resource.ETag())
+}
+
+// representationETag returns the ETag of what a GET of resource serves. A ?fields
+// projection is a different representation, so its tag adds a digest of the sorted
+// field paths to the version: a cached projection never validates the full resource,
+// nor the full resource a projection.
+func representationETag(r *http.Request, resource *api.Resource) string {
+	etag := resource.ETag()
+	fields := requestedFields(r)
+	if fields == nil {
+		return etag
+	}
+	sorted := slices.Clone(fields)
+	slices.Sort(sorted)
+	digest := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return fmt.Sprintf(`%s-%x"`, strings.TrimSuffix(etag, `"`), digest[:4])
+}
+
+// notModified answers a GET whose If-None-Match already names etag, the tag of the
+// representation it would serve, with 304 and no body. It reports whether the response
+// was written.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	etags := services.ParseETags(r.Header.Get("If-None-Match"))
+	if len(etags) == 0 || !services.MatchETag(etags, etag) {
+		return false
+	}
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Vary", "Authorization")
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// withIfMatch carries the request's If-Match tags to the service, which checks them
+// against the row it locks for the write.
+func withIfMatch(r *http.Request) context.Context {
+	return services.WithIfMatch(r.Context(), services.ParseETags(r.Header.Get("If-Match")))
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+)
+
+const (
+	// IdempotencyKeyHeader names the key a client sends to make a write safe to retry.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on a response replayed for a repeated key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// replayedHeaders are the response headers kept with an idempotent response.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// Idempotent wraps a write handler so that requests carrying an Idempotency-Key header
+// run at most once per key. The key is claimed in the request's transaction; a successful
+// response is stored with it and replayed, status code and body, for every retry that
+// sends the same method, path, query, and body until the key expires. A retry with a
+// different request is rejected with 422. Failed requests are not stored, so a retry
+// runs them again: the claim is released, or discarded with the transaction when the
+// failure marked it for rollback. Requests without the header are passed through unchanged.
+func Idempotent(service services.IdempotencyService, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			handleError(r, w, errors.BadRequest(
+				"%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			handleError(r, w, errors.MalformedRequest("Failed to read request body"))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := r.Context()
+		recorded, svcErr := service.Claim(ctx, key, requestHash(r, body))
+		if svcErr != nil {
+			handleError(r, w, svcErr)
+			return
+		}
+		if recorded != nil {
+			replayResponse(w, r, recorded.StatusCode, recorded.ResponseHeaders, recorded.ResponseBody)
+			return
+		}
+
+		buffered := &bufferedResponseWriter{header: make(http.Header)}
+		next(buffered, r)
+		if buffered.status == 0 {
+			buffered.status = http.StatusOK
+		}
+
+		if buffered.status < http.StatusOK || buffered.status >= http.StatusMultipleChoices {
+			// A failure that marked the transaction for rollback may have aborted it, so
+			// it can run no further statements; the rollback discards the claim anyway.
+			if !db.IsMarkedForRollback(ctx) {
+				svcErr = service.Release(ctx, key)
+			}
+		} else {
+			svcErr = service.Complete(ctx, key, buffered.status, buffered.keptHeaders(), buffered.body.Bytes())
+		}
+		if svcErr != nil {
+			handleError(r, w, svcErr)
+			return
+		}
+		buffered.flushTo(w, r)
+	}
+}
+
+// requestHash identifies a request for comparison with a retry.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replayResponse(w http.ResponseWriter, r *http.Request, status int, headers []byte, body []byte) {
+	var kept map[string]string
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &kept); err != nil {
+			handleError(r, w, errors.GeneralError("Unable to read recorded response headers: %s", err))
+			return
+		}
+	}
+	for name, value := range kept {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set("Vary", "Authorization")
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		logger.WithError(r.Context(), err).Warn("Failed to write replayed response body")
+	}
+}
+
+// bufferedResponseWriter holds a response until it is known whether it can be recorded.
+type bufferedResponseWriter struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponseWriter) keptHeaders() map[string]string {
+	kept := make(map[string]string)
+	for _, name := range replayedHeaders {
+		if value := b.header.Get(name); value != "" {
+			kept[name] = value
+		}
+	}
+	return kept
+}
+
+func (b *bufferedResponseWriter) flushTo(w http.ResponseWriter, r *http.Request) {
+	for name, values := range b.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(b.status)
+	if _, err := w.Write(b.body.Bytes()); err != nil {
+		logger.WithError(r.Context(), err).Warn("Failed to write response body")
+	}
+}
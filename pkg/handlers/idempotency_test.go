@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	dbContext "github.com/openshift-hyperfleet/hyperfleet-api/pkg/db/db_context"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db/transaction"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+)
+
+func serveIdempotent(
+	service services.IdempotencyService, key string, next http.HandlerFunc,
+) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/hyperfleet/v1/channels", strings.NewReader(`{"name":"stable"}`))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rr := httptest.NewRecorder()
+	Idempotent(service, next)(rr, req)
+	return rr
+}
+
+func createdHandler(calls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("ETag", `"1-1"`)
+		writeJSONResponse(w, r, http.StatusCreated, map[string]string{"id": "ch-1"})
+	}
+}
+
+func TestIdempotent_WithoutKey(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	calls := 0
+	rr := serveIdempotent(services.NewMockIdempotencyService(ctrl), "", createdHandler(&calls))
+	Expect(rr.Code).To(Equal(http.StatusCreated))
+	Expect(calls).To(Equal(1))
+}
+
+func TestIdempotent_RecordsSuccess(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := services.NewMockIdempotencyService(ctrl)
+	mockSvc.EXPECT().Claim(gomock.Any(), "k1", gomock.Any()).Return(nil, nil)
+	mockSvc.EXPECT().Complete(
+		gomock.Any(), "k1", http.StatusCreated,
+		map[string]string{"Content-Type": "application/json", "ETag": `"1-1"`}, []byte(`{"id":"ch-1"}`),
+	).Return(nil)
+
+	calls := 0
+	rr := serveIdempotent(mockSvc, "k1", createdHandler(&calls))
+	Expect(rr.Code).To(Equal(http.StatusCreated))
+	Expect(rr.Body.String()).To(MatchJSON(`{"id":"ch-1"}`))
+	Expect(rr.Header().Get("ETag")).To(Equal(`"1-1"`))
+	Expect(rr.Header().Get(IdempotentReplayedHeader)).To(BeEmpty())
+	Expect(calls).To(Equal(1))
+}
+
+func TestIdempotent_ReplaysRecordedResponse(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := services.NewMockIdempotencyService(ctrl)
+	mockSvc.EXPECT().Claim(gomock.Any(), "k1", gomock.Any()).Return(&api.IdempotencyKey{
+		StatusCode:      http.StatusCreated,
+		ResponseHeaders: datatypes.JSON(`{"Content-Type":"application/json","ETag":"\"1-1\""}`),
+		ResponseBody:    []byte(`{"id":"ch-1"}`),
+	}, nil)
+
+	calls := 0
+	rr := serveIdempotent(mockSvc, "k1", createdHandler(&calls))
+	Expect(rr.Code).To(Equal(http.StatusCreated))
+	Expect(rr.Body.String()).To(MatchJSON(`{"id":"ch-1"}`))
+	Expect(rr.Header().Get("ETag")).To(Equal(`"1-1"`))
+	Expect(rr.Header().Get(IdempotentReplayedHeader)).To(Equal("true"))
+	Expect(calls).To(BeZero())
+}
+
+func TestIdempotent_ReleasesFailure(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := services.NewMockIdempotencyService(ctrl)
+	mockSvc.EXPECT().Claim(gomock.Any(), "k1", gomock.Any()).Return(nil, nil)
+	mockSvc.EXPECT().Release(gomock.Any(), "k1").Return(nil)
+
+	rr := serveIdempotent(mockSvc, "k1", func(w http.ResponseWriter, r *http.Request) {
+		handleError(r, w, errors.Conflict("Channel 'stable' already exists"))
+	})
+	Expect(rr.Code).To(Equal(http.StatusConflict))
+	Expect(rr.Header().Get("Content-Type")).To(Equal("application/problem+json"))
+}
+
+func TestIdempotent_LeavesRolledBackFailureToRollback(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No Release is expected: the transaction is aborted and its rollback drops the claim.
+	mockSvc := services.NewMockIdempotencyService(ctrl)
+	mockSvc.EXPECT().Claim(gomock.Any(), "k1", gomock.Any()).Return(nil, nil)
+
+	ctx := dbContext.WithTransaction(context.Background(), transaction.BuildWithGORM(nil))
+	req := httptest.NewRequest(http.MethodPost, "/api/hyperfleet/v1/channels", strings.NewReader(`{"name":"stable"}`)).
+		WithContext(ctx)
+	req.Header.Set(IdempotencyKeyHeader, "k1")
+	rr := httptest.NewRecorder()
+	Idempotent(mockSvc, func(w http.ResponseWriter, r *http.Request) {
+		db.MarkForRollback(r.Context(), stderrors.New("duplicate key value violates unique constraint"))
+		handleError(r, w, errors.Conflict("Channel 'stable' already exists"))
+	})(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusConflict))
+}
+
+func TestIdempotent_RejectsReusedKey(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := services.NewMockIdempotencyService(ctrl)
+	mockSvc.EXPECT().Claim(gomock.Any(), "k1", gomock.Any()).
+		Return(nil, errors.IdempotencyKeyReused("Idempotency-Key %q was already used for a different request", "k1"))
+
+	calls := 0
+	rr := serveIdempotent(mockSvc, "k1", createdHandler(&calls))
+	Expect(rr.Code).To(Equal(http.StatusUnprocessableEntity))
+	Expect(calls).To(BeZero())
+}
+
+func TestIdempotent_RejectsLongKey(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	calls := 0
+	rr := serveIdempotent(services.NewMockIdempotencyService(ctrl), strings.Repeat("k", 256), createdHandler(&calls))
+	Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	Expect(calls).To(BeZero())
+}
+
+func TestRequestHash(t *testing.T) {
+	RegisterTestingT(t)
+
+	post := httptest.NewRequest(http.MethodPost, "/api/hyperfleet/v1/channels", nil)
+	other := httptest.NewRequest(http.MethodPost, "/api/hyperfleet/v1/wifconfigs", nil)
+	Expect(requestHash(post, []byte(`{"a":1}`))).To(Equal(requestHash(post, []byte(`{"a":1}`))))
+	Expect(requestHash(post, []byte(`{"a":1}`))).ToNot(Equal(requestHash(post, []byte(`{"a":2}`))))
+	Expect(requestHash(post, []byte(`{"a":1}`))).ToNot(Equal(requestHash(other, []byte(`{"a":1}`))))
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
+)
+
+// kindDescription presents a registry.EntityDescriptor to clients discovering the model.
+// NameMinLen and NameMaxLen are the bounds names are validated against, defaults included.
+type kindDescription struct {
+	SpecSchema       map[string]interface{} `json:"spec_schema,omitempty"`
+	Kind             string                 `json:"kind"`
+	Plural           string                 `json:"plural"`
+	Href             string                 `json:"href"`
+	CollectionHref   string                 `json:"collection_href"`
+	ParentKind       string                 `json:"parent_kind,omitempty"`
+	OnParentDelete   string                 `json:"on_parent_delete,omitempty"`
+	SpecSchemaName   string                 `json:"spec_schema_name,omitempty"`
+	RequiredAdapters []string               `json:"required_adapters"`
+	References       []kindReference        `json:"references"`
+	ConditionTypes   []string               `json:"condition_types"`
+	ImmutableFields  []string               `json:"immutable_fields,omitempty"`
+	StatusFields     []string               `json:"status_fields,omitempty"`
+	NameMinLen       int                    `json:"name_min_len"`
+	NameMaxLen       int                    `json:"name_max_len"`
+}
+
+// kindReference presents a registry.ReferenceDescriptor. Max 0 means unlimited, and
+// OnTargetDelete is the effective policy, so an unset one reads as restrict.
+type kindReference struct {
+	RefType        string `json:"ref_type"`
+	TargetKind     string `json:"target_kind"`
+	OnTargetDelete string `json:"on_target_delete"`
+	Min            int    `json:"min"`
+	Max            int    `json:"max"`
+}
+
+// KindHandler serves the registered entity kinds, so clients can discover the model
+// instead of reading the deployment's configuration.
+type KindHandler struct {
+	validator *validators.SchemaValidator
+}
+
+func NewKindHandler(validator *validators.SchemaValidator) *KindHandler {
+	return &KindHandler{validator: validator}
+}
+
+// List returns every registered kind, ordered by kind.
+func (h *KindHandler) List(w http.ResponseWriter, r *http.Request) {
+	descriptors := registry.All()
+	slices.SortFunc(descriptors, func(a, b registry.EntityDescriptor) int {
+		return cmp.Compare(a.Kind, b.Kind)
+	})
+
+	items := make([]kindDescription, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		item, err := h.describe(descriptor)
+		if err != nil {
+			handleError(r, w, err)
+			return
+		}
+		items = append(items, item)
+	}
+	writeJSONResponse(w, r, http.StatusOK, map[string]interface{}{
+		"kind":  "KindList",
+		"total": len(items),
+		"items": items,
+	})
+}
+
+// Get returns one kind, looked up by kind or by plural.
+func (h *KindHandler) Get(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("kind")
+	descriptor, ok := registry.Get(name)
+	if !ok {
+		descriptor, ok = descriptorByPlural(name)
+	}
+	if !ok {
+		handleError(r, w, errors.NotFound("Entity kind '%s' not found", name))
+		return
+	}
+
+	item, err := h.describe(descriptor)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+	writeJSONResponse(w, r, http.StatusOK, item)
+}
+
+func (h *KindHandler) describe(d registry.EntityDescriptor) (kindDescription, *errors.ServiceError) {
+	specSchema, err := h.validator.SpecJSONSchema(d.Plural)
+	if err != nil {
+		return kindDescription{}, errors.GeneralError("Unable to present the spec schema of %s: %s", d.Kind, err)
+	}
+
+	references := make([]kindReference, 0, len(d.References))
+	for _, ref := range d.References {
+		references = append(references, kindReference{
+			RefType:        ref.RefType,
+			TargetKind:     ref.TargetKind,
+			OnTargetDelete: string(ref.TargetDeletePolicy()),
+			Min:            ref.Min,
+			Max:            ref.Max,
+		})
+	}
+	conditionTypes := make([]string, 0, len(d.Conditions))
+	for _, rule := range d.Conditions {
+		conditionTypes = append(conditionTypes, rule.Type)
+	}
+	var statusFields []string
+	for _, rule := range d.StatusFields {
+		statusFields = append(statusFields, rule.Name)
+	}
+	nameMinLen, nameMaxLen := nameLenBounds(d.NameMinLen, d.NameMaxLen)
+
+	return kindDescription{
+		Kind:             d.Kind,
+		Plural:           d.Plural,
+		Href:             fmt.Sprintf("/api/hyperfleet/v1/kinds/%s", d.Kind),
+		CollectionHref:   fmt.Sprintf("/api/hyperfleet/v1/%s", d.Plural),
+		ParentKind:       d.ParentKind,
+		OnParentDelete:   string(d.OnParentDelete),
+		SpecSchemaName:   d.SpecSchemaName,
+		RequiredAdapters: append([]string{}, d.RequiredAdapters...),
+		References:       references,
+		ConditionTypes:   conditionTypes,
+		ImmutableFields:  append([]string(nil), d.ImmutableFields...),
+		StatusFields:     statusFields,
+		NameMinLen:       nameMinLen,
+		NameMaxLen:       nameMaxLen,
+		SpecSchema:       specSchema,
+	}, nil
+}
+
+func descriptorByPlural(plural string) (registry.EntityDescriptor, bool) {
+	for _, descriptor := range registry.All() {
+		if descriptor.Plural == plural {
+			return descriptor, true
+		}
+	}
+	return registry.EntityDescriptor{}, false
+}
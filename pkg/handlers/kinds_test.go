@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+)
+
+func registerKindDescriptors(t *testing.T) {
+	registry.Reset()
+	t.Cleanup(func() { registry.Reset() })
+
+	registry.Register(channelDescriptor)
+	version := versionDescriptor
+	version.OnParentDelete = registry.OnParentDeleteRestrict
+	version.RequiredAdapters = []string{"validation"}
+	version.References = []registry.ReferenceDescriptor{
+		{RefType: "image", TargetKind: "Channel", Min: 1},
+	}
+	version.Conditions = []registry.ConditionMappingRule{{Type: "ImageAvailable"}}
+	version.NameMaxLen = 63
+	version.ImmutableFields = []string{"raw_version"}
+	version.StatusFields = []registry.StatusFieldRule{{Name: "image_digest", Expression: `"sha256:0"`}}
+	registry.Register(version)
+}
+
+func serveKinds(handler http.HandlerFunc, path, kind string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if kind != "" {
+		req.SetPathValue("kind", kind)
+	}
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+	return rr
+}
+
+func TestKindHandler_List(t *testing.T) {
+	RegisterTestingT(t)
+	registerKindDescriptors(t)
+
+	handler := NewKindHandler(nil)
+	rr := serveKinds(handler.List, "/api/hyperfleet/v1/kinds", "")
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+
+	var body struct {
+		Kind  string            `json:"kind"`
+		Items []kindDescription `json:"items"`
+		Total int               `json:"total"`
+	}
+	Expect(json.Unmarshal(rr.Body.Bytes(), &body)).To(Succeed())
+	Expect(body.Kind).To(Equal("KindList"))
+	Expect(body.Total).To(Equal(2))
+	Expect(body.Items[0].Kind).To(Equal("Channel"))
+	Expect(body.Items[0].CollectionHref).To(Equal("/api/hyperfleet/v1/channels"))
+	Expect(body.Items[0].References).To(BeEmpty())
+	Expect(body.Items[0].NameMinLen).To(Equal(1), "names are never empty")
+	Expect(body.Items[0].NameMaxLen).To(Equal(dbNameMaxLen), "an unset maximum is the column limit")
+	Expect(body.Items[1].Kind).To(Equal("Version"))
+}
+
+func TestKindHandler_Get(t *testing.T) {
+	RegisterTestingT(t)
+	registerKindDescriptors(t)
+
+	handler := NewKindHandler(nil)
+	for _, name := range []string{"Version", "versions"} {
+		rr := serveKinds(handler.Get, "/api/hyperfleet/v1/kinds/"+name, name)
+		Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+
+		var body map[string]interface{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), &body)).To(Succeed())
+		Expect(body["kind"]).To(Equal("Version"))
+		Expect(body["href"]).To(Equal("/api/hyperfleet/v1/kinds/Version"))
+		Expect(body["parent_kind"]).To(Equal("Channel"))
+		Expect(body["on_parent_delete"]).To(Equal("restrict"))
+		Expect(body["required_adapters"]).To(ConsistOf("validation"))
+		Expect(body["condition_types"]).To(ConsistOf("ImageAvailable"))
+		Expect(body["name_min_len"]).To(BeNumerically("==", 1))
+		Expect(body["name_max_len"]).To(BeNumerically("==", 63))
+		Expect(body["immutable_fields"]).To(ConsistOf("raw_version"))
+		Expect(body["status_fields"]).To(ConsistOf("image_digest"))
+		Expect(body["references"]).To(ConsistOf(map[string]interface{}{
+			"ref_type": "image", "target_kind": "Channel", "on_target_delete": "restrict",
+			"min": float64(1), "max": float64(0),
+		}))
+		Expect(body).NotTo(HaveKey("spec_schema"), "no schema is loaded without a validator")
+	}
+}
+
+func TestKindHandler_Get_NotFound(t *testing.T) {
+	RegisterTestingT(t)
+	registerKindDescriptors(t)
+
+	rr := serveKinds(NewKindHandler(nil).Get, "/api/hyperfleet/v1/kinds/Widget", "Widget")
+	Expect(rr.Code).To(Equal(http.StatusNotFound), rr.Body.String())
+}
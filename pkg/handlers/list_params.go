@@ -17,8 +17,10 @@ const defaultOrder = "created_time desc"
 type listParams struct {
 	RefType     string `validate:"required_with=RefTargetID"`
 	RefTargetID string `validate:"required_with=RefType"`
-	Size        int64  `validate:"min=1,max=100"`
-	Page        int64  `validate:"min=1,max=100000"`
+	Continue    string
+	Size        int64 `validate:"min=1,max=100"`
+	Page        int64 `validate:"min=1,max=100000"`
+	SkipCount   bool
 }
 
 var listParamsValidator = validator.New()
@@ -34,13 +36,16 @@ func parseListParams(query url.Values) (*services.ListArguments, *errors.Service
 	}
 
 	args := &services.ListArguments{
-		Page:        p.Page,
-		Size:        p.Size,
-		Search:      strings.TrimSpace(query.Get("search")),
-		RefType:     p.RefType,
-		RefTargetID: p.RefTargetID,
-		Fields:      ensureIDField(normalizeList(query["fields"])),
-		Order:       normalizeList(query["order"]),
+		Page:          p.Page,
+		Size:          p.Size,
+		Search:        strings.TrimSpace(query.Get("search")),
+		LabelSelector: strings.TrimSpace(query.Get("labelSelector")),
+		RefType:       p.RefType,
+		RefTargetID:   p.RefTargetID,
+		Continue:      p.Continue,
+		SkipCount:     p.SkipCount,
+		Fields:        ensureIDField(normalizeList(query["fields"])),
+		Order:         normalizeList(query["order"]),
 	}
 
 	if len(args.Order) == 0 {
@@ -57,6 +62,7 @@ func bindListParams(query url.Values) (*listParams, *errors.ServiceError) {
 		Size:        defaults.Size,
 		RefType:     strings.TrimSpace(query.Get("ref_type")),
 		RefTargetID: strings.TrimSpace(query.Get("ref_target_id")),
+		Continue:    strings.TrimSpace(query.Get("continue")),
 	}
 
 	var formatErrors []errors.ValidationDetail
@@ -89,6 +95,30 @@ func bindListParams(query url.Values) (*listParams, *errors.ServiceError) {
 		}
 	}
 
+	if v := strings.TrimSpace(query.Get("count")); v != "" {
+		count, err := strconv.ParseBool(v)
+		if err != nil {
+			formatErrors = append(formatErrors, errors.ValidationDetail{
+				Field:      "count",
+				Value:      v,
+				Constraint: "format",
+				Message:    "must be true or false",
+			})
+		} else {
+			p.SkipCount = !count
+		}
+	}
+
+	// a continue token already encodes the position; an explicit page would contradict it
+	if p.Continue != "" && strings.TrimSpace(query.Get("page")) != "" {
+		formatErrors = append(formatErrors, errors.ValidationDetail{
+			Field:      "continue",
+			Value:      p.Continue,
+			Constraint: "conflict",
+			Message:    "continue and page cannot be used together",
+		})
+	}
+
 	if len(formatErrors) > 0 {
 		return nil, errors.ValidationWithDetails("Invalid query parameters", formatErrors)
 	}
@@ -48,6 +48,16 @@ func TestParseListParams(t *testing.T) {
 				Order:  []string{"created_time desc"},
 			},
 		},
+		{
+			name:  "labelSelector",
+			query: "?labelSelector=env%3Dprod,%20tier%20in%20(web)",
+			expected: &services.ListArguments{
+				Page:          1,
+				Size:          20,
+				LabelSelector: "env=prod, tier in (web)",
+				Order:         []string{"created_time desc"},
+			},
+		},
 		// Order
 		{
 			name:  "custom order",
@@ -114,6 +124,41 @@ func TestParseListParams(t *testing.T) {
 				{message: "ref_type and ref_target_id must be provided together"},
 			},
 		},
+		// Continue and count
+		{
+			name:  "continue token",
+			query: "?continue=eyJvIjpbXX0&count=false",
+			expected: &services.ListArguments{
+				Page:      1,
+				Size:      20,
+				Order:     []string{"created_time desc"},
+				Continue:  "eyJvIjpbXX0",
+				SkipCount: true,
+			},
+		},
+		{
+			name:  "count true keeps the total",
+			query: "?count=true",
+			expected: &services.ListArguments{
+				Page:  1,
+				Size:  20,
+				Order: []string{"created_time desc"},
+			},
+		},
+		{
+			name:  "continue with page",
+			query: "?continue=eyJvIjpbXX0&page=2",
+			errors: []expectedDetail{
+				{field: "continue", message: "continue and page cannot be used together"},
+			},
+		},
+		{
+			name:  "non-boolean count",
+			query: "?count=maybe",
+			errors: []expectedDetail{
+				{field: "count", message: "must be true or false"},
+			},
+		},
 		// Format errors
 		{
 			name:  "non-numeric page",
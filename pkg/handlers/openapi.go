@@ -5,8 +5,10 @@ import (
 	"embed"
 	"io/fs"
 	"net/http"
+	"sync/atomic"
+
+	"github.com/getkin/kin-openapi/openapi3"
 
-	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/openapi"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
 )
@@ -15,30 +17,18 @@ import (
 var openapiui embed.FS
 
 type OpenAPIHandler struct {
-	openAPIDefinitions []byte
+	openAPIDefinitions atomic.Pointer[[]byte]
 	uiContent          []byte
 }
 
-func NewOpenAPIHandler() (*OpenAPIHandler, error) {
+// NewOpenAPIHandler serves doc, the API document built by apidoc.Build, with the Swagger UI.
+func NewOpenAPIHandler(doc *openapi3.T) (*OpenAPIHandler, error) {
 	ctx := context.Background()
-	// Load the OpenAPI spec from the generated code's embedded swagger
-	swagger, err := openapi.GetSpec()
-	if err != nil {
-		return nil, errors.GeneralError(
-			"can't load OpenAPI specification from generated code: %v",
-			err,
-		)
+	h := &OpenAPIHandler{}
+	if err := h.SetDocument(doc); err != nil {
+		return nil, err
 	}
-
-	// Marshal the swagger spec to JSON
-	data, err := swagger.MarshalJSON()
-	if err != nil {
-		return nil, errors.GeneralError(
-			"can't marshal OpenAPI specification to JSON: %v",
-			err,
-		)
-	}
-	logger.Info(ctx, "Loaded fully resolved OpenAPI specification from embedded pkg/api/openapi/api/openapi.yaml")
+	logger.Info(ctx, "Loaded OpenAPI specification generated from the entity registry")
 
 	// Load the OpenAPI UI HTML content
 	uiContent, err := fs.ReadFile(openapiui, "openapi-ui.html")
@@ -50,16 +40,28 @@ func NewOpenAPIHandler() (*OpenAPIHandler, error) {
 	}
 	logger.Info(ctx, "Loaded OpenAPI UI HTML from embedded file")
 
-	return &OpenAPIHandler{
-		openAPIDefinitions: data,
-		uiContent:          uiContent,
-	}, nil
+	h.uiContent = uiContent
+	return h, nil
+}
+
+// SetDocument swaps in doc as the document served from now on, as when a configuration
+// reload changes the entity registry it was built from.
+func (h *OpenAPIHandler) SetDocument(doc *openapi3.T) error {
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return errors.GeneralError(
+			"can't marshal OpenAPI specification to JSON: %v",
+			err,
+		)
+	}
+	h.openAPIDefinitions.Store(&data)
+	return nil
 }
 
 func (h *OpenAPIHandler) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write(h.openAPIDefinitions); err != nil {
+	if _, err := w.Write(*h.openAPIDefinitions.Load()); err != nil {
 		// Response already committed, can't report error
 		logger.With(r.Context(),
 			logger.HTTPPath(r.URL.Path),
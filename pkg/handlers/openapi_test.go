@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	. "github.com/onsi/gomega"
+)
+
+func TestOpenAPIHandler_SetDocument(t *testing.T) {
+	RegisterTestingT(t)
+
+	doc := func(version string) *openapi3.T {
+		return &openapi3.T{
+			OpenAPI: "3.0.0",
+			Info:    &openapi3.Info{Title: "test", Version: version},
+			Paths:   openapi3.NewPaths(),
+		}
+	}
+	handler, err := NewOpenAPIHandler(doc("1"))
+	Expect(err).NotTo(HaveOccurred())
+
+	get := func() string {
+		rr := httptest.NewRecorder()
+		handler.GetOpenAPI(rr, httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/openapi", nil))
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		return rr.Body.String()
+	}
+	Expect(get()).To(ContainSubstring(`"version":"1"`))
+
+	Expect(handler.SetDocument(doc("2"))).To(Succeed())
+	Expect(get()).To(ContainSubstring(`"version":"2"`))
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/util"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
+)
+
+// PATCH bodies are interpreted by Content-Type. A plain JSON body replaces every field
+// it carries; merge patch (RFC 7386) and JSON patch (RFC 6902) are applied to the
+// stored resource, so a client can change one spec field without resending the rest.
+const (
+	mediaTypeJSON       = "application/json"
+	mediaTypeMergePatch = "application/merge-patch+json"
+	mediaTypeJSONPatch  = "application/json-patch+json"
+)
+
+// patchMediaType returns the media type of a PATCH body. Anything other than the two
+// patch formats keeps the original replace semantics.
+func patchMediaType(r *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return mediaTypeJSON
+	}
+	switch mediaType {
+	case mediaTypeMergePatch, mediaTypeJSONPatch:
+		return mediaType
+	default:
+		return mediaTypeJSON
+	}
+}
+
+// patchDocument applies a merge or JSON patch body to current, validates the resulting
+// spec, and saves it. The write is conditioned on current's version: the patch was
+// computed against it, so a concurrent update must fail with CNF-002 rather than be lost.
+func patchDocument(
+	r *http.Request, mediaType string,
+	service services.ResourceService, validator *validators.SchemaValidator,
+	plural string, current *api.Resource,
+) (*api.Resource, *errors.ServiceError) {
+	var patch *api.ResourcePatch
+	var svcErr *errors.ServiceError
+	if mediaType == mediaTypeMergePatch {
+		patch, svcErr = decodeMergePatch(r, current)
+	} else {
+		patch, svcErr = decodeJSONPatch(r, current)
+	}
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if patch.Spec != nil {
+		if svcErr := validateSpecSchema(validator, plural, patch.Spec); svcErr != nil {
+			return nil, svcErr
+		}
+	}
+
+	ctx, svcErr := pinPatchBase(r, current)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	return service.Patch(ctx, current.Kind, current.ID, patch)
+}
+
+// pinPatchBase honours a client If-Match against the base version, then requires the
+// service to find that same version when it locks the row.
+func pinPatchBase(r *http.Request, base *api.Resource) (context.Context, *errors.ServiceError) {
+	etags := services.ParseETags(r.Header.Get("If-Match"))
+	if len(etags) > 0 && !services.ETagMatches(etags, base) {
+		return nil, errors.ConflictVersion(
+			"%s '%s' has been modified: current version is %s", base.Kind, base.ID, base.ETag(),
+		)
+	}
+	return services.WithIfMatch(r.Context(), []string{base.ETag()}), nil
+}
+
+// mergePatchRequest keeps each member raw so an explicit null can be told apart from
+// an absent member.
+type mergePatchRequest struct {
+	Spec       json.RawMessage   `json:"spec"`
+	Labels     json.RawMessage   `json:"labels"`
+	References *api.ReferenceMap `json:"references"`
+}
+
+func decodeMergePatch(r *http.Request, current *api.Resource) (*api.ResourcePatch, *errors.ServiceError) {
+	var req mergePatchRequest
+	if svcErr := decodeStrict(r, &req); svcErr != nil {
+		return nil, svcErr
+	}
+	if req.Spec == nil && req.Labels == nil && req.References == nil {
+		return nil, errors.BadRequest("at least one field must be provided for update")
+	}
+
+	patch := &api.ResourcePatch{}
+	if req.Spec != nil {
+		var specPatch interface{}
+		if err := json.Unmarshal(req.Spec, &specPatch); err != nil {
+			return nil, errors.MalformedRequest("Invalid request format: %s", err)
+		}
+		if specPatch == nil {
+			return nil, errors.Validation("spec cannot be removed")
+		}
+		spec, svcErr := specObject(util.MergePatch(currentSpec(current), specPatch))
+		if svcErr != nil {
+			return nil, svcErr
+		}
+		patch.Spec = spec
+	}
+	if req.Labels != nil {
+		var labelPatch map[string]*string
+		if err := json.Unmarshal(req.Labels, &labelPatch); err != nil {
+			return nil, errors.Validation("field 'labels' must be an object of strings")
+		}
+		labels := currentLabels(current)
+		if labelPatch == nil {
+			labels = map[string]string{}
+		}
+		for k, v := range labelPatch {
+			if v == nil {
+				delete(labels, k)
+				continue
+			}
+			labels[k] = *v
+		}
+		patch.Labels = labels
+	}
+	if req.References != nil {
+		patch.References = *req.References
+	}
+	return patch, nil
+}
+
+// decodeJSONPatch applies the operations to a {"spec": ..., "labels": ...} view of the
+// resource; other members are not patchable.
+func decodeJSONPatch(r *http.Request, current *api.Resource) (*api.ResourcePatch, *errors.ServiceError) {
+	var ops []util.JSONPatchOperation
+	if svcErr := decodeStrict(r, &ops); svcErr != nil {
+		return nil, svcErr
+	}
+	if len(ops) == 0 {
+		return nil, errors.BadRequest("at least one patch operation must be provided")
+	}
+
+	labels := map[string]interface{}{}
+	for k, v := range currentLabels(current) {
+		labels[k] = v
+	}
+	doc := map[string]interface{}{"spec": currentSpec(current), "labels": labels}
+
+	patched, err := util.ApplyJSONPatch(doc, ops)
+	if err != nil {
+		return nil, errors.Validation("Invalid JSON patch: %v", err)
+	}
+	result, ok := patched.(map[string]interface{})
+	if !ok {
+		return nil, errors.Validation("JSON patch must leave the resource an object")
+	}
+	for key := range result {
+		if key != "spec" && key != "labels" {
+			return nil, errors.Validation("JSON patch may only modify /spec and /labels, not /%s", key)
+		}
+	}
+
+	spec, svcErr := specObject(result["spec"])
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	patch := &api.ResourcePatch{Spec: spec, Labels: map[string]string{}}
+	if patchedLabels, present := result["labels"]; present {
+		labelObj, ok := patchedLabels.(map[string]interface{})
+		if !ok {
+			return nil, errors.Validation("field 'labels' must be an object of strings")
+		}
+		for k, v := range labelObj {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.Validation("label %q must be a string", k)
+			}
+			patch.Labels[k] = s
+		}
+	}
+	return patch, nil
+}
+
+// decodeStrict decodes a patch body, rejecting unknown members like the plain JSON PATCH.
+func decodeStrict(r *http.Request, v any) *errors.ServiceError {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if err == io.EOF {
+			return errors.MalformedRequest("Request body is required but was empty")
+		}
+		if typeErr := cleanTypeMismatchError(err); typeErr != nil {
+			return typeErr
+		}
+		return errors.MalformedRequest("Invalid request format: %s", err)
+	}
+	return nil
+}
+
+func specObject(v interface{}) (map[string]interface{}, *errors.ServiceError) {
+	spec, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.Validation("spec must be an object")
+	}
+	return spec, nil
+}
+
+func currentSpec(resource *api.Resource) interface{} {
+	spec := map[string]interface{}{}
+	if len(resource.Spec) > 0 {
+		_ = json.Unmarshal(resource.Spec, &spec)
+	}
+	return spec
+}
+
+func currentLabels(resource *api.Resource) map[string]string {
+	labels := make(map[string]string, len(resource.Labels))
+	for _, l := range resource.Labels {
+		labels[l.Key] = l.Value
+	}
+	return labels
+}
+
+// validateSpecSchema validates spec against the schema loaded for plural, if any. The
+// schema's defaults are filled in by the resource service when the spec is saved.
+func validateSpecSchema(
+	validator *validators.SchemaValidator, plural string, spec map[string]interface{},
+) *errors.ServiceError {
+	if validator == nil {
+		return nil
+	}
+	if validationErr := validator.Validate(plural, spec); validationErr != nil {
+		specErr, ok := validationErr.(*errors.ServiceError)
+		if !ok {
+			specErr = errors.Validation("Spec validation failed: %v", validationErr)
+		}
+		return specErr
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+func patchBaseResource() *api.Resource {
+	return &api.Resource{
+		Meta:       api.Meta{ID: "ch-123", CreatedTime: time.Now(), UpdatedTime: time.Now()},
+		Kind:       "Channel",
+		Name:       "stable",
+		Spec:       datatypes.JSON(`{"release":{"version":"1","notes":"n"},"region":"us"}`),
+		Labels:     []api.ResourceLabel{{Key: "env", Value: "prod"}, {Key: "team", Value: "a"}},
+		Generation: 4,
+	}
+}
+
+func TestResourceHandler_Patch_DocumentPatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expectedSpec   map[string]interface{}
+		expectedLabels map[string]string
+	}{
+		{
+			name:        "merge patch updates one nested field",
+			contentType: "application/merge-patch+json",
+			body:        `{"spec":{"release":{"version":"2","notes":null}},"labels":{"team":null,"tier":"gold"}}`,
+			expectedSpec: map[string]interface{}{
+				"release": map[string]interface{}{"version": "2"},
+				"region":  "us",
+			},
+			expectedLabels: map[string]string{"env": "prod", "tier": "gold"},
+		},
+		{
+			name:        "merge patch with charset parameter leaves labels alone",
+			contentType: "application/merge-patch+json; charset=utf-8",
+			body:        `{"spec":{"region":"eu"}}`,
+			expectedSpec: map[string]interface{}{
+				"release": map[string]interface{}{"version": "1", "notes": "n"},
+				"region":  "eu",
+			},
+		},
+		{
+			name:        "json patch",
+			contentType: "application/json-patch+json",
+			body: `[{"op":"test","path":"/spec/release/version","value":"1"},` +
+				`{"op":"replace","path":"/spec/release/version","value":"2"},` +
+				`{"op":"remove","path":"/labels/team"}]`,
+			expectedSpec: map[string]interface{}{
+				"release": map[string]interface{}{"version": "2", "notes": "n"},
+				"region":  "us",
+			},
+			expectedLabels: map[string]string{"env": "prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			current := patchBaseResource()
+			handler, mockResourceSvc := newTestResourceHandler(ctrl)
+			mockResourceSvc.EXPECT().Get(gomock.Any(), "Channel", "ch-123").Return(current, nil)
+			mockResourceSvc.EXPECT().Patch(gomock.Any(), "Channel", "ch-123", gomock.Any()).
+				DoAndReturn(func(
+					_ context.Context, _, _ string, patch *api.ResourcePatch,
+				) (*api.Resource, *errors.ServiceError) {
+					Expect(patch.Spec).To(Equal(tt.expectedSpec))
+					if tt.expectedLabels == nil {
+						Expect(patch.Labels).To(BeNil())
+					} else {
+						Expect(patch.Labels).To(Equal(tt.expectedLabels))
+					}
+					return current, nil
+				})
+
+			req := httptest.NewRequest(http.MethodPatch,
+				"/api/hyperfleet/v1/channels/ch-123", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.SetPathValue("id", "ch-123")
+			rr := httptest.NewRecorder()
+
+			handler.Patch(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+		})
+	}
+}
+
+func TestResourceHandler_Patch_DocumentPatchRejected(t *testing.T) {
+	tests := []struct {
+		name               string
+		contentType        string
+		ifMatch            string
+		body               string
+		expectedStatusCode int
+	}{
+		{
+			name:               "merge patch removing spec",
+			contentType:        "application/merge-patch+json",
+			body:               `{"spec":null}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "merge patch with unknown member",
+			contentType:        "application/merge-patch+json",
+			body:               `{"name":"other"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "json patch outside spec and labels",
+			contentType:        "application/json-patch+json",
+			body:               `[{"op":"add","path":"/name","value":"other"}]`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "json patch failing test op",
+			contentType:        "application/json-patch+json",
+			body:               `[{"op":"test","path":"/spec/region","value":"eu"}]`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "json patch replace without a value",
+			contentType:        "application/json-patch+json",
+			body:               `[{"op":"replace","path":"/spec/region"}]`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "json patch replacing spec with a scalar",
+			contentType:        "application/json-patch+json",
+			body:               `[{"op":"replace","path":"/spec","value":1}]`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "stale If-Match",
+			contentType:        "application/merge-patch+json",
+			ifMatch:            `"1-0"`,
+			body:               `{"spec":{"region":"eu"}}`,
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockResourceSvc := newTestResourceHandler(ctrl)
+			mockResourceSvc.EXPECT().Get(gomock.Any(), "Channel", "ch-123").Return(patchBaseResource(), nil)
+
+			req := httptest.NewRequest(http.MethodPatch,
+				"/api/hyperfleet/v1/channels/ch-123", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			req.SetPathValue("id", "ch-123")
+			rr := httptest.NewRecorder()
+
+			handler.Patch(rr, req)
+			Expect(rr.Code).To(Equal(tt.expectedStatusCode), rr.Body.String())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
@@ -9,6 +10,7 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
 )
 
 // ResourceHandler serves both flat and owner-nested routes for a single entity
@@ -18,27 +20,45 @@ import (
 // descriptor is registered exclusively under a {parent_id} subrouter, and a flat
 // descriptor never is. If that registration is ever bypassed — e.g. a nested kind
 // wired to a flat route — these branches take the wrong path silently (Create
-// would skip setting owner references instead of erroring).
+// would skip setting owner references instead of erroring). Routes nested more than
+// one level deep also carry the IDs of the parent's ancestors (see AncestorPathValue),
+// and every ancestor in the path is verified before the parent.
 type ResourceHandler struct {
 	service    services.ResourceService
+	watch      services.WatchService
+	validator  *validators.SchemaValidator
 	descriptor registry.EntityDescriptor
 }
 
 func NewResourceHandler(
 	descriptor registry.EntityDescriptor,
 	service services.ResourceService,
+	watch services.WatchService,
+	validator *validators.SchemaValidator,
 ) *ResourceHandler {
 	return &ResourceHandler{
 		descriptor: descriptor,
 		service:    service,
+		watch:      watch,
+		validator:  validator,
 	}
 }
 
+// current returns the handler's descriptor as last loaded into the registry, so that
+// fields a configuration reload may change, such as name bounds, are up to date.
+func (h *ResourceHandler) current() registry.EntityDescriptor {
+	if descriptor, ok := registry.Get(h.descriptor.Kind); ok {
+		return descriptor
+	}
+	return h.descriptor
+}
+
 func (h *ResourceHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req openapi.ResourceCreateRequest
+	descriptor := h.current()
 	validateFuncs := []validate{
 		validateKind(&req, "Kind", "kind", h.descriptor.Kind),
-		validateName(&req, "Name", "name", h.descriptor.NameMinLen, h.descriptor.NameMaxLen),
+		validateName(&req, "Name", "name", descriptor.NameMinLen, descriptor.NameMaxLen),
 		validateSpec(&req, "Spec", "spec"),
 		validateLabels(&req, "Labels"),
 	}
@@ -58,7 +78,7 @@ func (h *ResourceHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var resource *api.Resource
 	var convErr error
 	if parentID != "" {
-		parent, err := h.service.Get(ctx, h.descriptor.ParentKind, parentID)
+		parent, err := resolveParent(r, h.service, h.descriptor.ParentKind)
 		if err != nil {
 			handleError(r, w, err)
 			return
@@ -79,6 +99,7 @@ func (h *ResourceHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, resource)
 	writeJSONResponse(w, r, http.StatusCreated, presenters.PresentResource(resource))
 }
 
@@ -91,6 +112,10 @@ func (h *ResourceHandler) Get(w http.ResponseWriter, r *http.Request) {
 		handleError(r, w, err)
 		return
 	}
+	if err = validateSpecFields(h.validator, h.descriptor.Kind, requestedFields(r)); err != nil {
+		handleError(r, w, err)
+		return
+	}
 
 	var resource *api.Resource
 	if parentID != "" {
@@ -102,13 +127,17 @@ func (h *ResourceHandler) Get(w http.ResponseWriter, r *http.Request) {
 		handleError(r, w, err)
 		return
 	}
-
 	result, err := applyFieldFilter(r, presenters.PresentResource(resource))
 	if err != nil {
 		handleError(r, w, err)
 		return
 	}
+	etag := representationETag(r, resource)
+	if notModified(w, r, etag) {
+		return
+	}
 
+	w.Header().Set("ETag", etag)
 	writeJSONResponse(w, r, http.StatusOK, result)
 }
 
@@ -121,11 +150,25 @@ func (h *ResourceHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	watch, err := watchRequested(r)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+	if watch {
+		serveWatch(w, r, h.watch, services.WatchFilter{Kind: h.descriptor.Kind, OwnerID: parentID})
+		return
+	}
+
 	listArgs, err := parseListParams(r.URL.Query())
 	if err != nil {
 		handleError(r, w, err)
 		return
 	}
+	if err = validateSpecFields(h.validator, h.descriptor.Kind, listArgs.Fields); err != nil {
+		handleError(r, w, err)
+		return
+	}
 
 	var resources api.ResourceList
 	var paging *api.PagingMeta
@@ -135,11 +178,91 @@ func (h *ResourceHandler) List(w http.ResponseWriter, r *http.Request) {
 		resources, paging, err = h.service.List(ctx, h.descriptor.Kind, listArgs)
 	}
 
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+	writeResourceList(w, r, resources, paging, listArgs)
+}
+
+// ListDescendants returns a handler listing this kind's resources owned, through any
+// number of intermediate owners, by the {ancestor_id} resource of ancestorKind, e.g.
+// every Machine of a Cluster across its NodePools. Watching is not supported there.
+func (h *ResourceHandler) ListDescendants(ancestorKind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		ancestorID := r.PathValue("ancestor_id")
+		if _, err := h.service.Get(ctx, ancestorKind, ancestorID); err != nil {
+			handleError(r, w, err)
+			return
+		}
+
+		watch, err := watchRequested(r)
+		if err != nil {
+			handleError(r, w, err)
+			return
+		}
+		if watch {
+			handleError(r, w, errors.Validation(
+				"watch is not supported when listing %s under a %s; watch %s under their parent instead",
+				h.descriptor.Plural, ancestorKind, h.descriptor.Plural,
+			))
+			return
+		}
+
+		listArgs, err := parseListParams(r.URL.Query())
+		if err != nil {
+			handleError(r, w, err)
+			return
+		}
+		if err = validateSpecFields(h.validator, h.descriptor.Kind, listArgs.Fields); err != nil {
+			handleError(r, w, err)
+			return
+		}
+
+		resources, paging, err := h.service.ListByAncestor(ctx, h.descriptor.Kind, ancestorKind, ancestorID, listArgs)
+		if err != nil {
+			handleError(r, w, err)
+			return
+		}
+		writeResourceList(w, r, resources, paging, listArgs)
+	}
+}
+
+// Aggregate counts this kind's resources matching the list's search and labelSelector
+// per distinct value of the groupBy fields, limited to the parent's children on nested
+// routes.
+func (h *ResourceHandler) Aggregate(w http.ResponseWriter, r *http.Request) {
+	parentID, err := h.parentIDIfExists(r)
 	if err != nil {
 		handleError(r, w, err)
 		return
 	}
 
+	groupBy, err := parseGroupBy(r)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+	listArgs, err := parseListParams(r.URL.Query())
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	aggregate, err := h.service.Aggregate(r.Context(), h.descriptor.Kind, parentID, groupBy, listArgs)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResourceAggregate(aggregate))
+}
+
+// writeResourceList presents a page of resources, applying the fields parameter.
+func writeResourceList(
+	w http.ResponseWriter, r *http.Request,
+	resources api.ResourceList, paging *api.PagingMeta, listArgs *services.ListArguments,
+) {
 	presented := presenters.PresentResourceList(resources, paging)
 	if listArgs.Fields != nil {
 		filtered, err := presenters.SliceFilter(listArgs.Fields, presented)
@@ -154,6 +277,11 @@ func (h *ResourceHandler) List(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *ResourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	if mediaType := patchMediaType(r); mediaType != mediaTypeJSON {
+		h.patchDocument(w, r, mediaType)
+		return
+	}
+
 	var req openapi.ResourcePatchRequest
 	validateFuncs := []validate{
 		validatePatchRequest(&req),
@@ -165,7 +293,7 @@ func (h *ResourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id := r.PathValue("id")
-	ctx := r.Context()
+	ctx := withIfMatch(r)
 	if err := h.checkOwnership(r, id); err != nil {
 		handleError(r, w, err)
 		return
@@ -178,12 +306,46 @@ func (h *ResourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, resource)
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResource(resource))
+}
+
+// patchDocument serves merge patch and JSON patch bodies, which are applied to the
+// stored resource rather than replacing fields wholesale.
+func (h *ResourceHandler) patchDocument(w http.ResponseWriter, r *http.Request, mediaType string) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	parentID, err := h.parentIDIfExists(r)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	var current *api.Resource
+	if parentID != "" {
+		current, err = h.service.GetByOwner(ctx, h.descriptor.Kind, id, parentID)
+	} else {
+		current, err = h.service.Get(ctx, h.descriptor.Kind, id)
+	}
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	resource, err := patchDocument(r, mediaType, h.service, h.validator, h.descriptor.Plural, current)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	setETag(w, resource)
 	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResource(resource))
 }
 
 func (h *ResourceHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	ctx := r.Context()
+	ctx := withIfMatch(r)
 	if err := h.checkOwnership(r, id); err != nil {
 		handleError(r, w, err)
 		return
@@ -195,6 +357,7 @@ func (h *ResourceHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, resource)
 	writeJSONResponse(w, r, http.StatusAccepted, presenters.PresentResource(resource))
 }
 
@@ -224,6 +387,121 @@ func (h *ResourceHandler) ForceDelete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// History returns the audit events of a resource, newest first by default. On nested
+// routes only events recorded under parent_id are returned. The resource itself need not
+// exist anymore, so history stays readable after a hard delete.
+func (h *ResourceHandler) History(w http.ResponseWriter, r *http.Request) {
+	listArgs, err := parseListParams(r.URL.Query())
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	events, paging, err := h.service.History(
+		r.Context(), h.descriptor.Kind, r.PathValue("id"), r.PathValue("parent_id"), listArgs,
+	)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResourceEventList(events, paging))
+}
+
+// Revisions returns the stored revisions of a resource, newest generation first by default.
+func (h *ResourceHandler) Revisions(w http.ResponseWriter, r *http.Request) {
+	listArgs, err := parseListParams(r.URL.Query())
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	id := r.PathValue("id")
+	if err := h.checkOwnership(r, id); err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	revisions, paging, err := h.service.ListRevisions(r.Context(), h.descriptor.Kind, id, listArgs)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResourceRevisionList(revisions, paging))
+}
+
+func (h *ResourceHandler) Revision(w http.ResponseWriter, r *http.Request) {
+	generation, err := pathGeneration(r)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	id := r.PathValue("id")
+	if err := h.checkOwnership(r, id); err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	revision, err := h.service.GetRevision(r.Context(), h.descriptor.Kind, id, generation)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResourceRevision(revision))
+}
+
+// Referencers lists the resources that reference this one, grouped by ref type.
+func (h *ResourceHandler) Referencers(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.checkOwnership(r, id); err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	referencers, err := h.service.ListReferencers(r.Context(), h.descriptor.Kind, id)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentReferencerList(referencers))
+}
+
+// Rollback re-applies a stored revision as a new generation.
+func (h *ResourceHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	parentID, err := h.parentIDIfExists(r)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	var current *api.Resource
+	if parentID != "" {
+		current, err = h.service.GetByOwner(ctx, h.descriptor.Kind, id, parentID)
+	} else {
+		current, err = h.service.Get(ctx, h.descriptor.Kind, id)
+	}
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	resource, err := rollbackResource(r, h.service, h.validator, h.descriptor.Plural, current)
+	if err != nil {
+		handleError(r, w, err)
+		return
+	}
+
+	setETag(w, resource)
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResource(resource))
+}
+
 // checkOwnership verifies id belongs to parent_id, checking the parent first so
 // a missing parent reports "not found" against the parent, not the child.
 func (h *ResourceHandler) checkOwnership(r *http.Request, id string) *errors.ServiceError {
@@ -240,15 +518,59 @@ func (h *ResourceHandler) checkOwnership(r *http.Request, id string) *errors.Ser
 }
 
 // parentIDIfExists returns the parent_id if the parent exists, "" for flat
-// routes, or a 404 if parent_id is present but the parent is missing.
+// routes, or a 404 if parent_id is present but the parent, or any ancestor the
+// route names above it, is missing.
 func (h *ResourceHandler) parentIDIfExists(r *http.Request) (string, *errors.ServiceError) {
-	parentID := r.PathValue("parent_id")
-	if parentID == "" {
+	if r.PathValue("parent_id") == "" {
 		return "", nil
 	}
-	_, err := h.service.Get(r.Context(), h.descriptor.ParentKind, parentID)
+	parent, err := resolveParent(r, h.service, h.descriptor.ParentKind)
 	if err != nil {
 		return "", err
 	}
-	return parentID, nil
+	return parent.ID, nil
+}
+
+// AncestorPathValue names the path wildcard holding the ID of the ancestor level
+// generations above a resource on nested routes: {parent_id} for its parent (level 1)
+// and {ancestor_<level>_id} above that, e.g. {ancestor_2_id} for its grandparent.
+func AncestorPathValue(level int) string {
+	if level == 1 {
+		return "parent_id"
+	}
+	return fmt.Sprintf("ancestor_%d_id", level)
+}
+
+// resolveParent loads the parent named by a nested route. When the route also names
+// the parent's ancestors, each is verified to own the next one down, starting from the
+// outermost so that a missing ancestor is reported against itself, not its descendants.
+func resolveParent(
+	r *http.Request, service services.ResourceService, parentKind string,
+) (*api.Resource, *errors.ServiceError) {
+	ctx := r.Context()
+	// Kinds and IDs of the ancestors in the path, nearest first.
+	kinds := []string{parentKind}
+	ids := []string{r.PathValue("parent_id")}
+	for _, ancestor := range registry.AncestorsOf(parentKind) {
+		id := r.PathValue(AncestorPathValue(len(ids) + 1))
+		if id == "" {
+			break
+		}
+		kinds = append(kinds, ancestor.Kind)
+		ids = append(ids, id)
+	}
+
+	var owner *api.Resource
+	for i := len(ids) - 1; i >= 0; i-- {
+		var err *errors.ServiceError
+		if owner == nil {
+			owner, err = service.Get(ctx, kinds[i], ids[i])
+		} else {
+			owner, err = service.GetByOwner(ctx, kinds[i], ids[i], owner.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return owner, nil
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -18,6 +20,7 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
 )
 
 var channelDescriptor = registry.EntityDescriptor{
@@ -37,7 +40,7 @@ func newTestResourceHandler(
 	ctrl *gomock.Controller,
 ) (*ResourceHandler, *services.MockResourceService) {
 	mockResourceSvc := services.NewMockResourceService(ctrl)
-	handler := NewResourceHandler(channelDescriptor, mockResourceSvc)
+	handler := NewResourceHandler(channelDescriptor, mockResourceSvc, nil, nil)
 	return handler, mockResourceSvc
 }
 
@@ -45,7 +48,7 @@ func newTestVersionHandler(
 	ctrl *gomock.Controller,
 ) (*ResourceHandler, *services.MockResourceService) {
 	mockResourceSvc := services.NewMockResourceService(ctrl)
-	handler := NewResourceHandler(versionDescriptor, mockResourceSvc)
+	handler := NewResourceHandler(versionDescriptor, mockResourceSvc, nil, nil)
 	return handler, mockResourceSvc
 }
 
@@ -187,6 +190,151 @@ func TestResourceHandler_Get(t *testing.T) {
 	}
 }
 
+func TestResourceHandler_Get_ETag(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resource := &api.Resource{
+		Meta:       api.Meta{ID: "ch-123", CreatedTime: time.Now(), UpdatedTime: time.Now()},
+		Kind:       "Channel",
+		Name:       "stable",
+		Spec:       datatypes.JSON(`{}`),
+		Generation: 3,
+	}
+	handler, mockResourceSvc := newTestResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().Get(gomock.Any(), "Channel", "ch-123").Return(resource, nil).Times(2)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-123", nil)
+	req.SetPathValue("id", "ch-123")
+	rr := httptest.NewRecorder()
+	handler.Get(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK))
+	Expect(rr.Header().Get("ETag")).To(Equal(resource.ETag()))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-123", nil)
+	req.SetPathValue("id", "ch-123")
+	req.Header.Set("If-None-Match", resource.ETag())
+	rr = httptest.NewRecorder()
+	handler.Get(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusNotModified))
+	Expect(rr.Body.Len()).To(BeZero())
+}
+
+func TestResourceHandler_Get_ProjectionETag(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resource := &api.Resource{
+		Meta:       api.Meta{ID: "ch-123", CreatedTime: time.Now(), UpdatedTime: time.Now()},
+		Kind:       "Channel",
+		Name:       "stable",
+		Spec:       datatypes.JSON(`{}`),
+		Generation: 3,
+	}
+	handler, mockResourceSvc := newTestResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().Get(gomock.Any(), "Channel", "ch-123").Return(resource, nil).AnyTimes()
+
+	get := func(query, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-123"+query, nil)
+		req.SetPathValue("id", "ch-123")
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rr := httptest.NewRecorder()
+		handler.Get(rr, req)
+		return rr
+	}
+
+	projected := get("?fields=name", "")
+	Expect(projected.Code).To(Equal(http.StatusOK))
+	projectedETag := projected.Header().Get("ETag")
+	Expect(projectedETag).NotTo(Equal(resource.ETag()))
+	Expect(projectedETag).To(HavePrefix(strings.TrimSuffix(resource.ETag(), `"`)))
+	Expect(get("?fields=id,name", "").Header().Get("ETag")).To(Equal(projectedETag),
+		"the same projection has the same tag")
+	Expect(get("?fields=spec", "").Header().Get("ETag")).NotTo(Equal(projectedETag))
+
+	Expect(get("?fields=name", projectedETag).Code).To(Equal(http.StatusNotModified))
+	Expect(get("?fields=name", resource.ETag()).Code).To(Equal(http.StatusOK),
+		"the full resource's tag does not validate a projection")
+	Expect(get("", projectedETag).Code).To(Equal(http.StatusOK),
+		"a projection's tag does not validate the full resource")
+}
+
+func TestResourceHandler_UnknownSpecField_Returns400(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registry.Reset()
+	t.Cleanup(registry.Reset)
+	registry.Register(channelDescriptor)
+	schemaPath := filepath.Join(t.TempDir(), "schema.yaml")
+	Expect(os.WriteFile(schemaPath, []byte(`
+openapi: 3.0.0
+info:
+  title: Channels
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ChannelSpec:
+      type: object
+      properties:
+        is_default:
+          type: boolean
+`), 0600)).To(Succeed())
+	validator, err := validators.NewSchemaValidator(schemaPath)
+	Expect(err).NotTo(HaveOccurred())
+
+	mockResourceSvc := services.NewMockResourceService(ctrl)
+	handler := NewResourceHandler(channelDescriptor, mockResourceSvc, nil, validator)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-123?fields=spec.is_defualt", nil)
+	req.SetPathValue("id", "ch-123")
+	rr := httptest.NewRecorder()
+	handler.Get(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	Expect(rr.Body.String()).To(ContainSubstring("spec.is_defualt"))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels?fields=name,spec.is_defualt", nil)
+	rr = httptest.NewRecorder()
+	handler.List(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusBadRequest))
+
+	mockResourceSvc.EXPECT().Get(gomock.Any(), "Channel", "ch-123").Return(&api.Resource{
+		Meta: api.Meta{ID: "ch-123"}, Kind: "Channel", Spec: datatypes.JSON(`{"is_default":true}`),
+	}, nil)
+	req = httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-123?fields=spec.is_default", nil)
+	req.SetPathValue("id", "ch-123")
+	rr = httptest.NewRecorder()
+	handler.Get(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+}
+
+func TestResourceHandler_Patch_IfMatchMismatch_Returns409(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockResourceSvc := newTestResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().Patch(gomock.Any(), "Channel", "ch-123", gomock.AssignableToTypeOf(&api.ResourcePatch{})).
+		Return(nil, errors.ConflictVersion("Channel 'ch-123' has been modified"))
+
+	req := httptest.NewRequest(http.MethodPatch,
+		"/api/hyperfleet/v1/channels/ch-123", strings.NewReader(`{"spec":{"is_default":false}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1-0"`)
+	req.SetPathValue("id", "ch-123")
+	rr := httptest.NewRecorder()
+
+	handler.Patch(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusConflict))
+	Expect(rr.Body.String()).To(ContainSubstring(errors.CodeConflictVersion))
+}
+
 func TestResourceHandler_List(t *testing.T) {
 	now := time.Now()
 
@@ -757,6 +905,101 @@ func TestResourceHandler_DeleteByOwner(t *testing.T) {
 	}
 }
 
+func TestResourceHandler_History(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		setupMock          func(mock *services.MockResourceService)
+		name               string
+		target             string
+		expectedStatusCode int
+		expectedItems      int
+	}{
+		{
+			name:   "Success",
+			target: "/api/hyperfleet/v1/channels/ch-1/history?search=action%3D'PATCH'",
+			setupMock: func(mock *services.MockResourceService) {
+				mock.EXPECT().History(gomock.Any(), "Channel", "ch-1", "",
+					gomock.AssignableToTypeOf(&services.ListArguments{})).
+					DoAndReturn(func(_, _, _, _ any, args *services.ListArguments) (
+						api.ResourceEventList, *api.PagingMeta, *errors.ServiceError,
+					) {
+						Expect(args.Search).To(Equal("action='PATCH'"))
+						return api.ResourceEventList{{
+							ID: "ev-1", ResourceID: "ch-1", Kind: "Channel", Action: api.AuditPatch,
+							Actor: "u@t.com", Changes: datatypes.JSON(`[{"path":"spec.key","old":1,"new":2}]`),
+							Generation: 2, CreatedTime: now,
+						}}, &api.PagingMeta{Page: 1, Size: 1, Total: 1}, nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedItems:      1,
+		},
+		{
+			name:   "Not found",
+			target: "/api/hyperfleet/v1/channels/ch-1/history",
+			setupMock: func(mock *services.MockResourceService) {
+				mock.EXPECT().History(gomock.Any(), "Channel", "ch-1", "", gomock.Any()).
+					Return(nil, nil, errors.NotFound("Channel not found"))
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockSvc := newTestResourceHandler(ctrl)
+			tt.setupMock(mockSvc)
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.SetPathValue("id", "ch-1")
+			rr := httptest.NewRecorder()
+
+			handler.History(rr, req)
+			Expect(rr.Code).To(Equal(tt.expectedStatusCode))
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var body struct {
+				Items []struct {
+					Action  string          `json:"action"`
+					Changes json.RawMessage `json:"changes"`
+				} `json:"items"`
+			}
+			Expect(json.Unmarshal(rr.Body.Bytes(), &body)).To(Succeed())
+			Expect(body.Items).To(HaveLen(tt.expectedItems))
+			for _, item := range body.Items {
+				Expect(item.Action).To(Equal(api.AuditPatch))
+				Expect(item.Changes).To(MatchJSON(`[{"path":"spec.key","old":1,"new":2}]`))
+			}
+		})
+	}
+}
+
+func TestResourceHandler_HistoryByOwner(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockSvc := newTestVersionHandler(ctrl)
+	// The parent is not looked up: history stays readable after the parent is gone.
+	mockSvc.EXPECT().History(gomock.Any(), "Version", "v-1", "ch-1", gomock.Any()).
+		Return(api.ResourceEventList{}, &api.PagingMeta{Page: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-1/versions/v-1/history", nil)
+	req.SetPathValue("parent_id", "ch-1")
+	req.SetPathValue("id", "v-1")
+	rr := httptest.NewRecorder()
+
+	handler.History(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK))
+	Expect(rr.Body.String()).To(MatchJSON(`{"items":[],"page":1,"size":0,"total":0}`))
+}
+
 func TestResourceHandler_ForceDelete(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -1261,7 +1504,7 @@ func TestResourceHandler_Create_ChildKindWithoutParent_Returns422(t *testing.T)
 	registry.Register(versionDescriptor)
 
 	mockSvc := services.NewMockResourceService(ctrl)
-	handler := NewResourceHandler(versionDescriptor, mockSvc)
+	handler := NewResourceHandler(versionDescriptor, mockSvc, nil, nil)
 
 	req := httptest.NewRequest(http.MethodPost,
 		"/api/hyperfleet/v1/versions",
@@ -1304,7 +1547,7 @@ func TestResourceHandler_Create_NameValidation(t *testing.T) {
 				NameMaxLen: tt.nameMaxLen,
 			}
 			mockResourceSvc := services.NewMockResourceService(ctrl)
-			handler := NewResourceHandler(descriptor, mockResourceSvc)
+			handler := NewResourceHandler(descriptor, mockResourceSvc, nil, nil)
 
 			if tt.wantStatus == http.StatusCreated {
 				now := time.Now()
@@ -1339,3 +1582,130 @@ func TestResourceHandler_Create_NameValidation(t *testing.T) {
 		})
 	}
 }
+
+// setupMachineHierarchy registers Cluster -> NodePool -> Machine and returns a
+// handler for Machine.
+func setupMachineHierarchy(
+	t *testing.T, ctrl *gomock.Controller,
+) (*ResourceHandler, *services.MockResourceService) {
+	registry.Reset()
+	t.Cleanup(registry.Reset)
+	registry.Register(registry.EntityDescriptor{Kind: "Cluster", Plural: "clusters"})
+	registry.Register(registry.EntityDescriptor{Kind: "NodePool", Plural: "nodepools", ParentKind: "Cluster"})
+	machine := registry.EntityDescriptor{Kind: "Machine", Plural: "machines", ParentKind: "NodePool"}
+	registry.Register(machine)
+
+	mockResourceSvc := services.NewMockResourceService(ctrl)
+	return NewResourceHandler(machine, mockResourceSvc, nil, nil), mockResourceSvc
+}
+
+func TestResourceHandler_Get_VerifiesAncestors(t *testing.T) {
+	now := time.Now()
+	newResource := func(kind, id string) *api.Resource {
+		return &api.Resource{
+			Meta: api.Meta{ID: id, CreatedTime: now, UpdatedTime: now},
+			Kind: kind, Name: id, Spec: datatypes.JSON(`{}`),
+		}
+	}
+
+	tests := []struct {
+		setupMock          func(mock *services.MockResourceService)
+		name               string
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock *services.MockResourceService) {
+				gomock.InOrder(
+					mock.EXPECT().Get(gomock.Any(), "Cluster", "c-1").Return(newResource("Cluster", "c-1"), nil),
+					mock.EXPECT().GetByOwner(gomock.Any(), "NodePool", "np-1", "c-1").
+						Return(newResource("NodePool", "np-1"), nil),
+					mock.EXPECT().GetByOwner(gomock.Any(), "Machine", "m-1", "np-1").
+						Return(newResource("Machine", "m-1"), nil),
+				)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Grandparent not found",
+			setupMock: func(mock *services.MockResourceService) {
+				mock.EXPECT().Get(gomock.Any(), "Cluster", "c-1").Return(nil, errors.NotFound("Cluster not found"))
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Parent owned by another cluster",
+			setupMock: func(mock *services.MockResourceService) {
+				mock.EXPECT().Get(gomock.Any(), "Cluster", "c-1").Return(newResource("Cluster", "c-1"), nil)
+				mock.EXPECT().GetByOwner(gomock.Any(), "NodePool", "np-1", "c-1").
+					Return(nil, errors.NotFound("NodePool not found"))
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			ctrl := gomock.NewController(t)
+			handler, mockResourceSvc := setupMachineHierarchy(t, ctrl)
+			tt.setupMock(mockResourceSvc)
+
+			req := httptest.NewRequest(
+				http.MethodGet, "/api/hyperfleet/v1/clusters/c-1/nodepools/np-1/machines/m-1", nil,
+			)
+			req.SetPathValue(AncestorPathValue(2), "c-1")
+			req.SetPathValue("parent_id", "np-1")
+			req.SetPathValue("id", "m-1")
+			rr := httptest.NewRecorder()
+
+			handler.Get(rr, req)
+			Expect(rr.Code).To(Equal(tt.expectedStatusCode), rr.Body.String())
+		})
+	}
+}
+
+func TestResourceHandler_ListDescendants(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	handler, mockResourceSvc := setupMachineHierarchy(t, ctrl)
+
+	now := time.Now()
+	mockResourceSvc.EXPECT().Get(gomock.Any(), "Cluster", "c-1").Return(&api.Resource{
+		Meta: api.Meta{ID: "c-1", CreatedTime: now, UpdatedTime: now}, Kind: "Cluster",
+	}, nil)
+	mockResourceSvc.EXPECT().ListByAncestor(gomock.Any(), "Machine", "Cluster", "c-1", gomock.Any()).Return(
+		api.ResourceList{{
+			Meta: api.Meta{ID: "m-1", CreatedTime: now, UpdatedTime: now},
+			Kind: "Machine", Name: "m-1", Spec: datatypes.JSON(`{}`),
+		}},
+		&api.PagingMeta{Page: 1, Size: 1, Total: 1}, nil,
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/clusters/c-1/machines", nil)
+	req.SetPathValue("ancestor_id", "c-1")
+	rr := httptest.NewRecorder()
+
+	handler.ListDescendants("Cluster")(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+
+	var list openapi.ResourceList
+	Expect(json.Unmarshal(rr.Body.Bytes(), &list)).To(Succeed())
+	Expect(list.Items).To(HaveLen(1))
+	Expect(list.Items[0].Id).To(Equal("m-1"))
+}
+
+func TestResourceHandler_ListDescendants_RejectsWatch(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	handler, mockResourceSvc := setupMachineHierarchy(t, ctrl)
+
+	mockResourceSvc.EXPECT().Get(gomock.Any(), "Cluster", "c-1").Return(&api.Resource{Kind: "Cluster"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/clusters/c-1/machines?watch=true", nil)
+	req.SetPathValue("ancestor_id", "c-1")
+	rr := httptest.NewRecorder()
+
+	handler.ListDescendants("Cluster")(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusBadRequest), rr.Body.String())
+}
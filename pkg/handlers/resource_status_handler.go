@@ -94,11 +94,17 @@ func (h *ResourceStatusHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 // verifyResource confirms the resource exists. For nested routes (parent_id
-// present), also verifies the resource belongs to the parent. No-op ownership
-// check for flat routes.
+// present), also verifies the resource belongs to the parent, and the parent to
+// any ancestors the route names. No-op ownership check for flat routes.
 func (h *ResourceStatusHandler) verifyResource(r *http.Request, id string) *errors.ServiceError {
 	ctx := r.Context()
 	if parentID := r.PathValue("parent_id"); parentID != "" {
+		// Deeper routes also name the parent's ancestors; verify the chain down to it.
+		if r.PathValue(AncestorPathValue(2)) != "" {
+			if _, err := resolveParent(r, h.resourceService, h.descriptor.ParentKind); err != nil {
+				return err
+			}
+		}
 		if _, err := h.resourceService.GetByOwner(ctx, h.descriptor.Kind, id, parentID); err != nil {
 			return err
 		}
@@ -114,7 +120,7 @@ func (h *ResourceStatusHandler) verifyResource(r *http.Request, id string) *erro
 func (h *ResourceStatusHandler) listStatuses(
 	ctx context.Context, resourceID string, listArgs *services.ListArguments,
 ) (interface{}, *errors.ServiceError) {
-	adapterStatuses, total, err := h.adapterStatusService.FindByResourcePaginated(
+	adapterStatuses, paging, err := h.adapterStatusService.FindByResourcePaginated(
 		ctx, h.descriptor.Kind, resourceID, listArgs,
 	)
 	if err != nil {
@@ -131,12 +137,7 @@ func (h *ResourceStatusHandler) listStatuses(
 		items = append(items, presented)
 	}
 
-	return openapi.AdapterStatusList{
-		Items: items,
-		Page:  int32(listArgs.Page),
-		Size:  int32(len(items)),
-		Total: int32(total),
-	}, nil
+	return presenters.PresentAdapterStatusList(items, paging), nil
 }
 
 // processStatus converts the request, delegates to ProcessAdapterStatus, and presents the result.
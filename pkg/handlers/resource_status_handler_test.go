@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -52,7 +53,7 @@ func TestResourceStatusHandler_List(t *testing.T) {
 	}
 	mockAdapterSvc.EXPECT().FindByResourcePaginated(
 		gomock.Any(), "Channel", testChannelID, gomock.Any(),
-	).Return(statuses, int64(1), nil)
+	).Return(statuses, &api.PagingMeta{Page: 1, Size: 1, Total: 1}, nil)
 
 	r := httptest.NewRequest(http.MethodGet, "/channels/ch-1/statuses", nil)
 	r.SetPathValue("id", testChannelID)
@@ -65,7 +66,37 @@ func TestResourceStatusHandler_List(t *testing.T) {
 	var response openapi.AdapterStatusList
 	Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
 	Expect(response.Items).To(HaveLen(1))
-	Expect(response.Total).To(Equal(int32(1)))
+	Expect(response.Total).To(HaveValue(Equal(int32(1))))
+}
+
+func TestResourceStatusHandler_List_ContinueWithoutCount(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+
+	handler, mockResourceSvc, mockAdapterSvc := newTestResourceStatusHandler(ctrl)
+
+	mockResourceSvc.EXPECT().Get(gomock.Any(), "Channel", testChannelID).Return(&api.Resource{Kind: "Channel"}, nil)
+	mockAdapterSvc.EXPECT().FindByResourcePaginated(
+		gomock.Any(), "Channel", testChannelID, gomock.Any(),
+	).DoAndReturn(func(
+		_ context.Context, _, _ string, listArgs *services.ListArguments,
+	) (api.AdapterStatusList, *api.PagingMeta, *errors.ServiceError) {
+		Expect(listArgs.Continue).To(Equal("token-1"))
+		Expect(listArgs.SkipCount).To(BeTrue())
+		return api.AdapterStatusList{}, &api.PagingMeta{Page: 1, Continue: "token-2", Uncounted: true}, nil
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/channels/ch-1/statuses?continue=token-1&count=false", nil)
+	r.SetPathValue("id", testChannelID)
+	w := httptest.NewRecorder()
+
+	handler.List(w, r)
+
+	Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+	var body map[string]interface{}
+	Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+	Expect(body).To(HaveKeyWithValue("continue", "token-2"))
+	Expect(body).NotTo(HaveKey("total"))
 }
 
 func TestResourceStatusHandler_List_ResourceNotFound(t *testing.T) {
@@ -276,7 +307,7 @@ func TestResourceStatusHandler_List_NestedWithParentID(t *testing.T) {
 
 	mockAdapterSvc.EXPECT().FindByResourcePaginated(
 		gomock.Any(), "Version", versionID, gomock.Any(),
-	).Return(api.AdapterStatusList{}, int64(0), nil)
+	).Return(api.AdapterStatusList{}, &api.PagingMeta{Page: 1}, nil)
 
 	r := httptest.NewRequest(http.MethodGet, "/channels/"+parentID+"/versions/"+versionID+"/statuses", nil)
 	r.SetPathValue("parent_id", parentID)
@@ -365,7 +396,7 @@ func newTestRootResourceHandler(
 ) (*RootResourceHandler, *services.MockResourceService, *services.MockAdapterStatusService) {
 	mockResourceSvc := services.NewMockResourceService(ctrl)
 	mockAdapterSvc := services.NewMockAdapterStatusService(ctrl)
-	handler := NewRootResourceHandler(mockResourceSvc, mockAdapterSvc, nil)
+	handler := NewRootResourceHandler(mockResourceSvc, mockAdapterSvc, nil, nil)
 	return handler, mockResourceSvc, mockAdapterSvc
 }
 
@@ -392,7 +423,7 @@ func TestRootResourceHandler_ListStatuses(t *testing.T) {
 	}
 	mockAdapterSvc.EXPECT().FindByResourcePaginated(
 		gomock.Any(), "Channel", testChannelID, gomock.Any(),
-	).Return(statuses, int64(1), nil)
+	).Return(statuses, &api.PagingMeta{Page: 1, Size: 1, Total: 1}, nil)
 
 	r := httptest.NewRequest(http.MethodGet, "/resources/"+testChannelID+"/statuses", nil)
 	r.SetPathValue("id", testChannelID)
@@ -405,7 +436,7 @@ func TestRootResourceHandler_ListStatuses(t *testing.T) {
 	var response openapi.AdapterStatusList
 	Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
 	Expect(response.Items).To(HaveLen(1))
-	Expect(response.Total).To(Equal(int32(1)))
+	Expect(response.Total).To(HaveValue(Equal(int32(1))))
 }
 
 func TestRootResourceHandler_ListStatuses_ResourceNotFound(t *testing.T) {
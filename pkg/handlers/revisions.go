@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/validators"
+)
+
+// rollbackRequest is the body of POST /{plural}/{id}/rollback.
+type rollbackRequest struct {
+	Generation *int32 `json:"generation"`
+}
+
+// pathGeneration parses the {generation} path segment of a revision route.
+func pathGeneration(r *http.Request) (int32, *errors.ServiceError) {
+	generation, err := strconv.ParseInt(r.PathValue("generation"), 10, 32)
+	if err != nil || generation < 1 {
+		return 0, errors.Validation("generation must be a positive integer")
+	}
+	return int32(generation), nil
+}
+
+// rollbackResource re-applies the revision named in the request body to current as a new
+// generation. The revision goes through the same spec schema validation and service Patch
+// as any other update, and is conditioned on current's version like a document patch.
+func rollbackResource(
+	r *http.Request, service services.ResourceService, validator *validators.SchemaValidator,
+	plural string, current *api.Resource,
+) (*api.Resource, *errors.ServiceError) {
+	var req rollbackRequest
+	if svcErr := decodeStrict(r, &req); svcErr != nil {
+		return nil, svcErr
+	}
+	if req.Generation == nil || *req.Generation < 1 {
+		return nil, errors.Validation("generation must be a positive integer")
+	}
+
+	revision, svcErr := service.GetRevision(r.Context(), current.Kind, current.ID, *req.Generation)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	patch, err := revision.Patch()
+	if err != nil {
+		return nil, errors.GeneralError("failed to read revision %d: %v", *req.Generation, err)
+	}
+	if svcErr := validateSpecSchema(validator, plural, patch.Spec); svcErr != nil {
+		return nil, svcErr
+	}
+
+	ctx, svcErr := pinPatchBase(r, current)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	return service.Patch(ctx, current.Kind, current.ID, patch)
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+func testRevision() *api.ResourceRevision {
+	return &api.ResourceRevision{
+		ResourceID:  "ch-123",
+		Kind:        "Channel",
+		Generation:  2,
+		CreatedBy:   "u@t.com",
+		CreatedTime: time.Now(),
+		Spec:        datatypes.JSON(`{"region":"eu"}`),
+		Labels:      datatypes.JSON(`{"env":"dev"}`),
+		References:  datatypes.JSON(`{}`),
+	}
+}
+
+func TestResourceHandler_Rollback(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	current := patchBaseResource()
+	handler, mockResourceSvc := newTestResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().Get(gomock.Any(), "Channel", "ch-123").Return(current, nil)
+	mockResourceSvc.EXPECT().GetRevision(gomock.Any(), "Channel", "ch-123", int32(2)).Return(testRevision(), nil)
+	mockResourceSvc.EXPECT().Patch(gomock.Any(), "Channel", "ch-123", gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _, _ string, patch *api.ResourcePatch,
+		) (*api.Resource, *errors.ServiceError) {
+			// Every member is set, so labels and references added since generation 2 are dropped.
+			Expect(patch.Spec).To(Equal(map[string]interface{}{"region": "eu"}))
+			Expect(patch.Labels).To(Equal(map[string]string{"env": "dev"}))
+			Expect(patch.References).To(Equal(api.ReferenceMap{}))
+			current.Generation = 5
+			return current, nil
+		})
+
+	req := httptest.NewRequest(http.MethodPost,
+		"/api/hyperfleet/v1/channels/ch-123/rollback", strings.NewReader(`{"generation":2}`))
+	req.SetPathValue("id", "ch-123")
+	rr := httptest.NewRecorder()
+
+	handler.Rollback(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+	Expect(rr.Header().Get("ETag")).To(Equal(current.ETag()))
+}
+
+func TestResourceHandler_RollbackRejected(t *testing.T) {
+	tests := []struct {
+		revisionErr        *errors.ServiceError
+		name               string
+		ifMatch            string
+		body               string
+		lookupRevision     bool
+		expectedStatusCode int
+	}{
+		{
+			name:               "missing generation",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "non-positive generation",
+			body:               `{"generation":0}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "unknown member",
+			body:               `{"generation":2,"spec":{}}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "unknown generation",
+			body:               `{"generation":9}`,
+			lookupRevision:     true,
+			revisionErr:        errors.NotFound("Channel revision with generation='9' not found"),
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "stale If-Match",
+			ifMatch:            `"1-0"`,
+			body:               `{"generation":2}`,
+			lookupRevision:     true,
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockResourceSvc := newTestResourceHandler(ctrl)
+			mockResourceSvc.EXPECT().Get(gomock.Any(), "Channel", "ch-123").Return(patchBaseResource(), nil)
+			if tt.lookupRevision {
+				revision := testRevision()
+				if tt.revisionErr != nil {
+					revision = nil
+				}
+				mockResourceSvc.EXPECT().GetRevision(gomock.Any(), "Channel", "ch-123", gomock.Any()).
+					Return(revision, tt.revisionErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost,
+				"/api/hyperfleet/v1/channels/ch-123/rollback", strings.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			req.SetPathValue("id", "ch-123")
+			rr := httptest.NewRecorder()
+
+			handler.Rollback(rr, req)
+			Expect(rr.Code).To(Equal(tt.expectedStatusCode), rr.Body.String())
+		})
+	}
+}
+
+func TestResourceHandler_Revision(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockResourceSvc := newTestResourceHandler(ctrl)
+	mockResourceSvc.EXPECT().GetRevision(gomock.Any(), "Channel", "ch-123", int32(2)).Return(testRevision(), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-123/revisions/2", nil)
+	req.SetPathValue("id", "ch-123")
+	req.SetPathValue("generation", "2")
+	rr := httptest.NewRecorder()
+
+	handler.Revision(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+	Expect(rr.Body.String()).To(ContainSubstring(`"labels":{"env":"dev"}`))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-123/revisions/latest", nil)
+	req.SetPathValue("id", "ch-123")
+	req.SetPathValue("generation", "latest")
+	rr = httptest.NewRecorder()
+
+	handler.Revision(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusBadRequest))
+}
+
+func TestResourceHandler_RevisionsByOwner(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockSvc := newTestVersionHandler(ctrl)
+	mockSvc.EXPECT().Get(gomock.Any(), "Channel", "ch-1").Return(&api.Resource{Kind: "Channel"}, nil)
+	mockSvc.EXPECT().GetByOwner(gomock.Any(), "Version", "v-1", "ch-1").Return(&api.Resource{Kind: "Version"}, nil)
+	mockSvc.EXPECT().ListRevisions(gomock.Any(), "Version", "v-1", gomock.Any()).
+		Return(api.ResourceRevisionList{}, &api.PagingMeta{Page: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels/ch-1/versions/v-1/revisions", nil)
+	req.SetPathValue("parent_id", "ch-1")
+	req.SetPathValue("id", "v-1")
+	rr := httptest.NewRecorder()
+
+	handler.Revisions(rr, req)
+	Expect(rr.Code).To(Equal(http.StatusOK))
+	Expect(rr.Body.String()).To(MatchJSON(`{"items":[],"page":1,"size":0,"total":0}`))
+}
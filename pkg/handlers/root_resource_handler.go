@@ -16,35 +16,55 @@ import (
 type RootResourceHandler struct {
 	service              services.ResourceService
 	adapterStatusService services.AdapterStatusService
+	watch                services.WatchService
 	validator            *validators.SchemaValidator
 }
 
 func NewRootResourceHandler(
 	service services.ResourceService,
 	adapterStatusService services.AdapterStatusService,
+	watch services.WatchService,
 	validator *validators.SchemaValidator,
 ) *RootResourceHandler {
 	return &RootResourceHandler{
 		service:              service,
 		adapterStatusService: adapterStatusService,
+		watch:                watch,
 		validator:            validator,
 	}
 }
 
 func (h *RootResourceHandler) List(w http.ResponseWriter, r *http.Request) {
+	kind, svcErr := kindParam(r)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	watch, svcErr := watchRequested(r)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+	if watch {
+		serveWatch(w, r, h.watch, services.WatchFilter{Kind: kind})
+		return
+	}
+
 	listArgs, svcErr := parseListParams(r.URL.Query())
 	if svcErr != nil {
 		handleError(r, w, svcErr)
 		return
 	}
+	// Resources of different kinds have different specs, so their fields can only be
+	// checked when the list is of one kind.
+	if svcErr = validateSpecFields(h.validator, kind, listArgs.Fields); svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
 
-	if kind := r.URL.Query().Get("kind"); kind != "" {
-		descriptor, ok := registry.Get(kind)
-		if !ok {
-			handleError(r, w, errors.Validation("Unknown entity kind: %s", kind))
-			return
-		}
-		kindFilter := fmt.Sprintf("kind = '%s'", descriptor.Kind)
+	if kind != "" {
+		kindFilter := fmt.Sprintf("kind = '%s'", kind)
 		if listArgs.Search == "" {
 			listArgs.Search = kindFilter
 		} else {
@@ -71,6 +91,47 @@ func (h *RootResourceHandler) List(w http.ResponseWriter, r *http.Request) {
 	writeJSONResponse(w, r, http.StatusOK, presented)
 }
 
+// Aggregate counts the resources of every kind, or of the kind query parameter, matching
+// the list's search and labelSelector per distinct value of the groupBy fields.
+func (h *RootResourceHandler) Aggregate(w http.ResponseWriter, r *http.Request) {
+	kind, svcErr := kindParam(r)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+	groupBy, svcErr := parseGroupBy(r)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+	listArgs, svcErr := parseListParams(r.URL.Query())
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	aggregate, svcErr := h.service.Aggregate(r.Context(), kind, "", groupBy, listArgs)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResourceAggregate(aggregate))
+}
+
+// kindParam resolves the optional kind query parameter of a /resources request to a
+// registered kind, or "" when it is not set.
+func kindParam(r *http.Request) (string, *errors.ServiceError) {
+	k := r.URL.Query().Get("kind")
+	if k == "" {
+		return "", nil
+	}
+	descriptor, ok := registry.Get(k)
+	if !ok {
+		return "", errors.Validation("Unknown entity kind: %s", k)
+	}
+	return descriptor.Kind, nil
+}
+
 func (h *RootResourceHandler) Get(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	resource, svcErr := h.service.GetByID(r.Context(), id)
@@ -78,12 +139,20 @@ func (h *RootResourceHandler) Get(w http.ResponseWriter, r *http.Request) {
 		handleError(r, w, svcErr)
 		return
 	}
-
+	if svcErr = validateSpecFields(h.validator, resource.Kind, requestedFields(r)); svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
 	result, svcErr := applyFieldFilter(r, presenters.PresentResource(resource))
 	if svcErr != nil {
 		handleError(r, w, svcErr)
 		return
 	}
+	etag := representationETag(r, resource)
+	if notModified(w, r, etag) {
+		return
+	}
+	w.Header().Set("ETag", etag)
 	writeJSONResponse(w, r, http.StatusOK, result)
 }
 
@@ -126,10 +195,16 @@ func (h *RootResourceHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", etag)
 	writeJSONResponse(w, r, http.StatusCreated, presenters.PresentResource(resource))
 }
 
 func (h *RootResourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	if mediaType := patchMediaType(r); mediaType != mediaTypeJSON {
+		h.patchDocument(w, r, mediaType)
+		return
+	}
+
 	var req openapi.ResourcePatchRequest
 	validateFuncs := []validate{
 		validatePatchRequest(&req),
@@ -141,7 +216,7 @@ func (h *RootResourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id := r.PathValue("id")
-	ctx := r.Context()
+	ctx := withIfMatch(r)
 	resource, svcErr := h.service.GetByID(ctx, id)
 	if svcErr != nil {
 		handleError(r, w, svcErr)
@@ -149,17 +224,13 @@ func (h *RootResourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.Spec != nil && h.validator != nil {
-		descriptor, ok := registry.Get(resource.Kind)
-		if !ok {
-			handleError(r, w, errors.GeneralError("Resource kind %q is no longer registered", resource.Kind))
+		descriptor, svcErr := h.registeredDescriptor(resource.Kind)
+		if svcErr != nil {
+			handleError(r, w, svcErr)
 			return
 		}
-		if validationErr := h.validator.Validate(descriptor.Plural, *req.Spec); validationErr != nil {
-			specErr, ok := validationErr.(*errors.ServiceError)
-			if !ok {
-				specErr = errors.Validation("Spec validation failed: %v", validationErr)
-			}
-			handleError(r, w, specErr)
+		if svcErr := validateSpecSchema(h.validator, descriptor.Plural, *req.Spec); svcErr != nil {
+			handleError(r, w, svcErr)
 			return
 		}
 	}
@@ -171,9 +242,41 @@ func (h *RootResourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, resource)
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResource(resource))
+}
+
+// patchDocument serves merge patch and JSON patch bodies for a resource resolved by ID.
+func (h *RootResourceHandler) patchDocument(w http.ResponseWriter, r *http.Request, mediaType string) {
+	current, svcErr := h.service.GetByID(r.Context(), r.PathValue("id"))
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+	descriptor, svcErr := h.registeredDescriptor(current.Kind)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	resource, svcErr := patchDocument(r, mediaType, h.service, h.validator, descriptor.Plural, current)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	setETag(w, resource)
 	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResource(resource))
 }
 
+func (h *RootResourceHandler) registeredDescriptor(kind string) (registry.EntityDescriptor, *errors.ServiceError) {
+	descriptor, ok := registry.Get(kind)
+	if !ok {
+		return registry.EntityDescriptor{}, errors.GeneralError("Resource kind %q is no longer registered", kind)
+	}
+	return descriptor, nil
+}
+
 func (h *RootResourceHandler) ForceDelete(w http.ResponseWriter, r *http.Request) {
 	var req openapi.ForceDeleteRequest
 	validateFuncs := []validate{
@@ -203,7 +306,7 @@ func (h *RootResourceHandler) ForceDelete(w http.ResponseWriter, r *http.Request
 
 func (h *RootResourceHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	ctx := r.Context()
+	ctx := withIfMatch(r)
 	resource, svcErr := h.service.GetByID(ctx, id)
 	if svcErr != nil {
 		handleError(r, w, svcErr)
@@ -216,9 +319,113 @@ func (h *RootResourceHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, resource)
 	writeJSONResponse(w, r, http.StatusAccepted, presenters.PresentResource(resource))
 }
 
+// History returns the audit events of a resource resolved by ID.
+func (h *RootResourceHandler) History(w http.ResponseWriter, r *http.Request) {
+	listArgs, svcErr := parseListParams(r.URL.Query())
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	events, paging, svcErr := h.service.History(r.Context(), "", r.PathValue("id"), "", listArgs)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResourceEventList(events, paging))
+}
+
+// Referencers lists the resources that reference a resource resolved by ID.
+func (h *RootResourceHandler) Referencers(w http.ResponseWriter, r *http.Request) {
+	referencers, svcErr := h.service.ListReferencers(r.Context(), "", r.PathValue("id"))
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentReferencerList(referencers))
+}
+
+// Graph returns the ownership tree and reference edges around a resource resolved by ID,
+// up to the depth given by the depth query parameter.
+func (h *RootResourceHandler) Graph(w http.ResponseWriter, r *http.Request) {
+	depth, svcErr := parseGraphDepth(r)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	graph, svcErr := h.service.Graph(r.Context(), r.PathValue("id"), depth)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResourceGraph(graph))
+}
+
+// Revisions returns the stored revisions of a resource resolved by ID.
+func (h *RootResourceHandler) Revisions(w http.ResponseWriter, r *http.Request) {
+	listArgs, svcErr := parseListParams(r.URL.Query())
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	revisions, paging, svcErr := h.service.ListRevisions(r.Context(), "", r.PathValue("id"), listArgs)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResourceRevisionList(revisions, paging))
+}
+
+// Revision returns one revision of a resource resolved by ID.
+func (h *RootResourceHandler) Revision(w http.ResponseWriter, r *http.Request) {
+	generation, svcErr := pathGeneration(r)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	revision, svcErr := h.service.GetRevision(r.Context(), "", r.PathValue("id"), generation)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResourceRevision(revision))
+}
+
+// Rollback re-applies a stored revision of a resource resolved by ID as a new generation.
+func (h *RootResourceHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	current, svcErr := h.service.GetByID(r.Context(), r.PathValue("id"))
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+	descriptor, svcErr := h.registeredDescriptor(current.Kind)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	resource, svcErr := rollbackResource(r, h.service, h.validator, descriptor.Plural, current)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	setETag(w, resource)
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentResource(resource))
+}
+
 // ListStatuses returns adapter statuses for a resource resolved by ID.
 func (h *RootResourceHandler) ListStatuses(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -235,7 +442,7 @@ func (h *RootResourceHandler) ListStatuses(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	statuses, total, svcErr := h.adapterStatusService.FindByResourcePaginated(
+	statuses, paging, svcErr := h.adapterStatusService.FindByResourcePaginated(
 		ctx, resource.Kind, id, listArgs,
 	)
 	if svcErr != nil {
@@ -254,14 +461,7 @@ func (h *RootResourceHandler) ListStatuses(w http.ResponseWriter, r *http.Reques
 		items = append(items, presented)
 	}
 
-	result := openapi.AdapterStatusList{
-		Items: items,
-		Page:  int32(listArgs.Page),
-		Size:  int32(len(items)),
-		Total: int32(total),
-	}
-
-	writeJSONResponse(w, r, http.StatusOK, result)
+	writeJSONResponse(w, r, http.StatusOK, presenters.PresentAdapterStatusList(items, paging))
 }
 
 // CreateStatus creates or updates an adapter status for a resource resolved by ID.
@@ -108,6 +108,16 @@ func validateMaxLength(i interface{}, fieldName string, field string, maxLen int
 	}
 }
 
+// nameLenBounds returns the name lengths validateName accepts for a descriptor's
+// NameMinLen and NameMaxLen: a name is never empty, and an unset maximum is the DB limit.
+func nameLenBounds(minLen, maxLen int) (effectiveMin, effectiveMax int) {
+	effectiveMin, effectiveMax = max(minLen, 1), maxLen
+	if effectiveMax == 0 {
+		effectiveMax = dbNameMaxLen
+	}
+	return effectiveMin, effectiveMax
+}
+
 // validateName validates that a name field matches the pattern ^[a-z0-9-]+$ and length constraints
 //
 //nolint:unparam // fieldName is kept as parameter for flexibility even though currently only "Name" is used
@@ -134,10 +144,7 @@ func validateName(i interface{}, fieldName string, field string, minLen, maxLen
 		}
 
 		// Check maximum length (0 = no constraint beyond DB limit)
-		effectiveMax := maxLen
-		if effectiveMax == 0 {
-			effectiveMax = dbNameMaxLen
-		}
+		_, effectiveMax := nameLenBounds(minLen, maxLen)
 		if len(name) > effectiveMax {
 			return errors.Validation("%s must be at most %d characters", field, effectiveMax)
 		}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+)
+
+// Change type of the keep-alive event that carries the stream position forward while
+// nothing the client watches has changed.
+const changeBookmark = "BOOKMARK"
+
+// watchEvent is the data of one server-sent event on a watch stream.
+type watchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object,omitempty"`
+}
+
+// watchRequested reports whether a list request asked for a change stream (?watch=true).
+func watchRequested(r *http.Request) (bool, *errors.ServiceError) {
+	v := strings.TrimSpace(r.URL.Query().Get("watch"))
+	if v == "" {
+		return false, nil
+	}
+	watch, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, errors.ValidationWithDetails("Invalid query parameters", []errors.ValidationDetail{{
+			Field:      "watch",
+			Value:      v,
+			Constraint: "format",
+			Message:    "must be a boolean",
+		}})
+	}
+	return watch, nil
+}
+
+// watchStartPosition resolves where a stream starts. A reconnecting EventSource sends
+// the id of the last event it saw as Last-Event-ID, which wins over ?position= because
+// the reconnect reuses the original URL. Without either, the stream starts from now.
+func watchStartPosition(r *http.Request, watch services.WatchService) (api.ChangePosition, *errors.ServiceError) {
+	token := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if token == "" {
+		token = strings.TrimSpace(r.URL.Query().Get("position"))
+	}
+	if token == "" {
+		return watch.Head(r.Context())
+	}
+	position, err := api.ParseChangePosition(token)
+	if err != nil {
+		return api.ChangePosition{}, errors.BadRequest("Invalid watch position: %s", err)
+	}
+	if svcErr := watch.CheckPosition(r.Context(), position); svcErr != nil {
+		return api.ChangePosition{}, svcErr
+	}
+	return position, nil
+}
+
+// serveWatch streams ADDED, MODIFIED and DELETED events for resources matching filter
+// as server-sent events until the client disconnects. Each event's id is the position
+// to resume from; idle streams get BOOKMARK events so that position stays current.
+func serveWatch(
+	w http.ResponseWriter, r *http.Request, watch services.WatchService, filter services.WatchFilter,
+) {
+	if watch == nil {
+		handleError(r, w, errors.NotImplemented("Watch is not available"))
+		return
+	}
+	for _, param := range []string{"search", "labelSelector"} {
+		if r.URL.Query().Get(param) != "" {
+			handleError(r, w, errors.BadRequest("%s is not supported with watch", param))
+			return
+		}
+	}
+
+	ctx := r.Context()
+	position, svcErr := watchStartPosition(r, watch)
+	if svcErr != nil {
+		handleError(r, w, svcErr)
+		return
+	}
+
+	// Subscribe before the first poll so a change committed in between still wakes us.
+	notifications, unsubscribe := watch.Subscribe()
+	defer unsubscribe()
+
+	rc := http.NewResponseController(w)
+	// The stream outlives the server's write timeout; clear the deadline where supported.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		logger.WithError(ctx, err).Warn("Watch stream does not support flushing")
+		return
+	}
+
+	heartbeat := time.NewTicker(watch.Heartbeat())
+	defer heartbeat.Stop()
+
+	log := logger.With(ctx, "kind", filter.Kind, "owner_id", filter.OwnerID)
+	sent := position
+	beat := false
+	for {
+		for {
+			changes, next, svcErr := watch.Poll(ctx, filter, position)
+			if svcErr != nil {
+				// Headers are already out; end the stream and let the client resume.
+				log.WithError(svcErr).Error("Watch poll failed")
+				return
+			}
+			position = next
+			for _, change := range changes {
+				event := watchEvent{Type: change.Type, Object: json.RawMessage(change.Object)}
+				if err := writeWatchEvent(w, change.Position(), event); err != nil {
+					return
+				}
+				sent = change.Position()
+			}
+			if len(changes) == 0 {
+				break
+			}
+		}
+
+		if beat {
+			beat = false
+			var err error
+			if sent.Before(position) {
+				err = writeWatchEvent(w, position, watchEvent{Type: changeBookmark})
+				sent = position
+			} else {
+				_, err = fmt.Fprint(w, ": keep-alive\n\n")
+			}
+			if err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case _, open := <-notifications:
+			if !open {
+				return
+			}
+		case <-heartbeat.C:
+			beat = true
+		}
+	}
+}
+
+func writeWatchEvent(w http.ResponseWriter, position api.ChangePosition, event watchEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\ndata: %s\n\n", position, data)
+	return err
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+)
+
+// fakeWatchService serves a fixed batch of changes once. Its subscription channel is
+// closed, so a stream ends as soon as it has drained the batch.
+type fakeWatchService struct {
+	positionErr *errors.ServiceError
+	filter      services.WatchFilter
+	changes     []*api.ResourceChange
+	head        api.ChangePosition
+	polledFrom  []api.ChangePosition
+}
+
+var _ services.WatchService = &fakeWatchService{}
+
+func (f *fakeWatchService) Head(context.Context) (api.ChangePosition, *errors.ServiceError) {
+	return f.head, nil
+}
+
+func (f *fakeWatchService) CheckPosition(context.Context, api.ChangePosition) *errors.ServiceError {
+	return f.positionErr
+}
+
+func (f *fakeWatchService) Poll(
+	_ context.Context, filter services.WatchFilter, after api.ChangePosition,
+) ([]*api.ResourceChange, api.ChangePosition, *errors.ServiceError) {
+	f.filter = filter
+	f.polledFrom = append(f.polledFrom, after)
+	changes := f.changes
+	f.changes = nil
+	if len(changes) == 0 {
+		return nil, after, nil
+	}
+	return changes, changes[len(changes)-1].Position(), nil
+}
+
+func (f *fakeWatchService) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{})
+	close(ch)
+	return ch, func() {}
+}
+
+func (f *fakeWatchService) Heartbeat() time.Duration { return time.Hour }
+func (f *fakeWatchService) Run(context.Context)      {}
+
+func TestResourceHandler_Watch_Rejected(t *testing.T) {
+	tests := []struct {
+		watch              services.WatchService
+		name               string
+		target             string
+		expectedStatusCode int
+	}{
+		{
+			name:               "invalid watch value",
+			watch:              &fakeWatchService{},
+			target:             "/api/hyperfleet/v1/channels?watch=sometimes",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "search is not supported",
+			watch:              &fakeWatchService{},
+			target:             "/api/hyperfleet/v1/channels?watch=true&search=name%3D'a'",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "labelSelector is not supported",
+			watch:              &fakeWatchService{},
+			target:             "/api/hyperfleet/v1/channels?watch=true&labelSelector=env%3Dprod",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "malformed position",
+			watch:              &fakeWatchService{},
+			target:             "/api/hyperfleet/v1/channels?watch=true&position=abc",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "expired position",
+			watch:              &fakeWatchService{positionErr: errors.Gone("expired")},
+			target:             "/api/hyperfleet/v1/channels?watch=true&position=1-1",
+			expectedStatusCode: http.StatusGone,
+		},
+		{
+			name:               "watch not configured",
+			target:             "/api/hyperfleet/v1/channels?watch=true",
+			expectedStatusCode: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewResourceHandler(channelDescriptor, services.NewMockResourceService(ctrl), tt.watch, nil)
+			rr := httptest.NewRecorder()
+			handler.List(rr, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			Expect(rr.Code).To(Equal(tt.expectedStatusCode))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/problem+json"))
+		})
+	}
+}
+
+func TestResourceHandler_Watch_StreamsEvents(t *testing.T) {
+	RegisterTestingT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	watch := &fakeWatchService{
+		head: api.ChangePosition{TxID: 90, ID: 7},
+		changes: []*api.ResourceChange{{
+			Type:   api.ChangeAdded,
+			Kind:   "Channel",
+			Object: datatypes.JSON(`{"id":"ch-1","kind":"Channel"}`),
+			TxID:   100,
+			ID:     8,
+		}},
+	}
+	handler := NewResourceHandler(channelDescriptor, services.NewMockResourceService(ctrl), watch, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/hyperfleet/v1/channels?watch=true&position=1-1", nil)
+	req.Header.Set("Last-Event-ID", "95-7")
+	rr := httptest.NewRecorder()
+	handler.List(rr, req)
+
+	Expect(rr.Code).To(Equal(http.StatusOK))
+	Expect(rr.Header().Get("Content-Type")).To(Equal("text/event-stream"))
+	Expect(rr.Body.String()).To(Equal(
+		"id: 100-8\ndata: {\"type\":\"ADDED\",\"object\":{\"id\":\"ch-1\",\"kind\":\"Channel\"}}\n\n"))
+	Expect(watch.filter).To(Equal(services.WatchFilter{Kind: "Channel"}))
+	// Last-Event-ID wins over ?position= so a reconnect resumes where it left off.
+	Expect(watch.polledFrom[0]).To(Equal(api.ChangePosition{TxID: 95, ID: 7}))
+}
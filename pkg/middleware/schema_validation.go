@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"regexp"
 
@@ -75,7 +76,7 @@ func SchemaValidationMiddleware(validator *validators.SchemaValidator) func(http
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			shouldValidate, resourcePlural := shouldValidateRequest(r.Method, r.URL.Path, matchers)
-			if !shouldValidate {
+			if !shouldValidate || isDocumentPatch(r) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -148,6 +149,17 @@ func SchemaValidationMiddleware(validator *validators.SchemaValidator) func(http
 	}
 }
 
+// isDocumentPatch reports whether r is a merge patch or JSON patch. Those bodies are
+// partial or not objects at all, so the handler validates the patched spec instead.
+func isDocumentPatch(r *http.Request) bool {
+	if r.Method != http.MethodPatch {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil &&
+		(mediaType == "application/merge-patch+json" || mediaType == "application/json-patch+json")
+}
+
 // rootResourcePattern matches the /resources root endpoint (with or without a trailing UUID).
 var rootResourcePattern = regexp.MustCompile(
 	`/resources(?:/?|/[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`,
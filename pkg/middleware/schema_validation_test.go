@@ -3,6 +3,7 @@ package middleware
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -41,6 +42,16 @@ components:
           type: integer
           minimum: 1
           maximum: 10
+        autoscaling:
+          type: object
+          default:
+            enabled: false
+          properties:
+            enabled:
+              type: boolean
+            max_replicas:
+              type: integer
+              default: 5
 
     FooSpec:
       type: object
@@ -158,6 +169,40 @@ func TestSchemaValidationMiddleware_PatchRequestValidation(t *testing.T) {
 	Expect(rr.Code).To(Equal(http.StatusOK))
 }
 
+func TestSchemaValidationMiddleware_DocumentPatchSkipped(t *testing.T) {
+	RegisterTestingT(t)
+
+	validator := setupTestValidator(t)
+	middleware := SchemaValidationMiddleware(validator)
+
+	// Partial specs would fail the schema's required fields; the handler validates the
+	// patched spec instead.
+	bodies := map[string]string{
+		"application/merge-patch+json": `{"spec":{"labels_only":true}}`,
+		"application/json-patch+json":  `[{"op":"remove","path":"/spec/region"}]`,
+	}
+	for contentType, body := range bodies {
+		req := httptest.NewRequest(
+			http.MethodPatch,
+			"/api/hyperfleet/v1/clusters/550e8400-e29b-41d4-a716-446655440000",
+			bytes.NewBufferString(body),
+		)
+		req.Header.Set("Content-Type", contentType)
+		rr := httptest.NewRecorder()
+
+		nextHandlerCalled := false
+		nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nextHandlerCalled = true
+			w.WriteHeader(http.StatusOK)
+		})
+
+		middleware(nextHandler).ServeHTTP(rr, req)
+
+		Expect(nextHandlerCalled).To(BeTrue(), contentType)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	}
+}
+
 func TestSchemaValidationMiddleware_GetRequestSkipped(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -276,6 +321,36 @@ func TestSchemaValidationMiddleware_NodePoolValidation(t *testing.T) {
 	Expect(rr.Code).To(Equal(http.StatusCreated))
 }
 
+func TestSchemaValidationMiddleware_PassesBodyThroughUnchanged(t *testing.T) {
+	RegisterTestingT(t)
+
+	validator := setupTestValidator(t)
+	middleware := SchemaValidationMiddleware(validator)
+
+	// The NodePool schema defaults autoscaling; the service fills it in, not the middleware.
+	body := []byte(`{"name":"test-nodepool","spec":{"replicas":3},"labels":{"id":"9007199254740993"}}`)
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/hyperfleet/v1/clusters/550e8400-e29b-41d4-a716-446655440000/nodepools",
+		bytes.NewBuffer(body),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	var received []byte
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var readErr error
+		received, readErr = io.ReadAll(r.Body)
+		Expect(readErr).NotTo(HaveOccurred())
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	middleware(nextHandler).ServeHTTP(rr, req)
+
+	Expect(rr.Code).To(Equal(http.StatusCreated), rr.Body.String())
+	Expect(received).To(Equal(body))
+}
+
 func TestSchemaValidationMiddleware_NestedNodePoolPathUsesNodePoolSchema(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -8,6 +8,16 @@ const (
 	OnParentDeleteCascade  OnParentDeletePolicy = "cascade"
 )
 
+// OnTargetDeletePolicy determines referencer behavior when a referenced target is deleted.
+// The zero value behaves as OnTargetDeleteRestrict.
+type OnTargetDeletePolicy string
+
+const (
+	OnTargetDeleteRestrict OnTargetDeletePolicy = "restrict"
+	OnTargetDeleteCascade  OnTargetDeletePolicy = "cascade"
+	OnTargetDeleteDetach   OnTargetDeletePolicy = "detach"
+)
+
 // ReferenceDescriptor declares a non-ownership association from one entity type to another.
 // See HYPERFLEET-1156 for the full resource references implementation.
 type ReferenceDescriptor struct {
@@ -17,10 +27,20 @@ type ReferenceDescriptor struct {
 	TargetKind string `mapstructure:"target_kind" json:"target_kind"`
 	// minimum references of this type (0 = optional)
 	Min int `mapstructure:"min" json:"min,omitempty"`
+	// what happens to the referencer when the target is deleted ("" = restrict)
+	OnTargetDelete OnTargetDeletePolicy `mapstructure:"on_target_delete" json:"on_target_delete,omitempty"`
 	// maximum references of this type (0 = unlimited)
 	Max int `mapstructure:"max" json:"max,omitempty"`
 }
 
+// TargetDeletePolicy returns the effective on-target-delete policy, defaulting to restrict.
+func (r ReferenceDescriptor) TargetDeletePolicy() OnTargetDeletePolicy {
+	if r.OnTargetDelete == "" {
+		return OnTargetDeleteRestrict
+	}
+	return r.OnTargetDelete
+}
+
 // EntityDescriptor defines everything specific to a HyperFleet entity type.
 // Descriptors are loaded from the application config YAML at startup via LoadDescriptors.
 type EntityDescriptor struct {
@@ -38,8 +58,14 @@ type EntityDescriptor struct {
 	RequiredAdapters []string `mapstructure:"required_adapters" json:"required_adapters,omitempty"`
 	// non-ownership associations to other entity types (HYPERFLEET-1156)
 	References []ReferenceDescriptor `mapstructure:"references" json:"references,omitempty"`
+	// dotted spec paths that cannot change after creation, e.g. "platform.type"
+	ImmutableFields []string `mapstructure:"immutable_fields" json:"immutable_fields,omitempty"`
 	// CEL-based condition mapping rules for this entity type
 	Conditions []ConditionMappingRule `mapstructure:"conditions" json:"conditions,omitempty"`
+	// CEL rules checked on every create and update of this entity type
+	Validations []ValidationRule `mapstructure:"validations" json:"validations,omitempty"`
+	// CEL projections of adapter-reported data into status.fields
+	StatusFields []StatusFieldRule `mapstructure:"status_fields" json:"status_fields,omitempty"`
 	// minimum name length (0 = no constraint)
 	NameMinLen int `mapstructure:"name_min_len" json:"name_min_len,omitempty"`
 	// maximum name length (0 = no constraint)
@@ -2,11 +2,18 @@ package registry
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
 )
 
-var descriptors = make(map[string]EntityDescriptor)
+// mu guards descriptors, which Replace swaps while requests read it.
+var (
+	mu          sync.RWMutex
+	descriptors = make(map[string]EntityDescriptor)
+)
 
 // Register adds a descriptor to the global registry. Panics on empty Kind or duplicate Kind.
 func Register(d EntityDescriptor) {
@@ -16,6 +23,8 @@ func Register(d EntityDescriptor) {
 	if d.Plural == "" {
 		panic(fmt.Sprintf("entity kind %q has empty plural", d.Kind))
 	}
+	mu.Lock()
+	defer mu.Unlock()
 	if _, exists := descriptors[d.Kind]; exists {
 		panic(fmt.Sprintf("entity kind %q already registered", d.Kind))
 	}
@@ -32,6 +41,8 @@ func LoadDescriptors(descriptors []EntityDescriptor) {
 
 // Get returns a descriptor by Kind, or (zero, false) if not found.
 func Get(entityKind string) (EntityDescriptor, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
 	d, ok := descriptors[entityKind]
 	return d, ok
 }
@@ -58,6 +69,8 @@ func Validated(entityKind string) (EntityDescriptor, *errors.ServiceError) {
 
 // All returns a snapshot of all registered descriptors.
 func All() []EntityDescriptor {
+	mu.RLock()
+	defer mu.RUnlock()
 	result := make([]EntityDescriptor, 0, len(descriptors))
 	for _, d := range descriptors {
 		result = append(result, d)
@@ -67,6 +80,8 @@ func All() []EntityDescriptor {
 
 // WithSpecSchema returns descriptors that declare an OpenAPI spec schema name.
 func WithSpecSchema() []EntityDescriptor {
+	mu.RLock()
+	defer mu.RUnlock()
 	var result []EntityDescriptor
 	for _, d := range descriptors {
 		if d.SpecSchemaName != "" {
@@ -78,6 +93,8 @@ func WithSpecSchema() []EntityDescriptor {
 
 // ChildrenOf returns descriptors whose ParentKind matches the given kind.
 func ChildrenOf(parentKind string) []EntityDescriptor {
+	mu.RLock()
+	defer mu.RUnlock()
 	var children []EntityDescriptor
 	for _, d := range descriptors {
 		if d.ParentKind == parentKind {
@@ -87,6 +104,24 @@ func ChildrenOf(parentKind string) []EntityDescriptor {
 	return children
 }
 
+// AncestorsOf returns the descriptors up kind's ParentKind chain, nearest first: its
+// parent, then its grandparent, up to a top-level kind. Unregistered parents end the
+// chain, and a ParentKind cycle (rejected by Validate) is walked only once.
+func AncestorsOf(entityKind string) []EntityDescriptor {
+	mu.RLock()
+	defer mu.RUnlock()
+	var ancestors []EntityDescriptor
+	seen := map[string]bool{entityKind: true}
+	for d, ok := descriptors[entityKind]; ok && d.ParentKind != "" && !seen[d.ParentKind]; {
+		seen[d.ParentKind] = true
+		d, ok = descriptors[d.ParentKind]
+		if ok {
+			ancestors = append(ancestors, d)
+		}
+	}
+	return ancestors
+}
+
 // Validate checks registry integrity. Panics on:
 //   - empty Kind or Plural on any descriptor
 //   - any ParentKind that references an unregistered kind
@@ -95,12 +130,21 @@ func ChildrenOf(parentKind string) []EntityDescriptor {
 //   - ReferenceDescriptor with TargetKind that doesn't resolve
 //   - duplicate RefType within a single entity's References
 //   - Max < Min (when Max > 0)
+//   - unknown OnTargetDelete policy, or detach on a required reference (Min > 0)
 //   - NameMaxLen > 0 && NameMinLen > NameMaxLen
 //   - circular required references (Min > 0 cycle between two or more kinds)
 func Validate() {
-	plurals := make(map[string]string, len(descriptors))
+	mu.RLock()
+	defer mu.RUnlock()
+	validateDescriptors(descriptors)
+}
 
-	for _, d := range descriptors {
+// validateDescriptors checks a set of descriptors as Validate does, panicking on the
+// first problem.
+func validateDescriptors(set map[string]EntityDescriptor) {
+	plurals := make(map[string]string, len(set))
+
+	for _, d := range set {
 		if d.Kind == "" {
 			panic("entity kind cannot be empty")
 		}
@@ -109,7 +153,7 @@ func Validate() {
 		}
 
 		if d.ParentKind != "" {
-			if _, ok := descriptors[d.ParentKind]; !ok {
+			if _, ok := set[d.ParentKind]; !ok {
 				panic(fmt.Sprintf(
 					"entity kind %q references unregistered parent kind %q",
 					d.Kind, d.ParentKind,
@@ -124,7 +168,7 @@ func Validate() {
 					))
 				}
 				visited[cur] = true
-				cur = descriptors[cur].ParentKind
+				cur = set[cur].ParentKind
 			}
 		}
 
@@ -139,7 +183,7 @@ func Validate() {
 		// Track seen RefType values to detect duplicates within this entity's References
 		refTypes := make(map[string]bool, len(d.References))
 		for _, ref := range d.References {
-			if _, ok := descriptors[ref.TargetKind]; !ok {
+			if _, ok := set[ref.TargetKind]; !ok {
 				panic(fmt.Sprintf(
 					"entity %q: reference %q targets unregistered kind %q",
 					d.Kind, ref.RefType, ref.TargetKind,
@@ -158,6 +202,22 @@ func Validate() {
 					d.Kind, ref.RefType, ref.Max, ref.Min,
 				))
 			}
+			switch ref.OnTargetDelete {
+			case "", OnTargetDeleteRestrict, OnTargetDeleteCascade:
+			case OnTargetDeleteDetach:
+				// Detaching would leave the referencer below its required count.
+				if ref.Min > 0 {
+					panic(fmt.Sprintf(
+						"entity %q: reference %q cannot use on_target_delete %q with min (%d) > 0",
+						d.Kind, ref.RefType, ref.OnTargetDelete, ref.Min,
+					))
+				}
+			default:
+				panic(fmt.Sprintf(
+					"entity %q: reference %q has unknown on_target_delete %q",
+					d.Kind, ref.RefType, ref.OnTargetDelete,
+				))
+			}
 		}
 
 		if d.NameMaxLen > 0 && d.NameMinLen > d.NameMaxLen {
@@ -166,29 +226,49 @@ func Validate() {
 				d.Kind, d.NameMaxLen, d.NameMinLen,
 			))
 		}
+
+		immutable := make(map[string]bool, len(d.ImmutableFields))
+		for _, field := range d.ImmutableFields {
+			if slices.Contains(strings.Split(field, "."), "") {
+				panic(fmt.Sprintf("entity %q: immutable field %q is not a dotted spec path", d.Kind, field))
+			}
+			if immutable[field] {
+				panic(fmt.Sprintf("entity %q: duplicate immutable field %q", d.Kind, field))
+			}
+			immutable[field] = true
+		}
 	}
 
 	// Validate condition mappings for each entity
-	entities := All() // snapshot of all descriptors
+	entities := make([]EntityDescriptor, 0, len(set))
+	for _, d := range set {
+		entities = append(entities, d)
+	}
 	for _, d := range entities {
 		if len(d.Conditions) > 0 {
 			if err := ValidateEntityConditions(entities, d); err != nil {
 				panic(fmt.Sprintf("entity %q: invalid conditions: %v", d.Kind, err))
 			}
 		}
+		if err := ValidateEntityValidations(d); err != nil {
+			panic(fmt.Sprintf("entity %q: invalid validations: %v", d.Kind, err))
+		}
+		if err := ValidateEntityStatusFields(d); err != nil {
+			panic(fmt.Sprintf("entity %q: invalid status_fields: %v", d.Kind, err))
+		}
 	}
 
 	// Detect cycles among required references (Min > 0).
 	// A cycle means two or more kinds mutually require each other, making
 	// Create impossible (each resource needs the other to exist first).
 	// Uses DFS with three-color marking: 0 = unvisited, 1 = in-stack, 2 = done.
-	color := make(map[string]int, len(descriptors))
+	color := make(map[string]int, len(set))
 	var path []string
 	var dfs func(kind string)
 	dfs = func(kind string) {
 		color[kind] = 1
 		path = append(path, kind)
-		d := descriptors[kind]
+		d := set[kind]
 		for _, ref := range d.References {
 			if ref.Min <= 0 {
 				continue
@@ -217,7 +297,7 @@ func Validate() {
 		path = path[:len(path)-1]
 		color[kind] = 2
 	}
-	for kind := range descriptors {
+	for kind := range set {
 		if color[kind] == 0 {
 			dfs(kind)
 		}
@@ -229,6 +309,8 @@ func Validate() {
 // RequireSpecSchema are left to buildSchemasMap, which warns and skips them.
 // See also Validate, which checks registry structural integrity.
 func ValidateSpecSchemas(schemaExists func(string) bool) {
+	mu.RLock()
+	defer mu.RUnlock()
 	for _, d := range descriptors {
 		if d.SpecSchemaName != "" && d.RequireSpecSchema && !schemaExists(d.SpecSchemaName) {
 			panic(fmt.Sprintf(
@@ -241,12 +323,16 @@ func ValidateSpecSchemas(schemaExists func(string) bool) {
 
 // Reset clears all registrations. Only for use in tests.
 func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
 	descriptors = make(map[string]EntityDescriptor)
 }
 
 // UpdateDescriptor modifies an existing descriptor in-place. Panics if kind not found.
 // Used by tests to temporarily override descriptor fields.
 func UpdateDescriptor(kind string, updateFn func(*EntityDescriptor)) {
+	mu.Lock()
+	defer mu.Unlock()
 	desc, ok := descriptors[kind]
 	if !ok {
 		panic(fmt.Sprintf("entity kind %q not registered", kind))
@@ -254,3 +340,58 @@ func UpdateDescriptor(kind string, updateFn func(*EntityDescriptor)) {
 	updateFn(&desc)
 	descriptors[kind] = desc
 }
+
+// Replace swaps in a new set of descriptors while the API is running, as on a
+// configuration reload. Routes and spec validation are set up once at startup, so the
+// set must keep every kind with the same plural, parent kind, and spec schema; condition
+// rules, required adapters, name bounds, references, and the parent delete policy may
+// change. The set is checked as Validate checks it at startup. On any error the registry
+// is left unchanged.
+func Replace(next []EntityDescriptor) (err error) {
+	set := make(map[string]EntityDescriptor, len(next))
+	for _, d := range next {
+		if _, exists := set[d.Kind]; exists {
+			return fmt.Errorf("entity kind %q is defined more than once", d.Kind)
+		}
+		set[d.Kind] = d
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err = checkReplaceable(descriptors, set); err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	validateDescriptors(set)
+	descriptors = set
+	return nil
+}
+
+// checkReplaceable rejects changes that would need routes or spec validation rebuilt.
+func checkReplaceable(current, next map[string]EntityDescriptor) error {
+	for kind := range current {
+		if _, ok := next[kind]; !ok {
+			return fmt.Errorf("entity kind %q cannot be removed without a restart", kind)
+		}
+	}
+	for kind, d := range next {
+		existing, ok := current[kind]
+		switch {
+		case !ok:
+			return fmt.Errorf("entity kind %q cannot be added without a restart", kind)
+		case d.Plural != existing.Plural:
+			return fmt.Errorf("entity kind %q: plural cannot change without a restart", kind)
+		case d.ParentKind != existing.ParentKind:
+			return fmt.Errorf("entity kind %q: parent_kind cannot change without a restart", kind)
+		case d.SpecSchemaName != existing.SpecSchemaName || d.RequireSpecSchema != existing.RequireSpecSchema:
+			return fmt.Errorf("entity kind %q: spec schema cannot change without a restart", kind)
+		case !slices.Equal(d.ImmutableFields, existing.ImmutableFields):
+			return fmt.Errorf("entity kind %q: immutable_fields cannot change without a restart", kind)
+		}
+	}
+	return nil
+}
@@ -131,6 +131,39 @@ func TestChildrenOf(t *testing.T) {
 	Expect(children).To(BeEmpty())
 }
 
+func TestAncestorsOf(t *testing.T) {
+	RegisterTestingT(t)
+	Reset()
+
+	Register(EntityDescriptor{Kind: "Cluster", Plural: "clusters"})
+	Register(EntityDescriptor{Kind: "NodePool", Plural: "nodepools", ParentKind: "Cluster"})
+	Register(EntityDescriptor{Kind: "Machine", Plural: "machines", ParentKind: "NodePool"})
+
+	kinds := func(ds []EntityDescriptor) []string {
+		result := make([]string, 0, len(ds))
+		for _, d := range ds {
+			result = append(result, d.Kind)
+		}
+		return result
+	}
+	Expect(kinds(AncestorsOf("Machine"))).To(Equal([]string{"NodePool", "Cluster"}))
+	Expect(kinds(AncestorsOf("NodePool"))).To(Equal([]string{"Cluster"}))
+	Expect(AncestorsOf("Cluster")).To(BeEmpty())
+	Expect(AncestorsOf("Ghost")).To(BeEmpty())
+}
+
+func TestAncestorsOf_CycleTerminates(t *testing.T) {
+	RegisterTestingT(t)
+	Reset()
+
+	Register(EntityDescriptor{Kind: "A", Plural: "as", ParentKind: "B"})
+	Register(EntityDescriptor{Kind: "B", Plural: "bs", ParentKind: "A"})
+
+	ancestors := AncestorsOf("A")
+	Expect(ancestors).To(HaveLen(1))
+	Expect(ancestors[0].Kind).To(Equal("B"))
+}
+
 func TestValidate_MissingParent_Panics(t *testing.T) {
 	RegisterTestingT(t)
 	Reset()
@@ -475,6 +508,50 @@ func TestValidate_ReferenceMaxLessThanMin_Panics(t *testing.T) {
 	}).To(PanicWith(ContainSubstring("max (1) < min (2)")))
 }
 
+func TestValidate_UnknownOnTargetDelete_Panics(t *testing.T) {
+	RegisterTestingT(t)
+	Reset()
+
+	Register(EntityDescriptor{Kind: "WifConfig", Plural: "wifconfigs"})
+	Register(EntityDescriptor{
+		Kind:   "Cluster",
+		Plural: "clusters",
+		References: []ReferenceDescriptor{
+			{RefType: "wif_config", TargetKind: "WifConfig", OnTargetDelete: "orphan"},
+		},
+	})
+
+	Expect(func() {
+		Validate()
+	}).To(PanicWith(ContainSubstring(`unknown on_target_delete "orphan"`)))
+}
+
+func TestValidate_DetachRequiredReference_Panics(t *testing.T) {
+	RegisterTestingT(t)
+	Reset()
+
+	Register(EntityDescriptor{Kind: "WifConfig", Plural: "wifconfigs"})
+	Register(EntityDescriptor{
+		Kind:   "Cluster",
+		Plural: "clusters",
+		References: []ReferenceDescriptor{
+			{RefType: "wif_config", TargetKind: "WifConfig", Min: 1, OnTargetDelete: OnTargetDeleteDetach},
+		},
+	})
+
+	Expect(func() {
+		Validate()
+	}).To(PanicWith(ContainSubstring(`cannot use on_target_delete "detach" with min (1) > 0`)))
+}
+
+func TestReferenceDescriptor_TargetDeletePolicy_DefaultsToRestrict(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ReferenceDescriptor{}.TargetDeletePolicy()).To(Equal(OnTargetDeleteRestrict))
+	Expect(ReferenceDescriptor{OnTargetDelete: OnTargetDeleteCascade}.TargetDeletePolicy()).
+		To(Equal(OnTargetDeleteCascade))
+}
+
 func TestValidate_ValidReferences_Success(t *testing.T) {
 	RegisterTestingT(t)
 	Reset()
@@ -495,6 +572,34 @@ func TestValidate_ValidReferences_Success(t *testing.T) {
 	}).ToNot(Panic())
 }
 
+func TestValidate_ImmutableFields(t *testing.T) {
+	RegisterTestingT(t)
+	Reset()
+
+	Register(EntityDescriptor{
+		Kind:            "Cluster",
+		Plural:          "clusters",
+		ImmutableFields: []string{"platform.type", "region"},
+	})
+	Expect(func() {
+		Validate()
+	}).ToNot(Panic())
+
+	UpdateDescriptor("Cluster", func(d *EntityDescriptor) {
+		d.ImmutableFields = []string{"platform..type"}
+	})
+	Expect(func() {
+		Validate()
+	}).To(PanicWith(ContainSubstring(`immutable field "platform..type" is not a dotted spec path`)))
+
+	UpdateDescriptor("Cluster", func(d *EntityDescriptor) {
+		d.ImmutableFields = []string{"region", "region"}
+	})
+	Expect(func() {
+		Validate()
+	}).To(PanicWith(ContainSubstring(`duplicate immutable field "region"`)))
+}
+
 func TestValidate_CircularRequiredRefs_DirectCycle_Panics(t *testing.T) {
 	RegisterTestingT(t)
 	Reset()
@@ -590,3 +695,111 @@ func TestValidate_CircularRequiredRefs_SelfCycle_Panics(t *testing.T) {
 		Validate()
 	}).To(PanicWith(ContainSubstring("circular required references")))
 }
+
+func registerReplaceTestDescriptors() []EntityDescriptor {
+	Reset()
+	current := []EntityDescriptor{
+		{Kind: "Channel", Plural: "channels", SpecSchemaName: "ChannelSpec"},
+		{Kind: "Version", Plural: "versions", ParentKind: "Channel", NameMaxLen: 20},
+	}
+	LoadDescriptors(current)
+	return current
+}
+
+func TestReplace_CompatibleChange(t *testing.T) {
+	RegisterTestingT(t)
+	next := registerReplaceTestDescriptors()
+
+	next[1].NameMaxLen = 40
+	next[1].RequiredAdapters = []string{"validation"}
+	next[1].References = []ReferenceDescriptor{{RefType: "channel", TargetKind: "Channel", Max: 1}}
+	next[1].OnParentDelete = OnParentDeleteCascade
+
+	Expect(Replace(next)).To(Succeed())
+	version := MustGet("Version")
+	Expect(version.NameMaxLen).To(Equal(40))
+	Expect(version.RequiredAdapters).To(ConsistOf("validation"))
+	Expect(version.References).To(HaveLen(1))
+	Expect(version.OnParentDelete).To(Equal(OnParentDeleteCascade))
+}
+
+func TestReplace_RejectsIncompatibleChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func([]EntityDescriptor) []EntityDescriptor
+		reason string
+	}{
+		{
+			name: "added kind",
+			change: func(next []EntityDescriptor) []EntityDescriptor {
+				return append(next, EntityDescriptor{Kind: "WifConfig", Plural: "wifconfigs"})
+			},
+			reason: `entity kind "WifConfig" cannot be added without a restart`,
+		},
+		{
+			name: "removed kind",
+			change: func(next []EntityDescriptor) []EntityDescriptor {
+				return next[:1]
+			},
+			reason: `entity kind "Version" cannot be removed without a restart`,
+		},
+		{
+			name: "changed plural",
+			change: func(next []EntityDescriptor) []EntityDescriptor {
+				next[0].Plural = "chans"
+				return next
+			},
+			reason: `entity kind "Channel": plural cannot change without a restart`,
+		},
+		{
+			name: "changed parent",
+			change: func(next []EntityDescriptor) []EntityDescriptor {
+				next[1].ParentKind = ""
+				return next
+			},
+			reason: `entity kind "Version": parent_kind cannot change without a restart`,
+		},
+		{
+			name: "changed spec schema",
+			change: func(next []EntityDescriptor) []EntityDescriptor {
+				next[0].SpecSchemaName = "OtherSpec"
+				return next
+			},
+			reason: `entity kind "Channel": spec schema cannot change without a restart`,
+		},
+		{
+			name: "changed immutable fields",
+			change: func(next []EntityDescriptor) []EntityDescriptor {
+				next[0].ImmutableFields = []string{"region"}
+				return next
+			},
+			reason: `entity kind "Channel": immutable_fields cannot change without a restart`,
+		},
+		{
+			name: "duplicate kind",
+			change: func(next []EntityDescriptor) []EntityDescriptor {
+				return append(next, next[0])
+			},
+			reason: `entity kind "Channel" is defined more than once`,
+		},
+		{
+			name: "invalid descriptor",
+			change: func(next []EntityDescriptor) []EntityDescriptor {
+				next[1].NameMinLen = 30
+				return next
+			},
+			reason: "NameMaxLen (20) must be >= NameMinLen (30)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			current := registerReplaceTestDescriptors()
+			next := tt.change(append([]EntityDescriptor{}, current...))
+
+			Expect(Replace(next)).To(MatchError(ContainSubstring(tt.reason)))
+			Expect(All()).To(ConsistOf(current), "a rejected replacement leaves the registry unchanged")
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/util"
+)
+
+// Status field constraints
+const (
+	// names appear in search queries as status.fields.<name>
+	MaxStatusFieldNameLength = 63
+	// bytes of the JSON-encoded value; larger values are skipped
+	MaxStatusFieldValueSize = 4096
+)
+
+// statusFieldNamePattern keeps status field names searchable: the search walker only
+// accepts lowercase letters, digits, and underscores in JSONB keys.
+var statusFieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// StatusFieldRule projects adapter-reported data into a named field of the resource
+// status. The expression sees the same statuses and resource variables as condition
+// mapping rules, and its result is presented under status.fields.<name>.
+type StatusFieldRule struct {
+	// field name, e.g. "api_url"
+	Name string `mapstructure:"name" json:"name" validate:"required"`
+	// CEL expression producing the value; null leaves the field unset
+	Expression string `mapstructure:"expression" json:"expression" validate:"required"`
+}
+
+// ValidateEntityStatusFields checks the names of the status field rules of a single
+// entity descriptor and compiles their expressions with the runtime cost limit.
+func ValidateEntityStatusFields(descriptor EntityDescriptor) error {
+	if len(descriptor.StatusFields) == 0 {
+		return nil
+	}
+
+	env, err := util.NewConditionMappingEnvironment()
+	if err != nil {
+		return fmt.Errorf("failed to create CEL environment for validation: %w", err)
+	}
+
+	seen := make(map[string]bool, len(descriptor.StatusFields))
+	for _, rule := range descriptor.StatusFields {
+		if !statusFieldNamePattern.MatchString(rule.Name) {
+			return fmt.Errorf(
+				"%s status field '%s' is invalid: must start with a lowercase letter "+
+					"and contain only lowercase letters, digits, and underscores",
+				descriptor.Kind, rule.Name,
+			)
+		}
+		if len(rule.Name) > MaxStatusFieldNameLength {
+			return fmt.Errorf(
+				"%s status field '%s' exceeds max length %d (got %d)",
+				descriptor.Kind, rule.Name, MaxStatusFieldNameLength, len(rule.Name),
+			)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf(
+				"%s status field '%s' is defined multiple times (each name must be unique)",
+				descriptor.Kind, rule.Name,
+			)
+		}
+		seen[rule.Name] = true
+
+		if err = validateCELExpression(descriptor.Kind, "status_fields", rule.Name, rule.Expression, env); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateEntityStatusFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  []StatusFieldRule
+		reason string
+	}{
+		{
+			name: "adapter data",
+			rules: []StatusFieldRule{{
+				Name:       "api_url",
+				Expression: `statuses.filter(s, s.adapter == "dns").map(s, s.data.api_url)[0]`,
+			}},
+		},
+		{name: "resource field", rules: []StatusFieldRule{{Name: "gen", Expression: "resource.generation"}}},
+		{
+			name:   "uppercase name",
+			rules:  []StatusFieldRule{{Name: "apiURL", Expression: "1"}},
+			reason: "must start with a lowercase letter",
+		},
+		{
+			name:   "empty name",
+			rules:  []StatusFieldRule{{Name: "", Expression: "1"}},
+			reason: "must start with a lowercase letter",
+		},
+		{
+			name:   "name too long",
+			rules:  []StatusFieldRule{{Name: strings.Repeat("a", MaxStatusFieldNameLength+1), Expression: "1"}},
+			reason: "exceeds max length",
+		},
+		{
+			name: "duplicate name",
+			rules: []StatusFieldRule{
+				{Name: "api_url", Expression: "1"},
+				{Name: "api_url", Expression: "2"},
+			},
+			reason: "defined multiple times",
+		},
+		{
+			name:   "syntax error",
+			rules:  []StatusFieldRule{{Name: "api_url", Expression: "statuses["}},
+			reason: "invalid CEL expression",
+		},
+		{
+			name:   "undeclared variable",
+			rules:  []StatusFieldRule{{Name: "api_url", Expression: "self.api_url"}},
+			reason: "CEL check failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := ValidateEntityStatusFields(EntityDescriptor{Kind: "Cluster", StatusFields: tt.rules})
+			if tt.reason == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tt.reason)))
+			g.Expect(err.Error()).To(HavePrefix("Cluster"))
+		})
+	}
+}
+
+func TestValidate_InvalidStatusFields_Panics(t *testing.T) {
+	RegisterTestingT(t)
+	Reset()
+
+	Register(EntityDescriptor{
+		Kind:         "Cluster",
+		Plural:       "clusters",
+		StatusFields: []StatusFieldRule{{Name: "api_url", Expression: "statuses["}},
+	})
+
+	Expect(func() {
+		Validate()
+	}).To(PanicWith(ContainSubstring(`entity "Cluster": invalid status_fields`)))
+}
@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/util"
+)
+
+// ValidationRule is a CEL rule checked on every create and update of an entity, modelled on
+// Kubernetes x-kubernetes-validations. The rule sees self (the spec being written), oldSelf
+// (the stored spec), labels, and parent (the parent object, null for top-level entities).
+// A rule that refers to oldSelf is a transition rule and is only checked on update.
+type ValidationRule struct {
+	// CEL expression that must evaluate to true, e.g. "self.replicas <= 100"
+	Rule string `mapstructure:"rule" json:"rule" validate:"required"`
+	// message returned when the rule fails; defaults to "failed rule: <rule>"
+	Message string `mapstructure:"message" json:"message,omitempty"`
+	// field reported with the failure; defaults to "spec"
+	FieldPath string `mapstructure:"field_path" json:"field_path,omitempty"`
+}
+
+// ValidateEntityValidations compiles the validation rules of a single entity descriptor
+// with the runtime cost limit, so a rule that cannot run fails startup instead of writes.
+func ValidateEntityValidations(descriptor EntityDescriptor) error {
+	if len(descriptor.Validations) == 0 {
+		return nil
+	}
+
+	env, err := util.NewSpecValidationEnvironment()
+	if err != nil {
+		return fmt.Errorf("failed to create CEL environment for validation: %w", err)
+	}
+
+	for i, rule := range descriptor.Validations {
+		if rule.Rule == "" {
+			return fmt.Errorf("%s validations[%d]: rule cannot be empty", descriptor.Kind, i)
+		}
+		ast, issues := env.Compile(rule.Rule)
+		if issues != nil && issues.Err() != nil {
+			return fmt.Errorf(
+				"%s validations[%d]: invalid CEL expression: %w\nExpression: %s",
+				descriptor.Kind, i, issues.Err(), rule.Rule,
+			)
+		}
+		output := ast.OutputType()
+		if !output.IsExactType(cel.BoolType) && !output.IsExactType(cel.DynType) {
+			return fmt.Errorf(
+				"%s validations[%d]: rule must evaluate to a bool, not %s\nExpression: %s",
+				descriptor.Kind, i, output, rule.Rule,
+			)
+		}
+		if _, err = env.Program(ast, cel.CostLimit(util.CELCostLimit)); err != nil {
+			return fmt.Errorf(
+				"%s validations[%d]: failed to compile CEL expression: %w\nExpression: %s",
+				descriptor.Kind, i, err, rule.Rule,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package registry
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateEntityValidations(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		reason string
+	}{
+		{name: "transition rule", rule: "self.replicas - oldSelf.replicas <= 10"},
+		{name: "labels and parent", rule: `labels["tier"] == parent.spec.tier`},
+		{name: "empty rule", rule: "", reason: "rule cannot be empty"},
+		{name: "syntax error", rule: "self.replicas <", reason: "invalid CEL expression"},
+		{name: "undeclared variable", rule: "statuses.size() > 0", reason: "invalid CEL expression"},
+		{name: "not a bool", rule: `"replicas"`, reason: "rule must evaluate to a bool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := ValidateEntityValidations(EntityDescriptor{
+				Kind:        "NodePool",
+				Validations: []ValidationRule{{Rule: tt.rule}},
+			})
+			if tt.reason == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tt.reason)))
+			g.Expect(err.Error()).To(HavePrefix("NodePool validations[0]"))
+		})
+	}
+}
+
+func TestValidate_InvalidValidations_Panics(t *testing.T) {
+	RegisterTestingT(t)
+	Reset()
+
+	Register(EntityDescriptor{
+		Kind:        "NodePool",
+		Plural:      "nodepools",
+		Validations: []ValidationRule{{Rule: "self.replicas <"}},
+	})
+
+	Expect(func() {
+		Validate()
+	}).To(PanicWith(ContainSubstring(`entity "NodePool": invalid validations`)))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	e "errors"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -25,15 +26,16 @@ type AdapterStatusService interface {
 	) (api.AdapterStatusList, *errors.ServiceError)
 	FindByResourcePaginated(
 		ctx context.Context, resourceType, resourceID string, listArgs *ListArguments,
-	) (api.AdapterStatusList, int64, *errors.ServiceError)
+	) (api.AdapterStatusList, *api.PagingMeta, *errors.ServiceError)
 	FindByResourceAndAdapter(
 		ctx context.Context, resourceType, resourceID, adapter string,
 	) (*api.AdapterStatus, *errors.ServiceError)
 }
 
-func NewAdapterStatusService(adapterStatusDao dao.AdapterStatusDao) AdapterStatusService {
+func NewAdapterStatusService(adapterStatusDao dao.AdapterStatusDao, generic GenericService) AdapterStatusService {
 	return &sqlAdapterStatusService{
 		adapterStatusDao: adapterStatusDao,
+		generic:          generic,
 	}
 }
 
@@ -41,6 +43,7 @@ var _ AdapterStatusService = &sqlAdapterStatusService{}
 
 type sqlAdapterStatusService struct {
 	adapterStatusDao dao.AdapterStatusDao
+	generic          GenericService
 }
 
 func (s *sqlAdapterStatusService) Get(ctx context.Context, id string) (*api.AdapterStatus, *errors.ServiceError) {
@@ -78,18 +81,31 @@ func (s *sqlAdapterStatusService) FindByResource(
 	return statuses, nil
 }
 
+// FindByResourcePaginated lists the statuses of a resource with the pagination, search,
+// and ordering of any other list, newest first by default.
 func (s *sqlAdapterStatusService) FindByResourcePaginated(
 	ctx context.Context, resourceType, resourceID string, listArgs *ListArguments,
-) (api.AdapterStatusList, int64, *errors.ServiceError) {
-	offset := int((listArgs.Page - 1) * listArgs.Size)
-	limit := int(listArgs.Size)
-
-	statuses, total, err := s.adapterStatusDao.FindByResourcePaginated(ctx, resourceType, resourceID, offset, limit)
-	if err != nil {
-		return nil, 0, errors.GeneralError("Unable to get adapter statuses: %s", err)
+) (api.AdapterStatusList, *api.PagingMeta, *errors.ServiceError) {
+	if listArgs == nil {
+		listArgs = NewListArguments()
+	}
+	scopedArgs := *listArgs
+	if len(scopedArgs.Order) == 0 {
+		scopedArgs.Order = []string{"created_time desc"}
+	}
+	resourceFilter := fmt.Sprintf("resource_type = '%s' AND resource_id = '%s'", resourceType, resourceID)
+	if scopedArgs.Search == "" {
+		scopedArgs.Search = resourceFilter
+	} else {
+		scopedArgs.Search = "(" + scopedArgs.Search + ") AND " + resourceFilter
 	}
 
-	return statuses, total, nil
+	var statuses api.AdapterStatusList
+	paging, svcErr := s.generic.List(ctx, &scopedArgs, &statuses)
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+	return statuses, paging, nil
 }
 
 func (s *sqlAdapterStatusService) FindByResourceAndAdapter(
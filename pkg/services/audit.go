@@ -0,0 +1,256 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// newAuditEvent starts an audit event for a mutation of resource by the caller.
+func newAuditEvent(ctx context.Context, action string, resource *api.Resource) *api.ResourceEvent {
+	tenancy := resource.Tenancy
+	if len(tenancy) == 0 {
+		tenancy = []byte("{}")
+	}
+	return &api.ResourceEvent{
+		ResourceID: resource.ID,
+		Kind:       resource.Kind,
+		OwnerID:    resource.OwnerID,
+		Action:     action,
+		Actor:      actorFromContext(ctx),
+		Tenancy:    tenancy,
+		Generation: resource.Generation,
+	}
+}
+
+// recordAudit appends event, with changes, to the resource audit log inside the
+// mutation's transaction.
+func (s *sqlResourceService) recordAudit(
+	ctx context.Context, event *api.ResourceEvent, changes []api.FieldChange,
+) *errors.ServiceError {
+	if changes == nil {
+		changes = []api.FieldChange{}
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		db.MarkForRollback(ctx, err)
+		return errors.GeneralError("Failed to marshal %s audit event: %s", event.Kind, err)
+	}
+	event.Changes = changesJSON
+	if err := s.resourceEventDao.Record(ctx, event); err != nil {
+		return errors.GeneralError("Failed to record %s audit event: %s", event.Kind, err)
+	}
+	return nil
+}
+
+// resourceSnapshot is the user-writable state of a resource that audit events diff.
+type resourceSnapshot struct {
+	spec       []byte
+	labels     []api.ResourceLabel
+	references []api.ResourceReference
+}
+
+func snapshotResource(resource *api.Resource) resourceSnapshot {
+	return resourceSnapshot{
+		spec:       append([]byte(nil), resource.Spec...),
+		labels:     resource.Labels,
+		references: resource.References,
+	}
+}
+
+// diffSnapshots lists the spec, label and reference fields that differ between before
+// and after, in that order. Spec objects are compared key by key; arrays and scalars are
+// reported whole.
+func diffSnapshots(before, after resourceSnapshot) ([]api.FieldChange, error) {
+	var changes []api.FieldChange
+
+	oldSpec, err := decodeJSONValue(before.spec)
+	if err != nil {
+		return nil, err
+	}
+	newSpec, err := decodeJSONValue(after.spec)
+	if err != nil {
+		return nil, err
+	}
+	// A missing spec diffs as an empty object, so a create lists the fields it sets.
+	if oldSpec == nil {
+		oldSpec = map[string]any{}
+	}
+	if newSpec == nil {
+		newSpec = map[string]any{}
+	}
+	if changes, err = diffJSONValues("spec", oldSpec, newSpec, changes); err != nil {
+		return nil, err
+	}
+
+	oldLabels := labelMap(before.labels)
+	newLabels := labelMap(after.labels)
+	for _, key := range sortedUnion(oldLabels, newLabels) {
+		if changes, err = appendChange(changes, "labels."+key, oldLabels, newLabels, key); err != nil {
+			return nil, err
+		}
+	}
+
+	oldRefs := referenceTargets(before.references)
+	newRefs := referenceTargets(after.references)
+	for _, refType := range sortedUnion(oldRefs, newRefs) {
+		if changes, err = appendChange(changes, "references."+refType, oldRefs, newRefs, refType); err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+// decodeJSONValue decodes a JSON document, keeping numbers exact. Empty input decodes to nil.
+func decodeJSONValue(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func diffJSONValues(path string, oldValue, newValue any, changes []api.FieldChange) ([]api.FieldChange, error) {
+	oldObj, oldIsObj := oldValue.(map[string]any)
+	newObj, newIsObj := newValue.(map[string]any)
+	if oldIsObj && newIsObj {
+		var err error
+		for _, key := range sortedUnion(oldObj, newObj) {
+			oldField, inOld := oldObj[key]
+			newField, inNew := newObj[key]
+			if inOld && inNew {
+				changes, err = diffJSONValues(path+"."+key, oldField, newField, changes)
+			} else {
+				changes, err = appendChange(changes, path+"."+key, oldObj, newObj, key)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return changes, nil
+	}
+	if reflect.DeepEqual(oldValue, newValue) {
+		return changes, nil
+	}
+	change := api.FieldChange{Path: path}
+	var err error
+	if oldValue != nil {
+		if change.Old, err = json.Marshal(oldValue); err != nil {
+			return nil, err
+		}
+	}
+	if newValue != nil {
+		if change.New, err = json.Marshal(newValue); err != nil {
+			return nil, err
+		}
+	}
+	return append(changes, change), nil
+}
+
+// appendChange records the change of key between oldMap and newMap, if any. A key
+// missing from either side leaves that side of the change empty.
+func appendChange[V any](
+	changes []api.FieldChange, path string, oldMap, newMap map[string]V, key string,
+) ([]api.FieldChange, error) {
+	oldValue, inOld := oldMap[key]
+	newValue, inNew := newMap[key]
+	if inOld && inNew && reflect.DeepEqual(oldValue, newValue) {
+		return changes, nil
+	}
+	change := api.FieldChange{Path: path}
+	var err error
+	if inOld {
+		if change.Old, err = json.Marshal(oldValue); err != nil {
+			return nil, err
+		}
+	}
+	if inNew {
+		if change.New, err = json.Marshal(newValue); err != nil {
+			return nil, err
+		}
+	}
+	return append(changes, change), nil
+}
+
+func labelMap(labels []api.ResourceLabel) map[string]string {
+	m := make(map[string]string, len(labels))
+	for _, l := range labels {
+		m[l.Key] = l.Value
+	}
+	return m
+}
+
+// referenceTargets maps each ref type to its sorted target IDs.
+func referenceTargets(refs []api.ResourceReference) map[string][]string {
+	m := make(map[string][]string)
+	for _, ref := range refs {
+		m[ref.RefType] = append(m[ref.RefType], ref.TargetID)
+	}
+	for _, ids := range m {
+		slices.Sort(ids)
+	}
+	return m
+}
+
+func sortedUnion[V any](a, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// adapterConditionTransitions lists the conditions of incoming whose status differs from
+// the adapter's previous report, as "conditions.<Type>" changes of the status value.
+func adapterConditionTransitions(existing, incoming *api.AdapterStatus) ([]api.FieldChange, error) {
+	oldStatuses, err := adapterConditionStatuses(existing)
+	if err != nil {
+		return nil, err
+	}
+	newStatuses, err := adapterConditionStatuses(incoming)
+	if err != nil {
+		return nil, err
+	}
+	var changes []api.FieldChange
+	for _, condType := range sortedUnion(oldStatuses, newStatuses) {
+		if _, reported := newStatuses[condType]; !reported {
+			// Conditions are replaced wholesale on every report; one left out is not a transition.
+			continue
+		}
+		if changes, err = appendChange(changes, "conditions."+condType, oldStatuses, newStatuses, condType); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func adapterConditionStatuses(status *api.AdapterStatus) (map[string]api.AdapterConditionStatus, error) {
+	m := make(map[string]api.AdapterConditionStatus)
+	if status == nil || len(status.Conditions) == 0 {
+		return m, nil
+	}
+	var conditions []api.AdapterCondition
+	if err := json.Unmarshal(status.Conditions, &conditions); err != nil {
+		return nil, err
+	}
+	for _, c := range conditions {
+		m[c.Type] = c.Status
+	}
+	return m, nil
+}
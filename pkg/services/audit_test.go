@@ -0,0 +1,106 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   resourceSnapshot
+		after    resourceSnapshot
+		expected string
+	}{
+		{
+			name:     "identical snapshots",
+			before:   resourceSnapshot{spec: []byte(`{"replicas":3}`)},
+			after:    resourceSnapshot{spec: []byte(`{"replicas":3}`)},
+			expected: `null`,
+		},
+		{
+			name:   "nested spec fields are diffed by path",
+			before: resourceSnapshot{spec: []byte(`{"nodes":{"replicas":3,"type":"n1"},"zones":["a"]}`)},
+			after:  resourceSnapshot{spec: []byte(`{"nodes":{"replicas":5},"zones":["a","b"],"region":"eu"}`)},
+			expected: `[
+				{"path":"spec.nodes.replicas","old":3,"new":5},
+				{"path":"spec.nodes.type","old":"n1"},
+				{"path":"spec.region","new":"eu"},
+				{"path":"spec.zones","old":["a"],"new":["a","b"]}
+			]`,
+		},
+		{
+			name:     "large numbers keep their precision",
+			before:   resourceSnapshot{spec: []byte(`{"id":9007199254740993}`)},
+			after:    resourceSnapshot{spec: []byte(`{"id":9007199254740995}`)},
+			expected: `[{"path":"spec.id","old":9007199254740993,"new":9007199254740995}]`,
+		},
+		{
+			name: "labels",
+			before: resourceSnapshot{labels: []api.ResourceLabel{
+				{Key: "env", Value: "dev"}, {Key: "team", Value: "core"},
+			}},
+			after: resourceSnapshot{labels: []api.ResourceLabel{
+				{Key: "env", Value: "prod"}, {Key: "tier", Value: "gold"},
+			}},
+			expected: `[
+				{"path":"labels.env","old":"dev","new":"prod"},
+				{"path":"labels.team","old":"core"},
+				{"path":"labels.tier","new":"gold"}
+			]`,
+		},
+		{
+			name: "references compare target sets",
+			before: resourceSnapshot{references: []api.ResourceReference{
+				{RefType: "wif_config", TargetID: "b"}, {RefType: "wif_config", TargetID: "a"},
+			}},
+			after: resourceSnapshot{references: []api.ResourceReference{
+				{RefType: "wif_config", TargetID: "a"}, {RefType: "wif_config", TargetID: "b"},
+				{RefType: "channel", TargetID: "c"},
+			}},
+			expected: `[{"path":"references.channel","new":["c"]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			changes, err := diffSnapshots(tt.before, tt.after)
+			Expect(err).NotTo(HaveOccurred())
+			actual, err := json.Marshal(changes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(MatchJSON(tt.expected))
+		})
+	}
+}
+
+func TestAdapterConditionTransitions(t *testing.T) {
+	RegisterTestingT(t)
+
+	existing := &api.AdapterStatus{Conditions: testConditionsJSON(
+		api.AdapterCondition{Type: api.AdapterConditionTypeAvailable, Status: api.AdapterConditionFalse},
+		api.AdapterCondition{Type: "Degraded", Status: api.AdapterConditionTrue},
+	)}
+	incoming := &api.AdapterStatus{Conditions: testConditionsJSON(
+		api.AdapterCondition{Type: api.AdapterConditionTypeAvailable, Status: api.AdapterConditionTrue},
+		api.AdapterCondition{Type: api.AdapterConditionTypeHealth, Status: api.AdapterConditionTrue},
+	)}
+
+	changes, err := adapterConditionTransitions(existing, incoming)
+	Expect(err).NotTo(HaveOccurred())
+	actual, err := json.Marshal(changes)
+	Expect(err).NotTo(HaveOccurred())
+	// Degraded was left out of the new report, which is not a transition.
+	Expect(actual).To(MatchJSON(`[
+		{"path":"conditions.Available","old":"False","new":"True"},
+		{"path":"conditions.Health","new":"True"}
+	]`))
+
+	changes, err = adapterConditionTransitions(incoming, incoming)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(changes).To(BeEmpty())
+}
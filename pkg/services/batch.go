@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/openapi"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/util"
+)
+
+// Operations of a batch.
+const (
+	BatchCreate = "create"
+	BatchPatch  = "patch"
+	BatchDelete = "delete"
+)
+
+// MaxBatchOperations bounds the number of operations in one batch, and so the time its
+// transaction holds row locks.
+const MaxBatchOperations = 100
+
+// batchRefPrefix marks a temporary ID: "$name" stands for the ID of the resource created
+// earlier in the same batch by the create operation whose Ref is "name".
+const batchRefPrefix = "$"
+
+// BatchOperation is one step of a batch. Create uses Resource, References, and ParentID;
+// Patch uses ID and Patch; Delete uses ID. ParentID, ID, and the IDs of References may be
+// temporary IDs.
+type BatchOperation struct {
+	Resource   *api.Resource
+	Patch      *api.ResourcePatch
+	References api.ReferenceMap
+	Op         string
+	Kind       string
+	Ref        string
+	ID         string
+	ParentID   string
+	IfMatch    []string
+}
+
+// BatchResult is the outcome of one operation. Err is set on the operation that failed,
+// which is always the last result.
+type BatchResult struct {
+	Resource *api.Resource
+	Err      *errors.ServiceError
+	Op       string
+	Ref      string
+}
+
+// Batch applies ops in order within the caller's transaction. Every operation goes through
+// the same Create, Patch, and Delete as a single request. The batch is all-or-nothing: the
+// first failing operation marks the transaction for rollback and ends the batch, and the
+// returned error is that operation's.
+func (s *sqlResourceService) Batch(
+	ctx context.Context, ops []BatchOperation,
+) ([]BatchResult, *errors.ServiceError) {
+	if svcErr := validateBatch(ops); svcErr != nil {
+		return nil, svcErr
+	}
+
+	created := make(map[string]string)
+	results := make([]BatchResult, 0, len(ops))
+	for i := range ops {
+		op := &ops[i]
+		resource, svcErr := s.applyBatchOperation(ctx, op, created)
+		results = append(results, BatchResult{Op: op.Op, Ref: op.Ref, Resource: resource, Err: svcErr})
+		if svcErr != nil {
+			db.MarkForRollback(ctx, svcErr)
+			return results, svcErr
+		}
+		if op.Op == BatchCreate && op.Ref != "" {
+			created[op.Ref] = resource.ID
+		}
+	}
+	return results, nil
+}
+
+// validateBatch checks the shape of a batch before any of it runs, so that a malformed
+// operation late in the batch does not cost the work of the ones before it.
+func validateBatch(ops []BatchOperation) *errors.ServiceError {
+	if len(ops) == 0 {
+		return errors.Validation("operations must not be empty")
+	}
+	if len(ops) > MaxBatchOperations {
+		return errors.Validation("operations must contain at most %d items", MaxBatchOperations)
+	}
+
+	defined := make(map[string]bool)
+	known := func(i int, field, id string) *errors.ServiceError {
+		if ref, ok := strings.CutPrefix(id, batchRefPrefix); ok && !defined[ref] {
+			return errors.Validation("operations[%d].%s refers to %q, which no earlier create defines", i, field, id)
+		}
+		return nil
+	}
+	knownReferences := func(i int, refs api.ReferenceMap) *errors.ServiceError {
+		for refType, targets := range refs {
+			for _, target := range targets {
+				if svcErr := known(i, "references."+refType, util.FromPtr(target.Id)); svcErr != nil {
+					return svcErr
+				}
+			}
+		}
+		return nil
+	}
+
+	for i, op := range ops {
+		if svcErr := validateKind(op.Kind); svcErr != nil {
+			return errors.Validation("operations[%d]: %s", i, svcErr.Reason)
+		}
+		switch op.Op {
+		case BatchCreate:
+			if op.Resource == nil {
+				return errors.Validation("operations[%d].resource is required", i)
+			}
+			if svcErr := known(i, "parent_id", op.ParentID); svcErr != nil {
+				return svcErr
+			}
+			if svcErr := knownReferences(i, op.References); svcErr != nil {
+				return svcErr
+			}
+			if op.Ref != "" {
+				if defined[op.Ref] {
+					return errors.Validation("operations[%d].ref %q is already defined", i, op.Ref)
+				}
+				defined[op.Ref] = true
+			}
+		case BatchPatch, BatchDelete:
+			if op.ID == "" {
+				return errors.Validation("operations[%d].id is required", i)
+			}
+			if op.Ref != "" {
+				return errors.Validation("operations[%d].ref is only allowed on create", i)
+			}
+			if svcErr := known(i, "id", op.ID); svcErr != nil {
+				return svcErr
+			}
+			if op.Op == BatchPatch {
+				if op.Patch == nil {
+					return errors.Validation("operations[%d].patch is required", i)
+				}
+				if svcErr := knownReferences(i, op.Patch.References); svcErr != nil {
+					return svcErr
+				}
+			}
+		default:
+			return errors.Validation("operations[%d].op must be one of create, patch, delete", i)
+		}
+	}
+	return nil
+}
+
+func (s *sqlResourceService) applyBatchOperation(
+	ctx context.Context, op *BatchOperation, created map[string]string,
+) (*api.Resource, *errors.ServiceError) {
+	switch op.Op {
+	case BatchCreate:
+		descriptor := registry.MustGet(op.Kind)
+		parentID := resolveBatchID(op.ParentID, created)
+		switch {
+		case descriptor.ParentKind != "" && parentID == "":
+			return nil, errors.Validation("parent_id is required to create a %s", op.Kind)
+		case descriptor.ParentKind == "" && parentID != "":
+			return nil, errors.Validation("%s is a top-level kind and takes no parent_id", op.Kind)
+		case parentID != "":
+			parent, svcErr := s.Get(ctx, descriptor.ParentKind, parentID)
+			if svcErr != nil {
+				return nil, svcErr
+			}
+			op.Resource.SetOwner(parent.ID, parent.Kind, parent.Href)
+		}
+		return s.Create(ctx, op.Kind, op.Resource, resolveBatchReferences(op.References, created))
+	case BatchPatch:
+		patch := *op.Patch
+		patch.References = resolveBatchReferences(patch.References, created)
+		return s.Patch(WithIfMatch(ctx, op.IfMatch), op.Kind, resolveBatchID(op.ID, created), &patch)
+	default:
+		return s.Delete(WithIfMatch(ctx, op.IfMatch), op.Kind, resolveBatchID(op.ID, created))
+	}
+}
+
+// resolveBatchID replaces a temporary ID with the ID of the resource created for it.
+// validateBatch has already checked that every temporary ID is defined.
+func resolveBatchID(id string, created map[string]string) string {
+	if ref, ok := strings.CutPrefix(id, batchRefPrefix); ok {
+		return created[ref]
+	}
+	return id
+}
+
+// resolveBatchReferences returns refs with temporary target IDs resolved. A nil map stays
+// nil, since it means "no references supplied" rather than "clear them".
+func resolveBatchReferences(refs api.ReferenceMap, created map[string]string) api.ReferenceMap {
+	if refs == nil {
+		return nil
+	}
+	resolved := make(api.ReferenceMap, len(refs))
+	for refType, targets := range refs {
+		resolvedTargets := make([]openapi.ObjectReference, 0, len(targets))
+		for _, target := range targets {
+			if target.Id != nil {
+				target.Id = util.PtrString(resolveBatchID(*target.Id, created))
+			}
+			resolvedTargets = append(resolvedTargets, target)
+		}
+		resolved[refType] = resolvedTargets
+	}
+	return resolved
+}
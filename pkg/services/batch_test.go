@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+)
+
+func TestResourceService_Batch_ResolvesTemporaryIDs(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+
+	results, svcErr := svc.Batch(context.Background(), []BatchOperation{
+		{Op: BatchCreate, Kind: "Channel", Ref: "ch", Resource: testResource("Channel", "ch-1", "stable")},
+		{Op: BatchCreate, Kind: "Version", ParentID: "$ch", Resource: testResource("Version", "v-1", "4.18")},
+		{Op: BatchPatch, Kind: "Channel", ID: "$ch", Patch: &api.ResourcePatch{Labels: map[string]string{"env": "prod"}}},
+	})
+	Expect(svcErr).To(BeNil())
+	Expect(results).To(HaveLen(3))
+	Expect(results[0].Ref).To(Equal("ch"))
+	Expect(*results[1].Resource.OwnerID).To(Equal("ch-1"))
+	Expect(results[2].Resource.Generation).To(Equal(int32(2)))
+}
+
+func TestResourceService_Batch_StopsAtFirstFailure(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+
+	results, svcErr := svc.Batch(context.Background(), []BatchOperation{
+		{Op: BatchCreate, Kind: "Channel", Resource: testResource("Channel", "ch-1", "stable")},
+		{Op: BatchDelete, Kind: "Channel", ID: "missing"},
+		{Op: BatchCreate, Kind: "Channel", Resource: testResource("Channel", "ch-2", "fast")},
+	})
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(404))
+	Expect(results).To(HaveLen(2))
+	Expect(results[0].Err).To(BeNil())
+	Expect(results[1].Err).To(Equal(svcErr))
+	Expect(mockDao.resources).ToNot(HaveKey(resourceKey("Channel", "ch-2")))
+}
+
+func TestResourceService_Batch_RequiresParentForChildKind(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+
+	results, svcErr := svc.Batch(context.Background(), []BatchOperation{
+		{Op: BatchCreate, Kind: "Version", Resource: testResource("Version", "v-1", "4.18")},
+	})
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(400))
+	Expect(results).To(HaveLen(1))
+}
+
+func TestValidateBatch(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	channel := testResource("Channel", "ch-1", "stable")
+	tooMany := make([]BatchOperation, MaxBatchOperations+1)
+	for i := range tooMany {
+		tooMany[i] = BatchOperation{Op: BatchDelete, Kind: "Channel", ID: "ch-1"}
+	}
+
+	tests := []struct {
+		name   string
+		reason string
+		ops    []BatchOperation
+	}{
+		{
+			name:   "empty",
+			ops:    nil,
+			reason: "operations must not be empty",
+		},
+		{
+			name:   "too many",
+			ops:    tooMany,
+			reason: "operations must contain at most 100 items",
+		},
+		{
+			name:   "unknown op",
+			ops:    []BatchOperation{{Op: "replace", Kind: "Channel", ID: "ch-1"}},
+			reason: "operations[0].op must be one of create, patch, delete",
+		},
+		{
+			name:   "unknown kind",
+			ops:    []BatchOperation{{Op: BatchDelete, Kind: "Nope", ID: "ch-1"}},
+			reason: "operations[0]: Unknown entity kind: Nope",
+		},
+		{
+			name: "undefined temporary ID",
+			ops: []BatchOperation{
+				{Op: BatchDelete, Kind: "Channel", ID: "$ch"},
+				{Op: BatchCreate, Kind: "Channel", Ref: "ch", Resource: channel},
+			},
+			reason: `operations[0].id refers to "$ch", which no earlier create defines`,
+		},
+		{
+			name: "duplicate ref",
+			ops: []BatchOperation{
+				{Op: BatchCreate, Kind: "Channel", Ref: "ch", Resource: channel},
+				{Op: BatchCreate, Kind: "Channel", Ref: "ch", Resource: channel},
+			},
+			reason: `operations[1].ref "ch" is already defined`,
+		},
+		{
+			name:   "ref on patch",
+			ops:    []BatchOperation{{Op: BatchPatch, Kind: "Channel", ID: "ch-1", Ref: "ch", Patch: &api.ResourcePatch{}}},
+			reason: "operations[0].ref is only allowed on create",
+		},
+		{
+			name:   "patch without patch",
+			ops:    []BatchOperation{{Op: BatchPatch, Kind: "Channel", ID: "ch-1"}},
+			reason: "operations[0].patch is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			svcErr := validateBatch(tt.ops)
+			Expect(svcErr).ToNot(BeNil())
+			Expect(svcErr.HTTPCode).To(Equal(400))
+			Expect(svcErr.Reason).To(Equal(tt.reason))
+		})
+	}
+}
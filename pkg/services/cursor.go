@@ -0,0 +1,151 @@
+package services
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// listCursor is the decoded form of a "continue" token: the order a list was read in,
+// and the values of its order keys (see db.OrderToSQL) on the last row of the page.
+// NULL values are nil.
+type listCursor struct {
+	Order  []string  `json:"o"`
+	Values []*string `json:"v"`
+}
+
+func encodeCursor(cursor listCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor parses a continue token and checks that it was issued for order, which
+// resolves to keys order keys.
+func decodeCursor(token string, order []string, keys int) (*listCursor, *errors.ServiceError) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.BadRequest("invalid continue token")
+	}
+	var cursor listCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || len(cursor.Values) != keys {
+		return nil, errors.BadRequest("invalid continue token")
+	}
+	if !slices.Equal(cursor.Order, order) {
+		return nil, errors.BadRequest("continue token was issued for a different order")
+	}
+	return &cursor, nil
+}
+
+// withPrimaryKeys appends the primary key columns missing from a cleaned order list, so
+// that every row has a distinct position. They sort in the direction of the last key.
+func withPrimaryKeys(order []string, primaryKeys []string) []string {
+	direction := "asc"
+	columns := make(map[string]bool, len(order))
+	for _, o := range order {
+		fields := strings.Fields(o)
+		columns[fields[0]] = true
+		direction = fields[1]
+	}
+	result := slices.Clone(order)
+	for _, pk := range primaryKeys {
+		if !columns[pk] {
+			result = append(result, pk+" "+direction)
+		}
+	}
+	return result
+}
+
+// keysetWhere restricts a list to the rows after the cursor in the order of keys. Each
+// key contributes one alternative: all earlier keys equal and this key past the cursor's
+// value. A NULL is past every value where NULLs sort last, and every value is past a
+// NULL where they sort first.
+func keysetWhere(keys []db.OrderKey, cursor *listCursor) dao.Where {
+	var alternatives []string
+	var values []any
+	var equal []string
+	var equalValues []any
+	for i, key := range keys {
+		expr := key.SQL
+		value := cursor.Values[i]
+
+		var past string
+		var pastValues []any
+		switch {
+		case value != nil && key.NullsSortLast():
+			op := ">"
+			if key.Desc {
+				op = "<"
+			}
+			past = fmt.Sprintf("(%s %s ? OR %s IS NULL)", expr, op, expr)
+			pastValues = append(append(append(pastValues, key.Values...), *value), key.Values...)
+		case value != nil:
+			past = expr + " < ?"
+			pastValues = append(append(pastValues, key.Values...), *value)
+		case !key.NullsSortLast():
+			past = expr + " IS NOT NULL"
+			pastValues = key.Values
+		}
+		if past != "" {
+			alternatives = append(alternatives, strings.Join(append(slices.Clone(equal), past), " AND "))
+			values = append(append(values, equalValues...), pastValues...)
+		}
+
+		equalValues = append(equalValues, key.Values...)
+		if value == nil {
+			equal = append(equal, expr+" IS NULL")
+		} else {
+			equal = append(equal, expr+" = ?")
+			equalValues = append(equalValues, *value)
+		}
+	}
+	if len(alternatives) == 0 {
+		return dao.NewWhere("1 = 0", nil)
+	}
+	return dao.NewWhere("("+strings.Join(alternatives, ") OR (")+")", values)
+}
+
+// cursorValue renders a column value for a continue token, or nil for NULL. Times keep
+// their full precision so that the row's own value compares equal when the list resumes.
+func cursorValue(value interface{}) *string {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	value = rv.Interface()
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil || v == nil {
+			return nil
+		}
+		value = v
+	}
+
+	var s string
+	switch v := value.(type) {
+	case time.Time:
+		s = v.UTC().Format(time.RFC3339Nano)
+	case []byte:
+		s = string(v)
+	default:
+		s = fmt.Sprint(v)
+	}
+	return &s
+}
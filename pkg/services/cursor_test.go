@@ -0,0 +1,150 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	RegisterTestingT(t)
+
+	order := []string{"created_time desc", "id desc"}
+	token, err := encodeCursor(listCursor{Order: order, Values: []*string{strPtr("2026-01-02T03:04:05.123456Z"), nil}})
+	Expect(err).ToNot(HaveOccurred())
+
+	cursor, svcErr := decodeCursor(token, order, 2)
+	Expect(svcErr).To(BeNil())
+	Expect(*cursor.Values[0]).To(Equal("2026-01-02T03:04:05.123456Z"))
+	Expect(cursor.Values[1]).To(BeNil())
+
+	_, svcErr = decodeCursor(token, []string{"name asc", "id asc"}, 2)
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.Type).To(Equal(errors.ErrorTypeBadRequest))
+
+	_, svcErr = decodeCursor(token, order, 3)
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.Type).To(Equal(errors.ErrorTypeBadRequest))
+
+	_, svcErr = decodeCursor("not a token!", order, 2)
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.Type).To(Equal(errors.ErrorTypeBadRequest))
+}
+
+func TestWithPrimaryKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(withPrimaryKeys(nil, []string{"id"})).To(Equal([]string{"id asc"}))
+	Expect(withPrimaryKeys([]string{"created_time desc"}, []string{"id"})).
+		To(Equal([]string{"created_time desc", "id desc"}))
+	Expect(withPrimaryKeys([]string{"id asc", "name desc"}, []string{"id"})).
+		To(Equal([]string{"id asc", "name desc"}))
+	Expect(withPrimaryKeys([]string{"generation desc"}, []string{"resource_id", "generation"})).
+		To(Equal([]string{"generation desc", "resource_id desc"}))
+}
+
+func TestKeysetWhere(t *testing.T) {
+	RegisterTestingT(t)
+
+	label := "(SELECT value FROM resource_labels WHERE resource_labels.resource_id = resources.id " +
+		"AND resource_labels.key = ?)"
+
+	tests := []struct {
+		name   string
+		cursor listCursor
+		where  dao.Where
+	}{
+		{
+			name:   "descending",
+			cursor: listCursor{Order: []string{"created_time desc", "id desc"}, Values: []*string{strPtr("t1"), strPtr("a")}},
+			where: dao.NewWhere(
+				"(resources.created_time < ?) OR (resources.created_time = ? AND resources.id < ?)",
+				[]any{"t1", "t1", "a"},
+			),
+		},
+		{
+			name:   "ascending includes NULLs last",
+			cursor: listCursor{Order: []string{"name asc", "id asc"}, Values: []*string{strPtr("n"), strPtr("a")}},
+			where: dao.NewWhere(
+				"((resources.name > ? OR resources.name IS NULL)) OR (resources.name = ? AND resources.id > ?)",
+				[]any{"n", "n", "a"},
+			),
+		},
+		{
+			name:   "ascending from NULL only moves on ties",
+			cursor: listCursor{Order: []string{"name asc", "id asc"}, Values: []*string{nil, strPtr("a")}},
+			where:  dao.NewWhere("(resources.name IS NULL AND resources.id > ?)", []any{"a"}),
+		},
+		{
+			name:   "descending from NULL moves to values",
+			cursor: listCursor{Order: []string{"name desc", "id desc"}, Values: []*string{nil, strPtr("a")}},
+			where: dao.NewWhere(
+				"(resources.name IS NOT NULL) OR (resources.name IS NULL AND resources.id < ?)",
+				[]any{"a"},
+			),
+		},
+		{
+			name:   "label descending includes NULLs last",
+			cursor: listCursor{Order: []string{"labels.env desc", "id desc"}, Values: []*string{strPtr("prod"), strPtr("a")}},
+			where: dao.NewWhere(
+				"(("+label+" < ? OR "+label+" IS NULL)) OR ("+label+" = ? AND resources.id < ?)",
+				[]any{"env", "prod", "env", "env", "prod", "a"},
+			),
+		},
+		{
+			name:   "label descending from NULL only moves on ties",
+			cursor: listCursor{Order: []string{"labels.env desc", "id desc"}, Values: []*string{nil, strPtr("a")}},
+			where:  dao.NewWhere("("+label+" IS NULL AND resources.id < ?)", []any{"env", "a"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			keys, svcErr := db.OrderToSQL(tt.cursor.Order, "resources")
+			Expect(svcErr).To(BeNil())
+			Expect(keysetWhere(keys, &tt.cursor)).To(Equal(tt.where))
+		})
+	}
+}
+
+func TestCursorValue(t *testing.T) {
+	RegisterTestingT(t)
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.FixedZone("CET", 3600))
+	Expect(*cursorValue(created)).To(Equal("2026-01-02T02:04:05.123456Z"))
+	Expect(*cursorValue(&created)).To(Equal("2026-01-02T02:04:05.123456Z"))
+	Expect(cursorValue((*time.Time)(nil))).To(BeNil())
+	Expect(cursorValue(nil)).To(BeNil())
+	Expect(*cursorValue(int32(3))).To(Equal("3"))
+	Expect(*cursorValue("ch-1")).To(Equal("ch-1"))
+	Expect(*cursorValue(datatypes.JSON(`{"a":1}`))).To(Equal(`{"a":1}`))
+}
+
+// columnlessDao is a dao whose model lacks every column.
+type columnlessDao struct {
+	dao.GenericDao
+}
+
+func (columnlessDao) GetColumnValue(_ interface{}, _ string) (interface{}, bool) {
+	return nil, false
+}
+
+func TestContinueToken_UnreadableColumn(t *testing.T) {
+	RegisterTestingT(t)
+
+	listCtx := &listContext{
+		order:     []string{"name asc", "id asc"},
+		orderKeys: []db.OrderKey{{SQL: "resources.name", Column: "name"}, {SQL: "resources.id", Column: "id"}},
+	}
+	token, svcErr := (&sqlGenericService{}).continueToken(listCtx, columnlessDao{}, &struct{}{})
+	Expect(token).To(BeEmpty())
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.Type).To(Equal(errors.ErrorTypeInternal))
+}
@@ -22,6 +22,9 @@ type GenericService interface {
 	List(
 		ctx context.Context, args *ListArguments, resourceList interface{},
 	) (*api.PagingMeta, *errors.ServiceError)
+	Aggregate(
+		ctx context.Context, args *ListArguments, resourceList interface{}, groupBy []string, limit int,
+	) ([]api.GroupCount, *errors.ServiceError)
 }
 
 func NewGenericService(genericDao dao.GenericDao) GenericService {
@@ -34,6 +37,10 @@ type sqlGenericService struct {
 	genericDao dao.GenericDao
 }
 
+// labeledResourceType names the list model whose rows carry labels, the only one a
+// labelSelector applies to.
+var labeledResourceType = reflect.TypeOf(api.Resource{}).Name()
+
 // wrap all needed pieces for the LIST function
 type listContext struct {
 	ctx          context.Context
@@ -44,6 +51,12 @@ type listContext struct {
 	set          map[string]bool
 	resourceType string
 	groupBy      []string
+	// order is the cleaned ORDER BY list, ending in the primary key
+	order []string
+	// orderKeys are the SQL keys order resolves to
+	orderKeys []db.OrderKey
+	// tenancyColumn is set for api.TenantScoped models
+	tenancyColumn string
 }
 
 func (s *sqlGenericService) newListContext(
@@ -57,13 +70,19 @@ func (s *sqlGenericService) newListContext(
 	if resourceTypeStr == "" {
 		return nil, nil, errors.GeneralError("Could not determine resource type")
 	}
+	model := reflect.New(resourceModel).Interface()
+	var tenancyColumn string
+	if scoped, ok := model.(api.TenantScoped); ok {
+		tenancyColumn = scoped.TenancyColumn()
+	}
 	return &listContext{
-		ctx:          ctx,
-		args:         args,
-		pagingMeta:   &api.PagingMeta{Page: args.Page},
-		resourceList: resourceList,
-		resourceType: resourceTypeStr,
-	}, reflect.New(resourceModel).Interface(), nil
+		ctx:           ctx,
+		args:          args,
+		pagingMeta:    &api.PagingMeta{Page: args.Page},
+		resourceList:  resourceList,
+		resourceType:  resourceTypeStr,
+		tenancyColumn: tenancyColumn,
+	}, model, nil
 }
 
 // List resourceList must be a pointer to a slice of database resource objects
@@ -83,6 +102,18 @@ func (s *sqlGenericService) List(
 		// add "ORDER BY"
 		s.buildOrderBy,
 
+		// restrict tenant-scoped models to the caller's tenancy
+		s.buildTenancy,
+
+		// add the service's own conditions as "WHERE"(s)
+		s.buildFilters,
+
+		// resume after the row named by a "continue" token
+		s.buildContinue,
+
+		// translate "labelSelector" into "WHERE"(s) on resource labels.
+		s.buildLabelSelector,
+
 		// translate "search" into "WHERE"(s), and "JOIN"(s) if related resource is searched.
 		s.buildSearch,
 
@@ -108,6 +139,60 @@ func (s *sqlGenericService) List(
 	return listCtx.pagingMeta, nil
 }
 
+// Aggregate counts the rows of resourceList's model that List would return for args,
+// ignoring pagination and ordering, per distinct value of the groupBy fields (see
+// db.GroupByToSQL). It returns at most limit groups, largest first.
+func (s *sqlGenericService) Aggregate(
+	ctx context.Context, args *ListArguments, resourceList interface{}, groupBy []string, limit int,
+) ([]api.GroupCount, *errors.ServiceError) {
+	listCtx, model, err := s.newListContext(ctx, args, resourceList)
+	if err != nil {
+		return nil, err
+	}
+
+	// the list builders that filter rows; pages and ordering do not apply to counts
+	builders := []listBuilder{
+		s.buildPreload,
+		s.buildTenancy,
+		s.buildFilters,
+		s.buildLabelSelector,
+		s.buildSearch,
+	}
+
+	d := s.genericDao.GetInstanceDao(ctx, model)
+	for _, builderFn := range builders {
+		if _, err = builderFn(listCtx, d); err != nil {
+			return nil, err
+		}
+	}
+	// joined searches group the rows by their ID, which would count each row on its own
+	if len(listCtx.groupBy) > 0 {
+		return nil, errors.BadRequest("search on related resources is not supported when aggregating")
+	}
+
+	groups := make([]dao.Where, len(groupBy))
+	for i, field := range groupBy {
+		sql, values, serviceErr := db.GroupByToSQL(field, d.GetTableName())
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+		groups[i] = dao.NewWhere(sql, values)
+	}
+
+	counts, countErr := d.CountGroups(groups, limit)
+	if countErr != nil {
+		switch {
+		case db.IsDBConnectionError(countErr):
+			return nil, errors.ServiceUnavailable("Database connection unavailable")
+		case db.IsInvalidColumnError(countErr):
+			return nil, errors.BadRequest("invalid field in search or groupBy query")
+		default:
+			return nil, errors.GeneralError("Unable to aggregate resources: %s", countErr)
+		}
+	}
+	return counts, nil
+}
+
 /*** Define all sub functions in the type of listBuilder ***/
 type listBuilder func(*listContext, dao.GenericDao) (finished bool, err *errors.ServiceError)
 
@@ -125,15 +210,69 @@ func (s *sqlGenericService) buildPreload(listCtx *listContext, d dao.GenericDao)
 }
 
 func (s *sqlGenericService) buildOrderBy(listCtx *listContext, d dao.GenericDao) (bool, *errors.ServiceError) {
-	if len(listCtx.args.Order) != 0 {
-		cleanedOrderList, serviceErr := db.ArgsToOrder(listCtx.args.Order)
-		if serviceErr != nil {
-			return false, serviceErr
-		}
-		for _, orderArg := range cleanedOrderList {
-			d.OrderBy(orderArg)
+	cleanedOrderList, serviceErr := db.ArgsToOrder(listCtx.args.Order)
+	if serviceErr != nil {
+		return false, serviceErr
+	}
+	for _, orderArg := range cleanedOrderList {
+		if db.IsExpressionOrder(orderArg) && listCtx.resourceType != labeledResourceType {
+			return false, errors.BadRequest("ordering by %s is only supported when listing resources",
+				strings.Fields(orderArg)[0])
 		}
 	}
+	// the primary key breaks ties, so that pages neither repeat nor skip rows
+	listCtx.order = withPrimaryKeys(cleanedOrderList, d.GetPrimaryKeys())
+	listCtx.orderKeys, serviceErr = db.OrderToSQL(listCtx.order, d.GetTableName())
+	if serviceErr != nil {
+		return false, serviceErr
+	}
+	for _, key := range listCtx.orderKeys {
+		d.OrderBy(key.Clause(), key.Values...)
+	}
+	return false, nil
+}
+
+func (s *sqlGenericService) buildTenancy(listCtx *listContext, d dao.GenericDao) (bool, *errors.ServiceError) {
+	if listCtx.tenancyColumn == "" {
+		return false, nil
+	}
+	if where, ok := dao.TenancyWhere(listCtx.ctx, d.GetTableName()+"."+listCtx.tenancyColumn); ok {
+		d.Where(where)
+	}
+	return false, nil
+}
+
+func (s *sqlGenericService) buildFilters(listCtx *listContext, d dao.GenericDao) (bool, *errors.ServiceError) {
+	for _, filter := range listCtx.args.Filters {
+		d.Where(filter)
+	}
+	return false, nil
+}
+
+func (s *sqlGenericService) buildContinue(listCtx *listContext, d dao.GenericDao) (bool, *errors.ServiceError) {
+	if listCtx.args.Continue == "" {
+		return false, nil
+	}
+	cursor, serviceErr := decodeCursor(listCtx.args.Continue, listCtx.order, len(listCtx.orderKeys))
+	if serviceErr != nil {
+		return false, serviceErr
+	}
+	d.Where(keysetWhere(listCtx.orderKeys, cursor))
+	return false, nil
+}
+
+func (s *sqlGenericService) buildLabelSelector(listCtx *listContext, d dao.GenericDao) (bool, *errors.ServiceError) {
+	if listCtx.args.LabelSelector == "" {
+		return false, nil
+	}
+	if listCtx.resourceType != labeledResourceType {
+		return false, errors.BadRequest("labelSelector is not supported when listing %s", listCtx.resourceType)
+	}
+	sql, values, serviceErr := db.LabelSelectorToSQL(listCtx.args.LabelSelector, d.GetTableName())
+	if serviceErr != nil {
+		return false, serviceErr
+	}
+	d.Where(dao.NewWhere(sql, values))
 	return false, nil
 }
 
@@ -213,14 +352,17 @@ func (s *sqlGenericService) addJoins(listCtx *listContext, d dao.GenericDao) {
 func (s *sqlGenericService) loadList(listCtx *listContext, d dao.GenericDao) *errors.ServiceError {
 	args := listCtx.args
 
-	if countErr := d.Count(listCtx.resourceList, &listCtx.pagingMeta.Total); countErr != nil {
-		switch {
-		case db.IsDBConnectionError(countErr):
-			return errors.ServiceUnavailable("Database connection unavailable")
-		case db.IsInvalidColumnError(countErr):
-			return errors.BadRequest("invalid field in search or order query")
-		default:
-			return errors.GeneralError("Unable to list resources: %s", countErr)
+	listCtx.pagingMeta.Uncounted = args.SkipCount
+	if !args.SkipCount {
+		if countErr := d.Count(listCtx.resourceList, &listCtx.pagingMeta.Total); countErr != nil {
+			switch {
+			case db.IsDBConnectionError(countErr):
+				return errors.ServiceUnavailable("Database connection unavailable")
+			case db.IsInvalidColumnError(countErr):
+				return errors.BadRequest("invalid field in search or order query")
+			default:
+				return errors.GeneralError("Unable to list resources: %s", countErr)
+			}
 		}
 	}
 
@@ -234,7 +376,13 @@ func (s *sqlGenericService) loadList(listCtx *listContext, d dao.GenericDao) *er
 		return nil
 	}
 
-	if err := d.Fetch(int((args.Page-1)*args.Size), int(args.Size), listCtx.resourceList); err != nil {
+	// a continue token replaces the offset; it already points past the previous pages
+	offset := int((args.Page - 1) * args.Size)
+	if args.Continue != "" {
+		offset = 0
+	}
+	// fetch one extra row to learn whether another page follows without counting
+	if err := d.Fetch(offset, int(args.Size)+1, listCtx.resourceList); err != nil {
 		switch {
 		case e.Is(err, gorm.ErrRecordNotFound):
 			listCtx.pagingMeta.Size = 0
@@ -246,11 +394,61 @@ func (s *sqlGenericService) loadList(listCtx *listContext, d dao.GenericDao) *er
 			return errors.GeneralError("Unable to list resources: %s", err)
 		}
 	}
-	listCtx.pagingMeta.Size = int64(reflect.ValueOf(listCtx.resourceList).Elem().Len())
+	items := reflect.ValueOf(listCtx.resourceList).Elem()
+	if items.Len() > int(args.Size) {
+		items.SetLen(int(args.Size))
+		token, err := s.continueToken(listCtx, d, items.Index(items.Len()-1).Interface())
+		if err != nil {
+			return err
+		}
+		listCtx.pagingMeta.Continue = token
+	}
+	listCtx.pagingMeta.Size = int64(items.Len())
 
 	return nil
 }
 
+// continueToken encodes the order key values of the last row of a page. Keys on columns
+// read the row itself; keys on labels, conditions or JSONB fields are read back from the
+// database.
+func (s *sqlGenericService) continueToken(
+	listCtx *listContext, d dao.GenericDao, last interface{},
+) (string, *errors.ServiceError) {
+	cursor := listCursor{Order: listCtx.order, Values: make([]*string, len(listCtx.orderKeys))}
+	var expressions []dao.Where
+	var positions []int
+	for i, key := range listCtx.orderKeys {
+		if key.Column == "" {
+			expressions = append(expressions, dao.NewWhere(key.SQL, key.Values))
+			positions = append(positions, i)
+			continue
+		}
+		value, ok := d.GetColumnValue(last, key.Column)
+		if !ok {
+			// The database sorted by the column, so the model lacking it is a server fault
+			return "", errors.GeneralError("Unable to read order column %q for continue token", key.Column)
+		}
+		cursor.Values[i] = cursorValue(value)
+	}
+	if len(expressions) > 0 {
+		values, err := d.GetExpressionValues(last, expressions)
+		if err != nil {
+			if db.IsDBConnectionError(err) {
+				return "", errors.ServiceUnavailable("Database connection unavailable")
+			}
+			return "", errors.GeneralError("Unable to read continue token values: %s", err)
+		}
+		for j, i := range positions {
+			cursor.Values[i] = values[j]
+		}
+	}
+	token, err := encodeCursor(cursor)
+	if err != nil {
+		return "", errors.GeneralError("Unable to encode continue token: %s", err)
+	}
+	return token, nil
+}
+
 // Allocate a slice with size 'cap' of the type i
 func zeroSlice(i interface{}, cap int64) *errors.ServiceError {
 	v := reflect.ValueOf(i)
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+)
+
+const (
+	// DefaultGraphDepth is the depth of a graph request that does not set one.
+	DefaultGraphDepth = 2
+	// MaxGraphDepth bounds the depth a graph request may ask for.
+	MaxGraphDepth = 5
+	// MaxGraphNodes bounds the size of a graph; larger graphs are truncated.
+	MaxGraphNodes = 500
+)
+
+// ListReferencers returns the live resources visible to the caller that reference the
+// resource, one entry per reference. kind may be empty to resolve the resource by ID.
+func (s *sqlResourceService) ListReferencers(
+	ctx context.Context, kind, id string,
+) ([]api.ResourceSummary, *errors.ServiceError) {
+	resource, svcErr := s.getAnyKind(ctx, kind, id)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	referencers, err := s.resourceDao.ListReferencers(ctx, resource.ID)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to list referencers of %s: %s", resource.Kind, err)
+	}
+	return referencers, nil
+}
+
+// Graph walks the resource's neighbourhood breadth-first up to depth edges away: the
+// children of each resource, the targets it references and the resources referencing
+// it. Resources the caller cannot see are left out, and the walk stops adding resources
+// once the graph holds MaxGraphNodes, marking it truncated.
+func (s *sqlResourceService) Graph(
+	ctx context.Context, id string, depth int,
+) (*api.ResourceGraph, *errors.ServiceError) {
+	if depth < 1 || depth > MaxGraphDepth {
+		return nil, errors.Validation("depth must be between 1 and %d", MaxGraphDepth)
+	}
+	root, svcErr := s.GetByID(ctx, id)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	graph := &api.ResourceGraph{RootID: root.ID, Depth: depth}
+	graph.Nodes = append(graph.Nodes, summarizeResource(root))
+	seen := map[string]bool{root.ID: true}
+	edges := make(map[api.GraphEdge]bool)
+
+	frontier := api.ResourceList{root}
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		var next api.ResourceList
+		for _, node := range frontier {
+			neighbours, neighbourErr := s.graphNeighbours(ctx, node)
+			if neighbourErr != nil {
+				return nil, neighbourErr
+			}
+			for _, n := range neighbours {
+				if !seen[n.resource.ID] {
+					if len(graph.Nodes) >= MaxGraphNodes {
+						graph.Truncated = true
+						continue
+					}
+					seen[n.resource.ID] = true
+					graph.Nodes = append(graph.Nodes, summarizeResource(n.resource))
+					next = append(next, n.resource)
+				}
+				if !edges[n.edge] {
+					edges[n.edge] = true
+					graph.Edges = append(graph.Edges, n.edge)
+				}
+			}
+		}
+		frontier = next
+	}
+	return graph, nil
+}
+
+// graphNeighbour is a resource adjacent to a graph node and the edge joining them.
+type graphNeighbour struct {
+	resource *api.Resource
+	edge     api.GraphEdge
+}
+
+// graphNeighbours returns node's children, the targets of its references and the
+// resources referencing it.
+func (s *sqlResourceService) graphNeighbours(
+	ctx context.Context, node *api.Resource,
+) ([]graphNeighbour, *errors.ServiceError) {
+	var neighbours []graphNeighbour
+
+	for _, child := range registry.ChildrenOf(node.Kind) {
+		items, err := s.resourceDao.FindByKindAndOwner(ctx, child.Kind, node.ID)
+		if err != nil {
+			return nil, errors.GeneralError("Unable to find %s children: %s", child.Kind, err)
+		}
+		for _, item := range items {
+			neighbours = append(neighbours, graphNeighbour{
+				resource: item,
+				edge:     api.GraphEdge{Type: api.GraphEdgeOwns, SourceID: node.ID, TargetID: item.ID},
+			})
+		}
+	}
+
+	for _, ref := range node.References {
+		target, found, svcErr := s.visibleResource(ctx, ref.TargetID)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+		if !found {
+			continue
+		}
+		neighbours = append(neighbours, graphNeighbour{
+			resource: target,
+			edge: api.GraphEdge{
+				Type: api.GraphEdgeReferences, SourceID: node.ID, TargetID: target.ID, RefType: ref.RefType,
+			},
+		})
+	}
+
+	referencers, err := s.resourceDao.ListReferencers(ctx, node.ID)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to list referencers of %s: %s", node.Kind, err)
+	}
+	for _, r := range referencers {
+		source, found, svcErr := s.visibleResource(ctx, r.ID)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+		if !found {
+			continue
+		}
+		neighbours = append(neighbours, graphNeighbour{
+			resource: source,
+			edge: api.GraphEdge{
+				Type: api.GraphEdgeReferences, SourceID: source.ID, TargetID: node.ID, RefType: r.RefType,
+			},
+		})
+	}
+	return neighbours, nil
+}
+
+// visibleResource loads the resource with id, reporting found=false rather than an
+// error when it does not exist or belongs to another tenant.
+func (s *sqlResourceService) visibleResource(
+	ctx context.Context, id string,
+) (*api.Resource, bool, *errors.ServiceError) {
+	resource, err := s.resourceDao.GetByID(ctx, id)
+	if err == nil {
+		return resource, true, nil
+	}
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, errors.GeneralError("Unable to get resource %s: %s", id, err)
+}
+
+func summarizeResource(r *api.Resource) api.ResourceSummary {
+	return api.ResourceSummary{ID: r.ID, Kind: r.Kind, Name: r.Name, Href: r.Href}
+}
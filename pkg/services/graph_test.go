@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+)
+
+// setupGraph registers Cluster -> WifConfig "wif_config" with NodePool owned by
+// Cluster, and stores wif w-1, cluster c-1 referencing it, and nodepool np-1 under c-1.
+func setupGraph() *mockResourceDao {
+	registry.Reset()
+	registry.Register(registry.EntityDescriptor{Kind: "WifConfig", Plural: "wifconfigs"})
+	registry.Register(registry.EntityDescriptor{
+		Kind:   "Cluster",
+		Plural: "clusters",
+		References: []registry.ReferenceDescriptor{
+			{RefType: "wif_config", TargetKind: "WifConfig"},
+		},
+	})
+	registry.Register(registry.EntityDescriptor{
+		Kind:           "NodePool",
+		Plural:         "nodepools",
+		ParentKind:     "Cluster",
+		OnParentDelete: registry.OnParentDeleteCascade,
+	})
+
+	mockDao := newMockResourceDao()
+	mockDao.addResource(testResource("WifConfig", "w-1", "wif"))
+	cluster := testResource("Cluster", "c-1", "prod")
+	cluster.References = []api.ResourceReference{
+		{SourceID: "c-1", RefType: "wif_config", TargetID: "w-1", TargetKind: "WifConfig"},
+	}
+	mockDao.addResource(cluster)
+	mockDao.addResource(testResourceWithOwner("NodePool", "np-1", "workers", "c-1"))
+	mockDao.referencersByTarget = map[string][]api.ResourceSummary{
+		"w-1": {{ID: "c-1", Kind: "Cluster", Name: "prod", RefType: "wif_config"}},
+	}
+	return mockDao
+}
+
+func graphNodeIDs(g *api.ResourceGraph) []string {
+	ids := make([]string, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+func TestResourceService_ListReferencers(t *testing.T) {
+	RegisterTestingT(t)
+	svc, _, _ := newTestResourceService(setupGraph())
+
+	referencers, svcErr := svc.ListReferencers(context.Background(), "WifConfig", "w-1")
+	Expect(svcErr).To(BeNil())
+	Expect(referencers).To(ConsistOf(api.ResourceSummary{
+		ID: "c-1", Kind: "Cluster", Name: "prod", RefType: "wif_config",
+	}))
+
+	_, svcErr = svc.ListReferencers(context.Background(), "WifConfig", "missing")
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(404))
+}
+
+func TestResourceService_Graph_Depth(t *testing.T) {
+	RegisterTestingT(t)
+	svc, _, _ := newTestResourceService(setupGraph())
+
+	graph, svcErr := svc.Graph(context.Background(), "w-1", 1)
+	Expect(svcErr).To(BeNil())
+	Expect(graph.RootID).To(Equal("w-1"))
+	Expect(graphNodeIDs(graph)).To(Equal([]string{"w-1", "c-1"}))
+	Expect(graph.Edges).To(ConsistOf(api.GraphEdge{
+		Type: api.GraphEdgeReferences, SourceID: "c-1", TargetID: "w-1", RefType: "wif_config",
+	}))
+
+	// The second hop reaches the cluster's nodepool; the cluster's own reference back
+	// to the WifConfig is the edge already found, so it is not repeated.
+	graph, svcErr = svc.Graph(context.Background(), "w-1", 2)
+	Expect(svcErr).To(BeNil())
+	Expect(graphNodeIDs(graph)).To(Equal([]string{"w-1", "c-1", "np-1"}))
+	Expect(graph.Edges).To(ConsistOf(
+		api.GraphEdge{Type: api.GraphEdgeReferences, SourceID: "c-1", TargetID: "w-1", RefType: "wif_config"},
+		api.GraphEdge{Type: api.GraphEdgeOwns, SourceID: "c-1", TargetID: "np-1"},
+	))
+	Expect(graph.Truncated).To(BeFalse())
+}
+
+func TestResourceService_Graph_FromOwner(t *testing.T) {
+	RegisterTestingT(t)
+	svc, _, _ := newTestResourceService(setupGraph())
+
+	graph, svcErr := svc.Graph(context.Background(), "c-1", 1)
+	Expect(svcErr).To(BeNil())
+	Expect(graphNodeIDs(graph)).To(ConsistOf("c-1", "np-1", "w-1"))
+	Expect(graph.Edges).To(ConsistOf(
+		api.GraphEdge{Type: api.GraphEdgeOwns, SourceID: "c-1", TargetID: "np-1"},
+		api.GraphEdge{Type: api.GraphEdgeReferences, SourceID: "c-1", TargetID: "w-1", RefType: "wif_config"},
+	))
+}
+
+func TestResourceService_Graph_InvalidDepth(t *testing.T) {
+	RegisterTestingT(t)
+	svc, _, _ := newTestResourceService(setupGraph())
+
+	for _, depth := range []int{0, MaxGraphDepth + 1} {
+		_, svcErr := svc.Graph(context.Background(), "w-1", depth)
+		Expect(svcErr).ToNot(BeNil())
+		Expect(svcErr.HTTPCode).To(Equal(400))
+	}
+}
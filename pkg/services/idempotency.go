@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
+)
+
+// idempotencyPruneInterval is how often expired idempotency keys are deleted. Every
+// replica prunes; the delete is idempotent, so no lock is taken.
+const idempotencyPruneInterval = 10 * time.Minute
+
+//go:generate go tool -modfile=../../tools/go.mod mockgen -source=idempotency.go -package=services -destination=idempotency_mock.go
+
+// IdempotencyService keeps the responses of writes made under an Idempotency-Key header,
+// so that a retry replays the original response instead of repeating the write. Keys are
+// scoped to the caller, and every call runs in the transaction of the request it guards.
+type IdempotencyService interface {
+	// Claim reserves key for a request whose method, path, and body hash to requestHash.
+	// It returns nil when the request should run, and the recorded response when key was
+	// already used for the same request. A key used for a different request is a 422.
+	Claim(ctx context.Context, key, requestHash string) (*api.IdempotencyKey, *errors.ServiceError)
+	// Complete records the response of a request that claimed key.
+	Complete(
+		ctx context.Context, key string, statusCode int, headers map[string]string, body []byte,
+	) *errors.ServiceError
+	// Release gives up a claimed key, so that a retry runs the request again.
+	Release(ctx context.Context, key string) *errors.ServiceError
+	// Run prunes expired keys until ctx is done.
+	Run(ctx context.Context)
+}
+
+func NewIdempotencyService(idempotencyKeyDao dao.IdempotencyKeyDao, ttl time.Duration) IdempotencyService {
+	return &sqlIdempotencyService{
+		idempotencyKeyDao: idempotencyKeyDao,
+		ttl:               ttl,
+	}
+}
+
+var _ IdempotencyService = &sqlIdempotencyService{}
+
+type sqlIdempotencyService struct {
+	idempotencyKeyDao dao.IdempotencyKeyDao
+	ttl               time.Duration
+}
+
+func (s *sqlIdempotencyService) Claim(
+	ctx context.Context, key, requestHash string,
+) (*api.IdempotencyKey, *errors.ServiceError) {
+	owner := actorFromContext(ctx)
+	now := time.Now()
+	claimed, err := s.idempotencyKeyDao.Claim(ctx, &api.IdempotencyKey{
+		Owner:       owner,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedTime: now,
+		ExpiresTime: now.Add(s.ttl),
+	})
+	if err != nil {
+		return nil, errors.GeneralError("Unable to claim idempotency key: %s", err)
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	existing, err := s.idempotencyKeyDao.Get(ctx, owner, key)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		// Pruned or released between the claim and the read; the client may simply retry.
+		return nil, errors.ConflictState("Idempotency-Key %q is in use by another request", key)
+	}
+	if err != nil {
+		return nil, errors.GeneralError("Unable to read idempotency key: %s", err)
+	}
+	if existing.RequestHash != requestHash {
+		return nil, errors.IdempotencyKeyReused("Idempotency-Key %q was already used for a different request", key)
+	}
+	if !existing.Completed() {
+		return nil, errors.ConflictState("Idempotency-Key %q is in use by another request", key)
+	}
+	return existing, nil
+}
+
+func (s *sqlIdempotencyService) Complete(
+	ctx context.Context, key string, statusCode int, headers map[string]string, body []byte,
+) *errors.ServiceError {
+	record := &api.IdempotencyKey{
+		Owner:        actorFromContext(ctx),
+		Key:          key,
+		StatusCode:   statusCode,
+		ResponseBody: body,
+	}
+	headersJSON, err := json.Marshal(headers)
+	if err != nil {
+		return errors.GeneralError("Unable to marshal response headers: %s", err)
+	}
+	record.ResponseHeaders = datatypes.JSON(headersJSON)
+	if err = s.idempotencyKeyDao.Complete(ctx, record); err != nil {
+		return errors.GeneralError("Unable to record idempotent response: %s", err)
+	}
+	return nil
+}
+
+func (s *sqlIdempotencyService) Release(ctx context.Context, key string) *errors.ServiceError {
+	if err := s.idempotencyKeyDao.Release(ctx, actorFromContext(ctx), key); err != nil {
+		return errors.GeneralError("Unable to release idempotency key: %s", err)
+	}
+	return nil
+}
+
+func (s *sqlIdempotencyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(idempotencyPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.prune(ctx)
+		}
+	}
+}
+
+func (s *sqlIdempotencyService) prune(ctx context.Context) {
+	deleted, err := s.idempotencyKeyDao.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		logger.WithError(ctx, err).Warn("Failed to prune expired idempotency keys")
+		return
+	}
+	if deleted > 0 {
+		logger.With(ctx, "deleted", deleted).Info("Pruned expired idempotency keys")
+	}
+}
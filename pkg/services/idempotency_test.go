@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+)
+
+// idempotencyKeyDaoMock keeps committed keys in a map; Claim succeeds for a new or
+// expired key, as the upsert does.
+type idempotencyKeyDaoMock struct {
+	keys map[string]*api.IdempotencyKey
+}
+
+var _ dao.IdempotencyKeyDao = &idempotencyKeyDaoMock{}
+
+func (d *idempotencyKeyDaoMock) Claim(_ context.Context, key *api.IdempotencyKey) (bool, error) {
+	if existing, ok := d.keys[key.Owner+"/"+key.Key]; ok && existing.ExpiresTime.After(key.CreatedTime) {
+		return false, nil
+	}
+	d.keys[key.Owner+"/"+key.Key] = key
+	return true, nil
+}
+
+func (d *idempotencyKeyDaoMock) Get(_ context.Context, owner, key string) (*api.IdempotencyKey, error) {
+	if existing, ok := d.keys[owner+"/"+key]; ok {
+		return existing, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *idempotencyKeyDaoMock) Complete(_ context.Context, key *api.IdempotencyKey) error {
+	existing := d.keys[key.Owner+"/"+key.Key]
+	existing.StatusCode = key.StatusCode
+	existing.ResponseHeaders = key.ResponseHeaders
+	existing.ResponseBody = key.ResponseBody
+	return nil
+}
+
+func (d *idempotencyKeyDaoMock) Release(_ context.Context, owner, key string) error {
+	delete(d.keys, owner+"/"+key)
+	return nil
+}
+
+func (d *idempotencyKeyDaoMock) DeleteExpired(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotencyService_Claim(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.Background()
+	mockDao := &idempotencyKeyDaoMock{keys: make(map[string]*api.IdempotencyKey)}
+	svc := NewIdempotencyService(mockDao, time.Hour)
+
+	recorded, svcErr := svc.Claim(ctx, "k1", "hash-a")
+	Expect(svcErr).To(BeNil())
+	Expect(recorded).To(BeNil())
+
+	// A claim that was committed without a response is still in use.
+	_, svcErr = svc.Claim(ctx, "k1", "hash-a")
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(http.StatusConflict))
+
+	Expect(svc.Complete(ctx, "k1", http.StatusCreated, map[string]string{"ETag": `"1-1"`}, []byte(`{}`))).To(BeNil())
+
+	recorded, svcErr = svc.Claim(ctx, "k1", "hash-a")
+	Expect(svcErr).To(BeNil())
+	Expect(recorded.StatusCode).To(Equal(http.StatusCreated))
+	Expect([]byte(recorded.ResponseHeaders)).To(MatchJSON(`{"ETag":"\"1-1\""}`))
+
+	_, svcErr = svc.Claim(ctx, "k1", "hash-b")
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(http.StatusUnprocessableEntity))
+}
+
+func TestIdempotencyService_ReleaseAndExpiry(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.Background()
+	mockDao := &idempotencyKeyDaoMock{keys: make(map[string]*api.IdempotencyKey)}
+	svc := NewIdempotencyService(mockDao, time.Hour)
+
+	_, svcErr := svc.Claim(ctx, "k1", "hash-a")
+	Expect(svcErr).To(BeNil())
+	Expect(svc.Release(ctx, "k1")).To(BeNil())
+
+	recorded, svcErr := svc.Claim(ctx, "k1", "hash-b")
+	Expect(svcErr).To(BeNil())
+	Expect(recorded).To(BeNil())
+
+	// An expired key is reclaimed for a new request.
+	mockDao.keys[defaultSystemUser+"/k1"].ExpiresTime = time.Now().Add(-time.Minute)
+	recorded, svcErr = svc.Claim(ctx, "k1", "hash-c")
+	Expect(svcErr).To(BeNil())
+	Expect(recorded).To(BeNil())
+}
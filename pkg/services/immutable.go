@@ -0,0 +1,63 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// checkImmutableFields rejects a spec change that touches any of the given dotted spec
+// paths, listing each offending field. Setting a field that was absent, or removing one
+// that was set, counts as a change.
+func checkImmutableFields(kind string, fields []string, before, after []byte) *errors.ServiceError {
+	if len(fields) == 0 {
+		return nil
+	}
+	oldSpec, err := decodeJSONValue(before)
+	if err != nil {
+		return errors.GeneralError("Failed to decode %s spec: %s", kind, err)
+	}
+	newSpec, err := decodeJSONValue(after)
+	if err != nil {
+		return errors.GeneralError("Failed to decode %s spec: %s", kind, err)
+	}
+
+	var details []errors.ValidationDetail
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		oldValue, oldFound := lookupSpecPath(oldSpec, path)
+		newValue, newFound := lookupSpecPath(newSpec, path)
+		if oldFound == newFound && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		details = append(details, errors.ValidationDetail{
+			Field:   "spec." + field,
+			Value:   newValue,
+			Message: "field is immutable and cannot change after creation",
+		})
+	}
+	if len(details) > 0 {
+		// The patch is well formed but cannot be applied to this resource.
+		svcErr := errors.ValidationWithDetails(fmt.Sprintf("Immutable fields of %s cannot be changed", kind), details)
+		svcErr.HTTPCode = http.StatusUnprocessableEntity
+		return svcErr
+	}
+	return nil
+}
+
+// lookupSpecPath returns the value at path within a decoded spec, and whether it is set.
+func lookupSpecPath(value any, path []string) (any, bool) {
+	for _, key := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
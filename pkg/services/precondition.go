@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+type ifMatchKey struct{}
+
+// WithIfMatch attaches the entity tags from a request's If-Match header to ctx.
+// Patch and Delete compare them against the locked row, so the check and the write
+// are serialized with concurrent updates.
+func WithIfMatch(ctx context.Context, etags []string) context.Context {
+	if len(etags) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, ifMatchKey{}, etags)
+}
+
+// ParseETags splits an If-Match or If-None-Match header value into its entity tags.
+func ParseETags(header string) []string {
+	var etags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			etags = append(etags, tag)
+		}
+	}
+	return etags
+}
+
+// ETagMatches reports whether any of etags matches the resource's current ETag using
+// weak comparison; "*" matches any existing resource.
+func ETagMatches(etags []string, resource *api.Resource) bool {
+	return MatchETag(etags, resource.ETag())
+}
+
+// MatchETag reports whether any of etags matches current using weak comparison; "*"
+// matches any current tag.
+func MatchETag(etags []string, current string) bool {
+	for _, tag := range etags {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == current {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch rejects the write with CNF-002 when the caller sent If-Match and no
+// tag matches the resource's current version.
+func checkIfMatch(ctx context.Context, resource *api.Resource) *errors.ServiceError {
+	etags, ok := ctx.Value(ifMatchKey{}).([]string)
+	if !ok || ETagMatches(etags, resource) {
+		return nil
+	}
+	return errors.ConflictVersion(
+		"%s '%s' has been modified: current version is %s", resource.Kind, resource.ID, resource.ETag(),
+	)
+}
@@ -3,14 +3,22 @@ package services
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/presenters"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/events"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/metrics"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
@@ -28,10 +36,33 @@ type ResourceService interface {
 	List(ctx context.Context, kind string, args *ListArguments) (api.ResourceList, *api.PagingMeta, *errors.ServiceError)
 	GetByOwner(ctx context.Context, kind, id, ownerID string) (*api.Resource, *errors.ServiceError)
 	ListByOwner(ctx context.Context, kind, ownerID string, args *ListArguments) (api.ResourceList, *api.PagingMeta, *errors.ServiceError) // nolint:lll
+	ListByAncestor(
+		ctx context.Context, kind, ancestorKind, ancestorID string, args *ListArguments,
+	) (api.ResourceList, *api.PagingMeta, *errors.ServiceError)
 	ForceDelete(ctx context.Context, kind, id, reason string) *errors.ServiceError
 	GetByID(ctx context.Context, id string) (*api.Resource, *errors.ServiceError)
 	ListAll(ctx context.Context, args *ListArguments) (api.ResourceList, *api.PagingMeta, *errors.ServiceError)
+	Aggregate(
+		ctx context.Context, kind, ownerID string, groupBy []string, args *ListArguments,
+	) (*api.ResourceAggregate, *errors.ServiceError)
 	ProcessAdapterStatus(ctx context.Context, kind, resourceID string, adapterStatus *api.AdapterStatus) (*api.AdapterStatus, *errors.ServiceError) // nolint:lll
+	History(
+		ctx context.Context, kind, id, ownerID string, args *ListArguments,
+	) (api.ResourceEventList, *api.PagingMeta, *errors.ServiceError)
+	ListRevisions(
+		ctx context.Context, kind, id string, args *ListArguments,
+	) (api.ResourceRevisionList, *api.PagingMeta, *errors.ServiceError)
+	GetRevision(ctx context.Context, kind, id string, generation int32) (*api.ResourceRevision, *errors.ServiceError)
+	ListReferencers(ctx context.Context, kind, id string) ([]api.ResourceSummary, *errors.ServiceError)
+	Graph(ctx context.Context, id string, depth int) (*api.ResourceGraph, *errors.ServiceError)
+	Batch(ctx context.Context, ops []BatchOperation) ([]BatchResult, *errors.ServiceError)
+	ReloadDescriptors(descriptors []registry.EntityDescriptor) error
+}
+
+// SpecDefaulter fills the default values that the spec schema of a resource plural declares
+// into a JSON spec, returning spec itself when nothing applies.
+type SpecDefaulter interface {
+	ApplyDefaults(resourcePlural string, spec []byte) ([]byte, error)
 }
 
 func NewResourceService(
@@ -39,20 +70,41 @@ func NewResourceService(
 	resourceLabelDao dao.ResourceLabelDao,
 	adapterStatusDao dao.AdapterStatusDao,
 	resourceConditionDao dao.ResourceConditionDao,
+	resourceChangeDao dao.ResourceChangeDao,
+	resourceEventDao dao.ResourceEventDao,
+	resourceRevisionDao dao.ResourceRevisionDao,
+	outbox events.Outbox,
 	generic GenericService,
+	specDefaulter SpecDefaulter,
 ) (ResourceService, error) {
 	mappers, err := buildConditionMappers(registry.All())
 	if err != nil {
 		return nil, fmt.Errorf("initialize resource service: %w", err)
 	}
-	return &sqlResourceService{
+	specValidators, err := buildSpecValidators(registry.All())
+	if err != nil {
+		return nil, fmt.Errorf("initialize resource service: %w", err)
+	}
+	statusFieldMappers, err := buildStatusFieldMappers(registry.All())
+	if err != nil {
+		return nil, fmt.Errorf("initialize resource service: %w", err)
+	}
+	s := &sqlResourceService{
 		resourceDao:          resourceDao,
 		resourceLabelDao:     resourceLabelDao,
 		adapterStatusDao:     adapterStatusDao,
 		resourceConditionDao: resourceConditionDao,
+		resourceChangeDao:    resourceChangeDao,
+		resourceEventDao:     resourceEventDao,
+		resourceRevisionDao:  resourceRevisionDao,
+		outbox:               outbox,
 		generic:              generic,
-		conditionMappers:     mappers,
-	}, nil
+		specDefaulter:        specDefaulter,
+	}
+	s.conditionMappers.Store(&mappers)
+	s.specValidators.Store(&specValidators)
+	s.statusFieldMappers.Store(&statusFieldMappers)
+	return s, nil
 }
 
 func buildConditionMappers(entities []registry.EntityDescriptor) (map[string]*ConditionMapper, error) {
@@ -69,6 +121,34 @@ func buildConditionMappers(entities []registry.EntityDescriptor) (map[string]*Co
 	return conditionMappers, nil
 }
 
+func buildSpecValidators(entities []registry.EntityDescriptor) (map[string]*SpecValidator, error) {
+	specValidators := make(map[string]*SpecValidator)
+	for _, descriptor := range entities {
+		if len(descriptor.Validations) > 0 {
+			validator, err := NewSpecValidator(descriptor.Kind, descriptor.Validations)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create spec validator for %s: %w", descriptor.Kind, err)
+			}
+			specValidators[descriptor.Kind] = validator
+		}
+	}
+	return specValidators, nil
+}
+
+func buildStatusFieldMappers(entities []registry.EntityDescriptor) (map[string]*StatusFieldMapper, error) {
+	statusFieldMappers := make(map[string]*StatusFieldMapper)
+	for _, descriptor := range entities {
+		if len(descriptor.StatusFields) > 0 {
+			mapper, err := NewStatusFieldMapper(descriptor.Kind, descriptor.StatusFields)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create status field mapper for %s: %w", descriptor.Kind, err)
+			}
+			statusFieldMappers[descriptor.Kind] = mapper
+		}
+	}
+	return statusFieldMappers, nil
+}
+
 var _ ResourceService = &sqlResourceService{}
 
 type sqlResourceService struct {
@@ -76,8 +156,124 @@ type sqlResourceService struct {
 	resourceLabelDao     dao.ResourceLabelDao
 	adapterStatusDao     dao.AdapterStatusDao
 	resourceConditionDao dao.ResourceConditionDao
+	resourceChangeDao    dao.ResourceChangeDao
+	resourceEventDao     dao.ResourceEventDao
+	resourceRevisionDao  dao.ResourceRevisionDao
+	outbox               events.Outbox // nil when event publishing is disabled
 	generic              GenericService
-	conditionMappers     map[string]*ConditionMapper // Indexed by Kind (e.g., "Cluster", "NodePool")
+	specDefaulter        SpecDefaulter // nil when no spec schema is loaded
+	// Indexed by Kind (e.g., "Cluster", "NodePool"); swapped by ReloadDescriptors
+	conditionMappers   atomic.Pointer[map[string]*ConditionMapper]
+	specValidators     atomic.Pointer[map[string]*SpecValidator]
+	statusFieldMappers atomic.Pointer[map[string]*StatusFieldMapper]
+	reloadMu           sync.Mutex
+}
+
+// conditionMapper returns the condition mapper of kind, or nil when it has no mapping rules.
+func (s *sqlResourceService) conditionMapper(kind string) *ConditionMapper {
+	mappers := s.conditionMappers.Load()
+	if mappers == nil {
+		return nil
+	}
+	return (*mappers)[kind]
+}
+
+// statusFieldMapper returns the status field mapper of kind, or nil when it has no status fields.
+func (s *sqlResourceService) statusFieldMapper(kind string) *StatusFieldMapper {
+	mappers := s.statusFieldMappers.Load()
+	if mappers == nil {
+		return nil
+	}
+	return (*mappers)[kind]
+}
+
+// specValidator returns the spec validator of kind, or nil when it has no validation rules.
+func (s *sqlResourceService) specValidator(kind string) *SpecValidator {
+	validators := s.specValidators.Load()
+	if validators == nil {
+		return nil
+	}
+	return (*validators)[kind]
+}
+
+// ReloadDescriptors swaps in new entity descriptors, as on a configuration reload, along
+// with the condition mappers, spec validators, and status field mappers built from them.
+// registry.Replace decides which changes are accepted; when it or a mapper fails, nothing
+// changes.
+func (s *sqlResourceService) ReloadDescriptors(descriptors []registry.EntityDescriptor) error {
+	mappers, err := buildConditionMappers(descriptors)
+	if err != nil {
+		return err
+	}
+	specValidators, err := buildSpecValidators(descriptors)
+	if err != nil {
+		return err
+	}
+	statusFieldMappers, err := buildStatusFieldMappers(descriptors)
+	if err != nil {
+		return err
+	}
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	if err = registry.Replace(descriptors); err != nil {
+		return err
+	}
+	s.conditionMappers.Store(&mappers)
+	s.specValidators.Store(&specValidators)
+	s.statusFieldMappers.Store(&statusFieldMappers)
+	return nil
+}
+
+// applySpecDefaults fills the defaults of the kind's spec schema into the resource's spec,
+// so they are stored and seen by the validation rules.
+func (s *sqlResourceService) applySpecDefaults(resource *api.Resource) *errors.ServiceError {
+	if s.specDefaulter == nil {
+		return nil
+	}
+	spec, err := s.specDefaulter.ApplyDefaults(registry.MustGet(resource.Kind).Plural, resource.Spec)
+	if err != nil {
+		return errors.GeneralError("Failed to apply %s spec defaults: %s", resource.Kind, err)
+	}
+	resource.Spec = spec
+	return nil
+}
+
+// checkValidationRules evaluates the validation rules of the resource's kind against its
+// new spec and labels. oldSpec is nil on create. parent may be nil, in which case it is
+// loaded when the resource has an owner.
+func (s *sqlResourceService) checkValidationRules(
+	ctx context.Context, resource *api.Resource, oldSpec []byte, parent *api.Resource,
+) *errors.ServiceError {
+	validator := s.specValidator(resource.Kind)
+	if validator == nil {
+		return nil
+	}
+
+	input := specValidationInput{
+		labels:  labelMap(resource.Labels),
+		spec:    resource.Spec,
+		oldSpec: oldSpec,
+	}
+	if ownerID := util.FromPtr(resource.OwnerID); ownerID != "" {
+		if parent == nil {
+			parentKind := registry.MustGet(resource.Kind).ParentKind
+			var err error
+			if parent, err = s.resourceDao.Get(ctx, parentKind, ownerID); err != nil {
+				return handleGetError(parentKind, "id", ownerID, err)
+			}
+		}
+		input.parent = parent
+	}
+
+	details, err := validator.Validate(input)
+	if err != nil {
+		return errors.GeneralError("Failed to validate %s: %s", resource.Kind, err)
+	}
+	if len(details) > 0 {
+		return errors.ValidationWithDetails(fmt.Sprintf("Invalid %s", resource.Kind), details)
+	}
+	return nil
 }
 
 // Get returns a single resource by kind and ID. Returns 404 if not found.
@@ -123,9 +319,11 @@ func (s *sqlResourceService) Create(
 	}
 
 	// Lock parent row to serialize with concurrent deletes.
+	var parent *api.Resource
 	if ownerID := util.FromPtr(resource.OwnerID); ownerID != "" {
 		desc := registry.MustGet(kind)
-		parent, err := s.resourceDao.GetForUpdate(ctx, desc.ParentKind, ownerID)
+		var err error
+		parent, err = s.resourceDao.GetForUpdate(ctx, desc.ParentKind, ownerID)
 		if err != nil {
 			return nil, handleGetError(desc.ParentKind, "id", ownerID, err)
 		}
@@ -134,6 +332,13 @@ func (s *sqlResourceService) Create(
 		}
 	}
 
+	if svcErr := s.applySpecDefaults(resource); svcErr != nil {
+		return nil, svcErr
+	}
+	if svcErr := s.checkValidationRules(ctx, resource, nil, parent); svcErr != nil {
+		return nil, svcErr
+	}
+
 	if svcErr := s.validateReferences(ctx, kind, refs); svcErr != nil {
 		return nil, svcErr
 	}
@@ -173,11 +378,27 @@ func (s *sqlResourceService) Create(
 	// metrics (INNER JOIN resource_conditions) and status search queries.
 	desc := registry.MustGet(kind)
 	if len(desc.RequiredAdapters) > 0 {
-		if svcErr := s.recomputeAndSaveResourceConditions(ctx, resource, nil); svcErr != nil {
+		if _, svcErr := s.recomputeAndSaveResourceConditions(ctx, resource, nil); svcErr != nil {
 			return nil, svcErr
 		}
 	}
 
+	if svcErr := s.recordChange(ctx, api.ChangeAdded, resource); svcErr != nil {
+		return nil, svcErr
+	}
+
+	changes, diffErr := diffSnapshots(resourceSnapshot{}, snapshotResource(resource))
+	if diffErr != nil {
+		db.MarkForRollback(ctx, diffErr)
+		return nil, errors.GeneralError("Failed to diff %s: %s", kind, diffErr)
+	}
+	if svcErr := s.recordAudit(ctx, newAuditEvent(ctx, api.AuditCreate, resource), changes); svcErr != nil {
+		return nil, svcErr
+	}
+	if svcErr := s.recordRevision(ctx, resource); svcErr != nil {
+		return nil, svcErr
+	}
+
 	return resource, nil
 }
 
@@ -194,22 +415,41 @@ func (s *sqlResourceService) Patch(
 	if err != nil {
 		return nil, handleGetError(kind, "id", id, err)
 	}
+	if svcErr := checkIfMatch(ctx, resource); svcErr != nil {
+		return nil, svcErr
+	}
 
 	if resource.DeletedTime != nil {
 		return nil, errors.ConflictState("%s '%s' is marked for deletion", kind, id)
 	}
 
-	oldSpec := append([]byte(nil), resource.Spec...)
-	oldLabels := resource.Labels
+	before := snapshotResource(resource)
 
 	if applyErr := applyResourcePatch(resource, patch); applyErr != nil {
 		return nil, errors.Validation("Invalid patch data: %v", applyErr)
 	}
+	if patch.Spec != nil {
+		if svcErr := s.applySpecDefaults(resource); svcErr != nil {
+			return nil, svcErr
+		}
+	}
 
-	specChanged := !jsonBytesEqual(oldSpec, resource.Spec)
-	labelsChanged := !labelsEqual(oldLabels, resource.Labels)
+	specChanged := !jsonBytesEqual(before.spec, resource.Spec)
+	labelsChanged := !labelsEqual(before.labels, resource.Labels)
 	refsChanged := patch.References != nil
 
+	if specChanged {
+		immutableFields := registry.MustGet(kind).ImmutableFields
+		if svcErr := checkImmutableFields(kind, immutableFields, before.spec, resource.Spec); svcErr != nil {
+			return nil, svcErr
+		}
+	}
+	if specChanged || labelsChanged {
+		if svcErr := s.checkValidationRules(ctx, resource, before.spec, nil); svcErr != nil {
+			return nil, svcErr
+		}
+	}
+
 	// Validate and persist references when the patch includes them (nil = skip, {} = clear).
 	if refsChanged {
 		if svcErr := s.validateReferences(ctx, kind, patch.References); svcErr != nil {
@@ -252,11 +492,27 @@ func (s *sqlResourceService) Patch(
 			db.MarkForRollback(ctx, statusErr)
 			return nil, errors.GeneralError("failed to get adapter statuses for condition recompute: %s", statusErr)
 		}
-		if svcErr := s.recomputeAndSaveResourceConditions(ctx, resource, adapterStatuses); svcErr != nil {
+		if _, svcErr := s.recomputeAndSaveResourceConditions(ctx, resource, adapterStatuses); svcErr != nil {
 			return nil, svcErr
 		}
 	}
 
+	if svcErr := s.recordChange(ctx, api.ChangeModified, resource); svcErr != nil {
+		return nil, svcErr
+	}
+
+	changes, diffErr := diffSnapshots(before, snapshotResource(resource))
+	if diffErr != nil {
+		db.MarkForRollback(ctx, diffErr)
+		return nil, errors.GeneralError("Failed to diff %s: %s", kind, diffErr)
+	}
+	if svcErr := s.recordAudit(ctx, newAuditEvent(ctx, api.AuditPatch, resource), changes); svcErr != nil {
+		return nil, svcErr
+	}
+	if svcErr := s.recordRevision(ctx, resource); svcErr != nil {
+		return nil, svcErr
+	}
+
 	return resource, nil
 }
 
@@ -272,6 +528,9 @@ func (s *sqlResourceService) Delete(ctx context.Context, kind, id string) (*api.
 	if err != nil {
 		return nil, handleSoftDeleteError(kind, err)
 	}
+	if svcErr := checkIfMatch(ctx, resource); svcErr != nil {
+		return nil, svcErr
+	}
 
 	deletedBy := actorFromContext(ctx)
 	deletedAt := time.Now().UTC().Truncate(time.Microsecond)
@@ -282,7 +541,8 @@ func (s *sqlResourceService) Delete(ctx context.Context, kind, id string) (*api.
 		resource.IncrementGeneration()
 	}
 
-	if svcErr := s.deleteResourceTree(ctx, resource, deletedBy, deletedAt); svcErr != nil {
+	visited := make(map[string]bool)
+	if svcErr := s.deleteResourceTree(ctx, resource, deletedBy, deletedAt, visited); svcErr != nil {
 		db.MarkForRollback(ctx, svcErr)
 		return nil, svcErr
 	}
@@ -290,11 +550,13 @@ func (s *sqlResourceService) Delete(ctx context.Context, kind, id string) (*api.
 	return resource, nil
 }
 
-// deleteResourceTree enforces child delete policies then persists bottom-up.
+// deleteResourceTree enforces child and reference delete policies then persists bottom-up.
+// visited holds the IDs already being deleted by this operation, so reference cycles end.
 func (s *sqlResourceService) deleteResourceTree(
 	ctx context.Context, resource *api.Resource,
-	deletedBy string, deletedAt time.Time,
+	deletedBy string, deletedAt time.Time, visited map[string]bool,
 ) *errors.ServiceError {
+	visited[resource.ID] = true
 	children := registry.ChildrenOf(resource.Kind)
 
 	for _, child := range children {
@@ -318,27 +580,16 @@ func (s *sqlResourceService) deleteResourceTree(
 					item.MarkDeleted(deletedBy, deletedAt)
 					item.IncrementGeneration()
 				}
-				if svcErr := s.deleteResourceTree(ctx, item, deletedBy, deletedAt); svcErr != nil {
+				if svcErr := s.deleteResourceTree(ctx, item, deletedBy, deletedAt, visited); svcErr != nil {
 					return svcErr
 				}
 			}
 		}
 	}
 
-	// Check if other resources reference this one before any deletion.
-	referencers, refErr := s.resourceDao.FindReferencers(ctx, resource.ID)
-	if refErr != nil {
-		return errors.GeneralError("failed to check references: %s", refErr)
-	}
-	// List out all the references for a specific resource
-	if len(referencers) > 0 {
-		names := make([]string, len(referencers))
-		for i, r := range referencers {
-			names[i] = fmt.Sprintf("%s %q", r.Kind, r.Name)
-		}
-		return errors.ConflictState(
-			"cannot delete %s %q: referenced by %s — remove the reference(s) before deleting",
-			resource.Kind, resource.Name, strings.Join(names, ", "))
+	// Apply the referencers' on_target_delete policies before any deletion.
+	if svcErr := s.applyTargetDeletePolicies(ctx, resource, deletedBy, deletedAt, visited); svcErr != nil {
+		return svcErr
 	}
 
 	shouldSoftDelete, svcErr := s.shouldSoftDelete(ctx, resource, children)
@@ -346,6 +597,10 @@ func (s *sqlResourceService) deleteResourceTree(
 		return svcErr
 	}
 
+	if svcErr := s.recordAudit(ctx, newAuditEvent(ctx, api.AuditDelete, resource), nil); svcErr != nil {
+		return svcErr
+	}
+
 	if shouldSoftDelete {
 		if saveErr := s.resourceDao.Save(ctx, resource); saveErr != nil {
 			return handleSoftDeleteError(resource.Kind, saveErr)
@@ -360,19 +615,190 @@ func (s *sqlResourceService) deleteResourceTree(
 			db.MarkForRollback(ctx, statusErr)
 			return errors.GeneralError("failed to get adapter statuses for condition recompute: %s", statusErr)
 		}
-		if svcErr := s.recomputeAndSaveResourceConditions(ctx, resource, adapterStatuses); svcErr != nil {
+		if _, svcErr := s.recomputeAndSaveResourceConditions(ctx, resource, adapterStatuses); svcErr != nil {
 			return svcErr
 		}
-		return nil
+		return s.recordChange(ctx, api.ChangeModified, resource)
 	}
 
 	if err := s.resourceDao.Delete(ctx, resource.Kind, resource.ID); err != nil {
 		return handleDeleteError(resource.Kind, err)
 	}
 
+	return s.recordChange(ctx, api.ChangeDeleted, resource)
+}
+
+// targetDeletePolicyRank orders on_target_delete policies so the strictest one wins when
+// a referencer holds several references to the same target.
+var targetDeletePolicyRank = map[registry.OnTargetDeletePolicy]int{
+	registry.OnTargetDeleteDetach:   0,
+	registry.OnTargetDeleteCascade:  1,
+	registry.OnTargetDeleteRestrict: 2,
+}
+
+// applyTargetDeletePolicies resolves every inbound reference to target against the
+// on_target_delete policy declared by the referencing kind:
+//   - restrict rejects the delete with 409
+//   - cascade deletes the referencer through deleteResourceTree
+//   - detach drops the reference and bumps the referencer's generation
+//
+// Referencers already in visited are being deleted by this operation and are skipped.
+// On success no inbound references to target remain.
+func (s *sqlResourceService) applyTargetDeletePolicies(
+	ctx context.Context, target *api.Resource,
+	deletedBy string, deletedAt time.Time, visited map[string]bool,
+) *errors.ServiceError {
+	referencers, refErr := s.resourceDao.FindReferencers(ctx, target.ID)
+	if refErr != nil {
+		return errors.GeneralError("failed to check references: %s", refErr)
+	}
+	if len(referencers) == 0 {
+		return nil
+	}
+
+	policies := make(map[string]registry.OnTargetDeletePolicy, len(referencers))
+	sources := make([]api.ResourceSummary, 0, len(referencers))
+	for _, r := range referencers {
+		if visited[r.ID] {
+			continue
+		}
+		policy := targetDeletePolicy(r.Kind, r.RefType)
+		prev, seen := policies[r.ID]
+		if !seen {
+			sources = append(sources, r)
+		}
+		if !seen || targetDeletePolicyRank[policy] > targetDeletePolicyRank[prev] {
+			policies[r.ID] = policy
+		}
+	}
+
+	var restricted []string
+	for _, r := range sources {
+		if policies[r.ID] == registry.OnTargetDeleteRestrict {
+			restricted = append(restricted, fmt.Sprintf("%s %q", r.Kind, r.Name))
+		}
+	}
+	if len(restricted) > 0 {
+		return errors.ConflictState(
+			"cannot delete %s %q: referenced by %s — remove the reference(s) before deleting",
+			target.Kind, target.Name, strings.Join(restricted, ", "))
+	}
+
+	for _, r := range sources {
+		if policies[r.ID] != registry.OnTargetDeleteCascade {
+			continue
+		}
+		referencer, svcErr := s.lockReferencer(ctx, target, r)
+		if svcErr != nil {
+			return svcErr
+		}
+		if referencer.DeletedTime == nil {
+			referencer.MarkDeleted(deletedBy, deletedAt)
+			referencer.IncrementGeneration()
+		}
+		if treeErr := s.deleteResourceTree(ctx, referencer, deletedBy, deletedAt, visited); treeErr != nil {
+			return treeErr
+		}
+	}
+
+	// Cascaded referencers have dropped their references already; this removes the
+	// detached ones and those held by resources deleted earlier in this operation.
+	if err := s.resourceDao.ClearTargetReferences(ctx, target.ID); err != nil {
+		return errors.GeneralError("failed to clear references: %s", err)
+	}
+
+	for _, r := range sources {
+		if policies[r.ID] != registry.OnTargetDeleteDetach {
+			continue
+		}
+		if svcErr := s.detachReferencer(ctx, target, r); svcErr != nil {
+			return svcErr
+		}
+	}
 	return nil
 }
 
+// targetDeletePolicy returns the on_target_delete policy kind declares for refType.
+// Unknown kinds and ref types fall back to restrict.
+func targetDeletePolicy(kind, refType string) registry.OnTargetDeletePolicy {
+	desc, ok := registry.Get(kind)
+	if !ok {
+		return registry.OnTargetDeleteRestrict
+	}
+	for _, ref := range desc.References {
+		if ref.RefType == refType {
+			return ref.TargetDeletePolicy()
+		}
+	}
+	return registry.OnTargetDeleteRestrict
+}
+
+// lockReferencer loads the referencer r for update. A referencer outside the caller's
+// tenancy cannot be modified, so it blocks the delete like a restrict policy would.
+func (s *sqlResourceService) lockReferencer(
+	ctx context.Context, target *api.Resource, r api.ResourceSummary,
+) (*api.Resource, *errors.ServiceError) {
+	referencer, err := s.resourceDao.GetForUpdate(ctx, r.Kind, r.ID)
+	if err == nil {
+		return referencer, nil
+	}
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.ConflictState(
+			"cannot delete %s %q: referenced by %s %q — remove the reference(s) before deleting",
+			target.Kind, target.Name, r.Kind, r.Name)
+	}
+	return nil, errors.GeneralError("Unable to lock referencing %s: %s", r.Kind, err)
+}
+
+// detachReferencer drops the referencer's references to target, bumps its generation so
+// adapters observe the change, and re-runs condition aggregation. The reference rows are
+// already gone; this brings the referencer itself in line, as a patch would.
+func (s *sqlResourceService) detachReferencer(
+	ctx context.Context, target *api.Resource, r api.ResourceSummary,
+) *errors.ServiceError {
+	referencer, svcErr := s.lockReferencer(ctx, target, r)
+	if svcErr != nil {
+		return svcErr
+	}
+	before := snapshotResource(referencer)
+
+	kept := make([]api.ResourceReference, 0, len(referencer.References))
+	for _, ref := range referencer.References {
+		if ref.TargetID != target.ID {
+			kept = append(kept, ref)
+		}
+	}
+	referencer.References = kept
+	referencer.IncrementGeneration()
+	referencer.UpdatedBy = actorFromContext(ctx)
+
+	if saveErr := s.resourceDao.Save(ctx, referencer); saveErr != nil {
+		return handleUpdateError(referencer.Kind, saveErr)
+	}
+
+	adapterStatuses, statusErr := s.adapterStatusDao.FindByResource(ctx, referencer.Kind, referencer.ID)
+	if statusErr != nil {
+		db.MarkForRollback(ctx, statusErr)
+		return errors.GeneralError("failed to get adapter statuses for condition recompute: %s", statusErr)
+	}
+	if _, recomputeErr := s.recomputeAndSaveResourceConditions(ctx, referencer, adapterStatuses); recomputeErr != nil {
+		return recomputeErr
+	}
+
+	if changeErr := s.recordChange(ctx, api.ChangeModified, referencer); changeErr != nil {
+		return changeErr
+	}
+	changes, diffErr := diffSnapshots(before, snapshotResource(referencer))
+	if diffErr != nil {
+		db.MarkForRollback(ctx, diffErr)
+		return errors.GeneralError("Failed to diff %s: %s", referencer.Kind, diffErr)
+	}
+	if auditErr := s.recordAudit(ctx, newAuditEvent(ctx, api.AuditPatch, referencer), changes); auditErr != nil {
+		return auditErr
+	}
+	return s.recordRevision(ctx, referencer)
+}
+
 // shouldSoftDelete determines whether a resource requires soft-deletion.
 // Soft-delete is required when:
 // 1. Resource has RequiredAdapters (must wait for adapter finalization)
@@ -476,16 +902,62 @@ func (s *sqlResourceService) ListByOwner(
 	if svcErr := validateKind(kind); svcErr != nil {
 		return nil, nil, svcErr
 	}
+	return s.listFiltered(ctx, kind, fmt.Sprintf("kind = '%s' AND owner_id = '%s'", kind, ownerID), args)
+}
+
+// ListByAncestor returns the kind resources owned by ancestorID through any number of
+// intermediate owners, e.g. every Machine of a Cluster across its NodePools, with
+// pagination, search, and ordering. ancestorKind must be above kind's parent.
+func (s *sqlResourceService) ListByAncestor(
+	ctx context.Context, kind, ancestorKind, ancestorID string, args *ListArguments,
+) (api.ResourceList, *api.PagingMeta, *errors.ServiceError) {
+	if svcErr := validateKind(kind); svcErr != nil {
+		return nil, nil, svcErr
+	}
+	// Kinds owned by the ancestor on the way down to kind, nearest to kind first.
+	var between []string
+	found := false
+	for _, ancestor := range registry.AncestorsOf(kind) {
+		if ancestor.Kind == ancestorKind {
+			found = true
+			break
+		}
+		between = append(between, ancestor.Kind)
+	}
+	if !found || len(between) == 0 {
+		return nil, nil, errors.Validation("%s is not an ancestor above the parent of %s", ancestorKind, kind)
+	}
+
+	// Restrict owner_id to the descendants of the ancestor one kind above kind, nesting a
+	// subquery per intermediate kind with the ancestor's ID innermost.
+	owners, values := "?", []any{ancestorID}
+	for i := len(between) - 1; i >= 0; i-- {
+		owners = "SELECT id FROM resources WHERE kind = ? AND owner_id IN (" + owners + ")"
+		values = append([]any{between[i]}, values...)
+	}
+
+	if args == nil {
+		args = NewListArguments()
+	}
+	scopedArgs := *args
+	scopedArgs.Filters = append(slices.Clone(scopedArgs.Filters),
+		dao.NewWhere(api.Resource{}.TableName()+".owner_id IN ("+owners+")", values))
+	return s.listFiltered(ctx, kind, fmt.Sprintf("kind = '%s'", kind), &scopedArgs)
+}
+
+// listFiltered lists kind resources matching both the caller's search and filter.
+func (s *sqlResourceService) listFiltered(
+	ctx context.Context, kind, filter string, args *ListArguments,
+) (api.ResourceList, *api.PagingMeta, *errors.ServiceError) {
 	if args == nil {
 		args = NewListArguments()
 	}
 	scopedArgs := *args
 	scopedArgs.Preloads = append(append([]string(nil), scopedArgs.Preloads...), "Labels", "Conditions", "References")
-	kindFilter := fmt.Sprintf("kind = '%s' AND owner_id = '%s'", kind, ownerID)
 	if scopedArgs.Search == "" {
-		scopedArgs.Search = kindFilter
+		scopedArgs.Search = filter
 	} else {
-		scopedArgs.Search = "(" + scopedArgs.Search + ") AND " + kindFilter
+		scopedArgs.Search = "(" + scopedArgs.Search + ") AND " + filter
 	}
 
 	if svcErr := s.applyRefFilter(ctx, kind, &scopedArgs); svcErr != nil {
@@ -534,6 +1006,116 @@ func (s *sqlResourceService) ListAll(
 	return result, paging, nil
 }
 
+// History returns the audit events of a resource with pagination, search, and ordering,
+// newest first by default. An empty kind matches any kind, and a non-empty ownerID limits
+// the events to those recorded while the resource belonged to that owner. History outlives
+// the resource, so 404 is only returned when no events match and the resource does not
+// exist either.
+func (s *sqlResourceService) History(
+	ctx context.Context, kind, id, ownerID string, args *ListArguments,
+) (api.ResourceEventList, *api.PagingMeta, *errors.ServiceError) {
+	if kind != "" {
+		if svcErr := validateKind(kind); svcErr != nil {
+			return nil, nil, svcErr
+		}
+	}
+	if args == nil {
+		args = NewListArguments()
+	}
+	scopedArgs := *args
+	if len(scopedArgs.Order) == 0 {
+		scopedArgs.Order = []string{"created_time desc"}
+	}
+	table := api.ResourceEvent{}.TableName()
+	conditions := []string{table + ".resource_id = ?"}
+	values := []any{id}
+	if kind != "" {
+		conditions = append(conditions, table+".kind = ?")
+		values = append(values, kind)
+	}
+	if ownerID != "" {
+		conditions = append(conditions, table+".owner_id = ?")
+		values = append(values, ownerID)
+	}
+	scopedArgs.Filters = append(slices.Clone(scopedArgs.Filters), dao.NewWhere(strings.Join(conditions, " AND "), values))
+
+	var auditEvents api.ResourceEventList
+	paging, svcErr := s.generic.List(ctx, &scopedArgs, &auditEvents)
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+	// Without a count, only an empty first page shows that no event matched.
+	matched := paging.Total > 0
+	if args.SkipCount {
+		matched = len(auditEvents) > 0 || args.Page > 1 || args.Continue != ""
+	}
+	if !matched {
+		var lookupErr *errors.ServiceError
+		switch {
+		case kind == "":
+			_, lookupErr = s.GetByID(ctx, id)
+		case ownerID != "":
+			_, lookupErr = s.GetByOwner(ctx, kind, id, ownerID)
+		default:
+			_, lookupErr = s.Get(ctx, kind, id)
+		}
+		if lookupErr != nil {
+			return nil, nil, lookupErr
+		}
+	}
+	return auditEvents, paging, nil
+}
+
+// ListRevisions returns the stored revisions of a resource with pagination, search, and
+// ordering, newest generation first by default. An empty kind matches any kind.
+func (s *sqlResourceService) ListRevisions(
+	ctx context.Context, kind, id string, args *ListArguments,
+) (api.ResourceRevisionList, *api.PagingMeta, *errors.ServiceError) {
+	if _, svcErr := s.getAnyKind(ctx, kind, id); svcErr != nil {
+		return nil, nil, svcErr
+	}
+	if args == nil {
+		args = NewListArguments()
+	}
+	scopedArgs := *args
+	if len(scopedArgs.Order) == 0 {
+		scopedArgs.Order = []string{"generation desc"}
+	}
+	scopedArgs.Filters = append(slices.Clone(scopedArgs.Filters),
+		dao.NewWhere(api.ResourceRevision{}.TableName()+".resource_id = ?", []any{id}))
+
+	var revisions api.ResourceRevisionList
+	paging, svcErr := s.generic.List(ctx, &scopedArgs, &revisions)
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+	return revisions, paging, nil
+}
+
+// GetRevision returns the revision of a resource at generation. Returns 404 if the resource
+// does not exist or has no revision for that generation, e.g. one produced by a soft delete.
+func (s *sqlResourceService) GetRevision(
+	ctx context.Context, kind, id string, generation int32,
+) (*api.ResourceRevision, *errors.ServiceError) {
+	resource, svcErr := s.getAnyKind(ctx, kind, id)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	revision, err := s.resourceRevisionDao.Get(ctx, resource.ID, generation)
+	if err != nil {
+		return nil, handleGetError(resource.Kind+" revision", "generation", generation, err)
+	}
+	return revision, nil
+}
+
+// getAnyKind looks a resource up by kind and ID, or by ID alone when kind is empty.
+func (s *sqlResourceService) getAnyKind(ctx context.Context, kind, id string) (*api.Resource, *errors.ServiceError) {
+	if kind == "" {
+		return s.GetByID(ctx, id)
+	}
+	return s.Get(ctx, kind, id)
+}
+
 // ProcessAdapterStatus validates, upserts an adapter status report, and triggers
 // status aggregation for a generic resource. Follows a 4-DB-call pattern:
 //  1. GetForUpdate        — lock + fetch resource with conditions
@@ -593,6 +1175,20 @@ func (s *sqlResourceService) ProcessAdapterStatus(
 		return nil, handleCreateError("AdapterStatus", err)
 	}
 
+	// Audit condition transitions only; repeated reports of the same state are not mutations.
+	transitions, err := adapterConditionTransitions(existingStatus, upsertedStatus)
+	if err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, errors.GeneralError("Failed to diff adapter conditions: %s", err)
+	}
+	if len(transitions) > 0 {
+		event := newAuditEvent(ctx, api.AuditAdapterStatus, resource)
+		event.Adapter = &adapterStatus.Adapter
+		if svcErr := s.recordAudit(ctx, event, transitions); svcErr != nil {
+			return nil, svcErr
+		}
+	}
+
 	// Build the post-upsert snapshot of all statuses. Using the pre-upsert
 	// list for hard-delete or aggregation would miss the just-written status.
 	updatedStatuses := replaceAdapterStatusInList(allStatuses, upsertedStatus)
@@ -612,36 +1208,43 @@ func (s *sqlResourceService) ProcessAdapterStatus(
 	// Step 4: Re-aggregate conditions from all adapter statuses and persist
 	// to the resource_conditions table. Runs when:
 	// 1. Available condition changed to True or False (not on Unknown or discarded updates), OR
-	// 2. A CEL condition mapper is configured AND (conditions or data changed from previous report)
+	// 2. A CEL condition or status field mapper is configured AND (conditions or data changed
+	//    from previous report)
 	//
 	// Rationale: mapper recompute is expensive (JSON marshal + MaskSensitiveFields + CEL eval),
 	// runs inside the GetForUpdate row-level lock, and most adapter reports are duplicates.
 	// Gating on actual changes reduces CPU waste and lock hold time (CWE-400 mitigation).
-	hasMapper := s.conditionMappers[resource.Kind] != nil
+	hasMapper := s.conditionMapper(resource.Kind) != nil || s.statusFieldMapper(resource.Kind) != nil
 
 	// Inline statusChanged computation so jsonEqual is skipped when hasMapper=false,
-	// avoiding unnecessary JSON marshaling for entities without condition mappings.
+	// avoiding unnecessary JSON marshaling for entities without condition or status field mappings.
 	if triggerAggregation || (hasMapper && (existingStatus == nil ||
 		!jsonEqual(existingStatus.Conditions, adapterStatus.Conditions) ||
 		!jsonEqual(existingStatus.Data, adapterStatus.Data))) {
-		if aggregateErr := s.recomputeAndSaveResourceConditions(
-			ctx, resource, updatedStatuses,
-		); aggregateErr != nil {
+		changed, aggregateErr := s.recomputeAndSaveResourceConditions(ctx, resource, updatedStatuses)
+		if aggregateErr != nil {
 			return nil, aggregateErr
 		}
+		// Adapter statuses are not part of the presented resource; only a change to
+		// the aggregated conditions is visible to watchers.
+		if changed {
+			if svcErr := s.recordChange(ctx, api.ChangeModified, resource); svcErr != nil {
+				return nil, svcErr
+			}
+		}
 	}
 
 	return upsertedStatus, nil
 }
 
 // recomputeAndSaveResourceConditions runs AggregateResourceStatus and persists
-// the result to the resource_conditions table. Skips the write when conditions
-// are unchanged.
+// the result to the resource_conditions table, along with the status fields.
+// Skips the writes when they are unchanged, and reports whether either changed.
 func (s *sqlResourceService) recomputeAndSaveResourceConditions(
 	ctx context.Context,
 	resource *api.Resource,
 	adapterStatuses api.AdapterStatusList,
-) *errors.ServiceError {
+) (bool, *errors.ServiceError) {
 	desc := registry.MustGet(resource.Kind)
 
 	// Convert the GORM association ([]ResourceCondition) to JSON so it can be
@@ -653,7 +1256,7 @@ func (s *sqlResourceService) recomputeAndSaveResourceConditions(
 		var marshalErr error
 		prevConditionsJSON, marshalErr = json.Marshal(resource.Conditions)
 		if marshalErr != nil {
-			return errors.GeneralError("Failed to marshal previous conditions: %s", marshalErr)
+			return false, errors.GeneralError("Failed to marshal previous conditions: %s", marshalErr)
 		}
 	}
 
@@ -668,7 +1271,7 @@ func (s *sqlResourceService) recomputeAndSaveResourceConditions(
 		var err error
 		hasChildResources, err = s.hasActiveChildren(ctx, resource)
 		if err != nil {
-			return errors.GeneralError("Failed to check children for status aggregation: %s", err)
+			return false, errors.GeneralError("Failed to check children for status aggregation: %s", err)
 		}
 	}
 
@@ -692,7 +1295,7 @@ func (s *sqlResourceService) recomputeAndSaveResourceConditions(
 	)
 
 	// Build the full conditions slice: Reconciled + LastKnownReconciled + per-adapter + mapped conditions.
-	mapper := s.conditionMappers[resource.Kind]
+	mapper := s.conditionMapper(resource.Kind)
 	var mappedCapacity int
 	if mapper != nil {
 		mappedCapacity = len(mapper.sortedNames)
@@ -713,38 +1316,90 @@ func (s *sqlResourceService) recomputeAndSaveResourceConditions(
 			// Mark transaction for rollback - ensures adapter status update is retried
 			// in 10s instead of 30min delay that would occur with partial commit
 			db.MarkForRollback(ctx, fmt.Errorf("condition mapping failed for %s: %w", resource.Kind, err))
-			return errors.GeneralError("Condition mapping failed: %s", err)
+			return false, errors.GeneralError("Condition mapping failed: %s", err)
 		}
 		newConditions = append(newConditions, mappedConditions...)
 	}
 
+	fieldsChanged, svcErr := s.saveStatusFields(ctx, resource, adapterStatuses)
+	if svcErr != nil {
+		return false, svcErr
+	}
+
 	// Compare via JSON to detect actual changes.
 	newJSON, marshalErr := json.Marshal(newConditions)
 	if marshalErr != nil {
-		return errors.GeneralError("Failed to marshal conditions: %s", marshalErr)
+		return false, errors.GeneralError("Failed to marshal conditions: %s", marshalErr)
 	}
 	if jsonEqual(prevConditionsJSON, newJSON) {
-		return nil
+		if !fieldsChanged {
+			return false, nil
+		}
+		return true, s.touchStatus(ctx, resource)
 	}
 
 	// Write to resource_conditions table (not JSONB on the resource row).
 	// MarkForRollback is handled by the DAO internally.
 	if err := s.resourceConditionDao.UpdateConditions(ctx, resource.ID, newConditions); err != nil {
-		return errors.GeneralError("Failed to update resource conditions: %s", err)
+		return false, errors.GeneralError("Failed to update resource conditions: %s", err)
 	}
 
 	// Update the in-memory resource so callers see the new conditions.
 	resource.Conditions = newConditions
 
-	// Emit metric on Reconciled=False transition (reconciliation started).
-	if reconciled.Status == api.ConditionFalse &&
+	// Emit metric on Reconciled=False transition (reconciliation started). A dry run
+	// starts no reconciliation.
+	if !db.IsDryRun(ctx) && reconciled.Status == api.ConditionFalse &&
 		(prevReconciledStatus == nil || *prevReconciledStatus != api.ConditionFalse) {
 		metrics.RecordReconciliationStarted(resource.Kind, resource.DeletedTime != nil)
 	}
 
+	return true, s.touchStatus(ctx, resource)
+}
+
+// touchStatus records that the resource's conditions or status fields changed, so that
+// its ETag moves even though the resource itself, and so its updated_time, did not.
+func (s *sqlResourceService) touchStatus(ctx context.Context, resource *api.Resource) *errors.ServiceError {
+	now := time.Now().Truncate(time.Microsecond)
+	if err := s.resourceDao.UpdateStatusTime(ctx, resource.ID, now); err != nil {
+		return errors.GeneralError("Failed to update status time: %s", err)
+	}
+	resource.StatusUpdatedTime = &now
 	return nil
 }
 
+// saveStatusFields evaluates the status field rules of the resource's kind and persists the
+// result when it differs from the stored fields. A kind without rules has no fields, so
+// fields left over from removed rules are cleared. Reports whether the fields changed.
+func (s *sqlResourceService) saveStatusFields(
+	ctx context.Context,
+	resource *api.Resource,
+	adapterStatuses api.AdapterStatusList,
+) (bool, *errors.ServiceError) {
+	fields := map[string]interface{}{}
+	if mapper := s.statusFieldMapper(resource.Kind); mapper != nil {
+		fields = mapper.Apply(ctx, adapterStatuses, resource)
+	}
+
+	newJSON, err := json.Marshal(fields)
+	if err != nil {
+		return false, errors.GeneralError("Failed to marshal status fields: %s", err)
+	}
+	prevJSON := []byte(resource.StatusFields)
+	if len(prevJSON) == 0 {
+		prevJSON = []byte("{}")
+	}
+	if jsonEqual(prevJSON, newJSON) {
+		return false, nil
+	}
+
+	if err = s.resourceDao.UpdateStatusFields(ctx, resource.ID, newJSON); err != nil {
+		return false, errors.GeneralError("Failed to update status fields: %s", err)
+	}
+	resource.StatusFields = newJSON
+	return true, nil
+}
+
 // tryHardDeleteResource checks whether all required adapters have reported
 // Finalized=True for a soft-deleted resource and no children remain, then
 // permanently removes the resource and its adapter statuses/conditions.
@@ -805,6 +1460,10 @@ func (s *sqlResourceService) tryHardDeleteResource(
 		return false, errors.GeneralError("Failed to hard-delete %s: %s", resource.Kind, err)
 	}
 
+	if svcErr := s.recordChange(ctx, api.ChangeDeleted, resource); svcErr != nil {
+		return false, svcErr
+	}
+
 	logger.With(ctx, "resource_type", resource.Kind, "resource_id", resource.ID).
 		Info("Hard-deleted resource after all required adapters reported Finalized=True")
 
@@ -862,6 +1521,41 @@ func (s *sqlResourceService) applyRefFilter(
 	return nil
 }
 
+// recordChange appends resource, as the API presents it now, to the change log read by
+// watch streams and, when events are enabled, queues the matching CloudEvent. It runs
+// inside the write's transaction, so both happen only if the write commits.
+func (s *sqlResourceService) recordChange(
+	ctx context.Context, changeType string, resource *api.Resource,
+) *errors.ServiceError {
+	object, err := json.Marshal(presenters.PresentResource(resource))
+	if err != nil {
+		db.MarkForRollback(ctx, err)
+		return errors.GeneralError("Failed to marshal %s change: %s", resource.Kind, err)
+	}
+	tenancy := resource.Tenancy
+	if len(tenancy) == 0 {
+		tenancy = []byte("{}")
+	}
+	change := &api.ResourceChange{
+		Type:       changeType,
+		Kind:       resource.Kind,
+		ResourceID: resource.ID,
+		OwnerID:    resource.OwnerID,
+		Tenancy:    tenancy,
+		Object:     object,
+	}
+	if err := s.resourceChangeDao.Record(ctx, change); err != nil {
+		return errors.GeneralError("Failed to record %s change: %s", resource.Kind, err)
+	}
+	if s.outbox != nil {
+		if err := s.outbox.Enqueue(ctx, changeType, resource, object); err != nil {
+			db.MarkForRollback(ctx, err)
+			return errors.GeneralError("Failed to queue %s event: %s", resource.Kind, err)
+		}
+	}
+	return nil
+}
+
 // validateKind checks that the kind is a registered entity type.
 // Returns 400 if the kind is unknown, preventing invalid kinds from reaching the DAO.
 func validateKind(kind string) *errors.ServiceError {
@@ -953,7 +1647,8 @@ func (s *sqlResourceService) ForceDelete(ctx context.Context, kind, id, reason s
 	}
 
 	caller := actorFromContext(ctx)
-	if svcErr := s.forceDeleteResourceTree(ctx, resource, caller, reason); svcErr != nil {
+	visited := make(map[string]bool)
+	if svcErr := s.forceDeleteResourceTree(ctx, resource, caller, reason, visited); svcErr != nil {
 		db.MarkForRollback(ctx, svcErr)
 		return svcErr
 	}
@@ -961,8 +1656,9 @@ func (s *sqlResourceService) ForceDelete(ctx context.Context, kind, id, reason s
 }
 
 func (s *sqlResourceService) forceDeleteResourceTree(
-	ctx context.Context, resource *api.Resource, caller, reason string,
+	ctx context.Context, resource *api.Resource, caller, reason string, visited map[string]bool,
 ) *errors.ServiceError {
+	visited[resource.ID] = true
 	children := registry.ChildrenOf(resource.Kind)
 
 	childIDs := make([]string, 0)
@@ -975,12 +1671,19 @@ func (s *sqlResourceService) forceDeleteResourceTree(
 		}
 		for _, item := range items {
 			childIDs = append(childIDs, item.ID)
-			if svcErr := s.forceDeleteResourceTree(ctx, item, caller, reason); svcErr != nil {
+			if svcErr := s.forceDeleteResourceTree(ctx, item, caller, reason, visited); svcErr != nil {
 				return svcErr
 			}
 		}
 	}
 
+	// Force-delete bypasses adapter finalization, not the referencers' on_target_delete
+	// policies: restrict still blocks, and cascaded referencers are soft-deleted normally.
+	deletedAt := time.Now().UTC().Truncate(time.Microsecond)
+	if svcErr := s.applyTargetDeletePolicies(ctx, resource, caller, deletedAt, visited); svcErr != nil {
+		return svcErr
+	}
+
 	logger.With(ctx,
 		"resource_kind", resource.Kind,
 		"resource_id", resource.ID,
@@ -989,27 +1692,23 @@ func (s *sqlResourceService) forceDeleteResourceTree(
 		"child_resource_ids", childIDs,
 	).Info("Force-deleting resource")
 
+	event := newAuditEvent(ctx, api.AuditForceDelete, resource)
+	event.Reason = &reason
+	if svcErr := s.recordAudit(ctx, event, nil); svcErr != nil {
+		return svcErr
+	}
+
 	if err := s.adapterStatusDao.DeleteByResource(ctx, resource.Kind, resource.ID); err != nil {
 		return errors.GeneralError("Failed to delete adapter statuses during force-delete: %s", err)
 	}
 	if err := s.resourceConditionDao.DeleteByResource(ctx, resource.ID); err != nil {
 		return errors.GeneralError("Failed to delete resource conditions during force-delete: %s", err)
 	}
-	// Clear inbound references before hard-deleting (FK uses ON DELETE RESTRICT).
-	// Note: referencing resources with Min>0 on this ref type will silently
-	// violate their required-reference invariant after this operation.
-	if err := s.resourceDao.ClearTargetReferences(ctx, resource.ID); err != nil {
-		return errors.GeneralError("failed to clear references: %s", err)
-	}
-	logger.With(ctx,
-		"resource_kind", resource.Kind,
-		"resource_id", resource.ID,
-	).Info("Cleared inbound references for force-delete")
 	if err := s.resourceDao.Delete(ctx, resource.Kind, resource.ID); err != nil {
 		return handleDeleteError(resource.Kind, err)
 	}
 
-	return nil
+	return s.recordChange(ctx, api.ChangeDeleted, resource)
 }
 
 // validateReferences checks that refs satisfies the ReferenceDescriptors on the entity:
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+const (
+	// MaxGroupByFields bounds the number of fields an aggregate may group by.
+	MaxGroupByFields = 5
+	// MaxAggregateGroups bounds the number of groups of an aggregate; larger aggregates
+	// keep their largest groups.
+	MaxAggregateGroups = 1000
+)
+
+// Aggregate counts the resources a list would return for args, ignoring pagination and
+// ordering, per distinct value of the groupBy fields: kind, owner_id, labels.<key>,
+// spec.<path> or status.conditions.<Type>. An empty kind counts resources of every
+// kind, and a non-empty ownerID only the children of that owner. Without groupBy all
+// matching resources form a single group. Groups are ordered by count, largest first,
+// then by their values.
+func (s *sqlResourceService) Aggregate(
+	ctx context.Context, kind, ownerID string, groupBy []string, args *ListArguments,
+) (*api.ResourceAggregate, *errors.ServiceError) {
+	if len(groupBy) > MaxGroupByFields {
+		return nil, errors.Validation("groupBy accepts at most %d fields", MaxGroupByFields)
+	}
+	for i, field := range groupBy {
+		if slices.Contains(groupBy[:i], field) {
+			return nil, errors.Validation("groupBy field %s is repeated", field)
+		}
+	}
+	if args == nil {
+		args = NewListArguments()
+	}
+
+	scopedArgs := *args
+	if kind != "" {
+		if svcErr := validateKind(kind); svcErr != nil {
+			return nil, svcErr
+		}
+		filter := fmt.Sprintf("kind = '%s'", kind)
+		if scopedArgs.Search == "" {
+			scopedArgs.Search = filter
+		} else {
+			scopedArgs.Search = "(" + scopedArgs.Search + ") AND " + filter
+		}
+	}
+	if ownerID != "" {
+		// ownerID comes from the request path, so it is bound rather than written into Search.
+		scopedArgs.Filters = append(slices.Clone(scopedArgs.Filters),
+			dao.NewWhere(api.Resource{}.TableName()+".owner_id = ?", []any{ownerID}))
+	}
+	if kind != "" {
+		if svcErr := s.applyRefFilter(ctx, kind, &scopedArgs); svcErr != nil {
+			return nil, svcErr
+		}
+	}
+
+	var resources []api.Resource
+	counts, svcErr := s.generic.Aggregate(ctx, &scopedArgs, &resources, groupBy, MaxAggregateGroups+1)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	aggregate := &api.ResourceAggregate{GroupBy: groupBy, Groups: counts}
+	if len(counts) <= MaxAggregateGroups {
+		for _, group := range counts {
+			aggregate.Total += group.Count
+		}
+		return aggregate, nil
+	}
+
+	// The smallest groups were dropped; count them into the total without grouping.
+	aggregate.Groups = counts[:MaxAggregateGroups]
+	aggregate.Truncated = true
+	totals, svcErr := s.generic.Aggregate(ctx, &scopedArgs, &resources, nil, 1)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	if len(totals) > 0 {
+		aggregate.Total = totals[0].Count
+	}
+	return aggregate, nil
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+)
+
+func TestResourceService_Aggregate_ScopesSearch(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	svc, _, generic := newTestResourceService(newMockResourceDao())
+	east, west := "us-east", "us-west"
+	generic.groupCounts = []api.GroupCount{
+		{Values: []*string{&east}, Count: 3},
+		{Values: []*string{&west}, Count: 2},
+		{Values: []*string{nil}, Count: 1},
+	}
+
+	args := &ListArguments{Search: "name = 'v'", LabelSelector: "env=prod"}
+	aggregate, svcErr := svc.Aggregate(context.Background(), "Version", "ch-1", []string{"labels.region"}, args)
+	Expect(svcErr).To(BeNil())
+	Expect(args.Search).To(Equal("name = 'v'"))
+	Expect(generic.lastSearch).To(Equal("(name = 'v') AND kind = 'Version'"))
+	Expect(generic.lastFilters).To(Equal([]dao.Where{dao.NewWhere("resources.owner_id = ?", []any{"ch-1"})}))
+	Expect(generic.lastGroupBy).To(Equal([]string{"labels.region"}))
+	Expect(generic.lastLimit).To(Equal(MaxAggregateGroups + 1))
+	Expect(aggregate.Groups).To(HaveLen(3))
+	Expect(aggregate.Total).To(Equal(int64(6)))
+	Expect(aggregate.Truncated).To(BeFalse())
+}
+
+func TestResourceService_Aggregate_AllKinds(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	svc, _, generic := newTestResourceService(newMockResourceDao())
+
+	_, svcErr := svc.Aggregate(context.Background(), "", "", []string{"kind"}, nil)
+	Expect(svcErr).To(BeNil())
+	Expect(generic.lastSearch).To(BeEmpty())
+	Expect(generic.lastFilters).To(BeEmpty())
+}
+
+func TestResourceService_Aggregate_Truncates(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	svc, _, generic := newTestResourceService(newMockResourceDao())
+	generic.groupCounts = make([]api.GroupCount, MaxAggregateGroups+5)
+	for i := range generic.groupCounts {
+		generic.groupCounts[i] = api.GroupCount{Values: []*string{nil}, Count: 1}
+	}
+
+	aggregate, svcErr := svc.Aggregate(context.Background(), "Channel", "", []string{"spec.region"}, nil)
+	Expect(svcErr).To(BeNil())
+	Expect(aggregate.Groups).To(HaveLen(MaxAggregateGroups))
+	Expect(aggregate.Total).To(Equal(int64(MaxAggregateGroups + 5)))
+	Expect(aggregate.Truncated).To(BeTrue())
+}
+
+func TestResourceService_Aggregate_InvalidGroupBy(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	svc, _, generic := newTestResourceService(newMockResourceDao())
+
+	tests := map[string][]string{
+		"repeated": {"kind", "kind"},
+		"too many": {"kind", "owner_id", "labels.a", "labels.b", "labels.c", "labels.d"},
+	}
+	for name, groupBy := range tests {
+		_, svcErr := svc.Aggregate(context.Background(), "Channel", "", groupBy, nil)
+		Expect(svcErr).ToNot(BeNil(), name)
+		Expect(svcErr.HTTPCode).To(Equal(400), name)
+	}
+	Expect(generic.lastGroupBy).To(BeNil())
+
+	_, svcErr := svc.Aggregate(context.Background(), "Bogus", "", []string{"kind"}, nil)
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(400))
+}
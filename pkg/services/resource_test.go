@@ -16,8 +16,10 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/auth"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/events"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/tenant"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/util"
 )
 
 const (
@@ -53,8 +55,10 @@ type mockResourceDao struct {
 	deleteErr                   error
 	existsSoftDeletedByOwnerErr error
 	replaceRefsErr              error
+	referencersByTarget         map[string][]api.ResourceSummary
 	findReferencersResult       []api.ResourceSummary
 	lastReplacedRefs            []api.ResourceReference
+	clearedTargets              []string
 	replaceRefsCalled           bool
 }
 
@@ -196,17 +200,45 @@ func (d *mockResourceDao) ReplaceReferences(_ context.Context, _ string, refs []
 	return nil
 }
 
-func (d *mockResourceDao) FindReferencers(_ context.Context, _ string) ([]api.ResourceSummary, error) {
+func (d *mockResourceDao) FindReferencers(_ context.Context, targetID string) ([]api.ResourceSummary, error) {
+	if d.referencersByTarget != nil {
+		return d.referencersByTarget[targetID], nil
+	}
 	return d.findReferencersResult, nil
 }
 
-func (d *mockResourceDao) ClearTargetReferences(_ context.Context, _ string) error {
+func (d *mockResourceDao) ListReferencers(ctx context.Context, targetID string) ([]api.ResourceSummary, error) {
+	return d.FindReferencers(ctx, targetID)
+}
+
+func (d *mockResourceDao) ClearTargetReferences(_ context.Context, targetID string) error {
+	d.clearedTargets = append(d.clearedTargets, targetID)
 	return nil
 }
 
 func (d *mockResourceDao) FindSourceIDsByRef(_ context.Context, _, _ string) ([]string, error) {
 	return nil, nil
 }
+
+func (d *mockResourceDao) UpdateStatusFields(_ context.Context, id string, fields datatypes.JSON) error {
+	for _, r := range d.resources {
+		if r.ID == id {
+			r.StatusFields = fields
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (d *mockResourceDao) UpdateStatusTime(_ context.Context, id string, t time.Time) error {
+	for _, r := range d.resources {
+		if r.ID == id {
+			r.StatusUpdatedTime = &t
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
 func (d *mockResourceDao) addResource(r *api.Resource) {
 	d.resources[resourceKey(r.Kind, r.ID)] = r
 }
@@ -238,9 +270,13 @@ func (d *mockResourceLabelDao) ReplaceLabels(_ context.Context, resourceID strin
 var _ dao.ResourceLabelDao = &mockResourceLabelDao{}
 
 type resourceGenericMock struct {
-	listErr    *errors.ServiceError
-	lastSearch string
-	listCalled bool
+	listErr     *errors.ServiceError
+	lastSearch  string
+	lastFilters []dao.Where
+	lastGroupBy []string
+	groupCounts []api.GroupCount
+	lastLimit   int
+	listCalled  bool
 }
 
 func (g *resourceGenericMock) List(
@@ -248,12 +284,36 @@ func (g *resourceGenericMock) List(
 ) (*api.PagingMeta, *errors.ServiceError) {
 	g.listCalled = true
 	g.lastSearch = args.Search
+	g.lastFilters = args.Filters
 	if g.listErr != nil {
 		return nil, g.listErr
 	}
 	return &api.PagingMeta{Page: 1, Size: 0, Total: 0}, nil
 }
 
+func (g *resourceGenericMock) Aggregate(
+	_ context.Context, args *ListArguments, _ interface{}, groupBy []string, limit int,
+) ([]api.GroupCount, *errors.ServiceError) {
+	g.lastSearch = args.Search
+	g.lastFilters = args.Filters
+	g.lastGroupBy = groupBy
+	g.lastLimit = limit
+	if g.listErr != nil {
+		return nil, g.listErr
+	}
+	if len(groupBy) == 0 {
+		total := api.GroupCount{Values: []*string{}}
+		for _, group := range g.groupCounts {
+			total.Count += group.Count
+		}
+		return []api.GroupCount{total}, nil
+	}
+	if len(g.groupCounts) > limit {
+		return g.groupCounts[:limit], nil
+	}
+	return g.groupCounts, nil
+}
+
 var _ GenericService = &resourceGenericMock{}
 
 // resourceConditionMock implements dao.ResourceConditionDao for testing.
@@ -279,10 +339,136 @@ func (d *resourceConditionMock) DeleteByResource(_ context.Context, resourceID s
 
 var _ dao.ResourceConditionDao = &resourceConditionMock{}
 
+// resourceChangeMock implements dao.ResourceChangeDao, keeping recorded changes in order.
+type resourceChangeMock struct {
+	changes []*api.ResourceChange
+}
+
+func newResourceChangeMock() *resourceChangeMock {
+	return &resourceChangeMock{}
+}
+
+func (d *resourceChangeMock) Record(_ context.Context, change *api.ResourceChange) error {
+	change.ID = int64(len(d.changes) + 1)
+	d.changes = append(d.changes, change)
+	return nil
+}
+
+func (d *resourceChangeMock) FindAfter(
+	_ context.Context, after api.ChangePosition, filter dao.ChangeFilter, limit int,
+) ([]*api.ResourceChange, error) {
+	var result []*api.ResourceChange
+	for _, c := range d.changes {
+		if !after.Before(c.Position()) {
+			continue
+		}
+		if filter.Kind != "" && c.Kind != filter.Kind {
+			continue
+		}
+		if filter.OwnerID != "" && (c.OwnerID == nil || *c.OwnerID != filter.OwnerID) {
+			continue
+		}
+		result = append(result, c)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (d *resourceChangeMock) Head(_ context.Context) (api.ChangePosition, error) {
+	if len(d.changes) == 0 {
+		return api.ChangePosition{}, nil
+	}
+	return d.changes[len(d.changes)-1].Position(), nil
+}
+
+func (d *resourceChangeMock) Oldest(_ context.Context) (api.ChangePosition, bool, error) {
+	if len(d.changes) == 0 {
+		return api.ChangePosition{}, false, nil
+	}
+	return d.changes[0].Position(), true, nil
+}
+
+func (d *resourceChangeMock) DeleteBefore(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (d *resourceChangeMock) types() []string {
+	types := make([]string, len(d.changes))
+	for i, c := range d.changes {
+		types[i] = c.Type
+	}
+	return types
+}
+
+var _ dao.ResourceChangeDao = &resourceChangeMock{}
+
+// resourceOutboxMock implements dao.ResourceOutboxDao, keeping queued events in order.
+type resourceOutboxMock struct {
+	events []*api.OutboxEvent
+}
+
+func (d *resourceOutboxMock) Enqueue(_ context.Context, event *api.OutboxEvent) error {
+	event.ID = int64(len(d.events) + 1)
+	d.events = append(d.events, event)
+	return nil
+}
+
+func (d *resourceOutboxMock) FindDue(_ context.Context, limit int) ([]*api.OutboxEvent, error) {
+	return d.events[:min(limit, len(d.events))], nil
+}
+
+func (d *resourceOutboxMock) Delete(_ context.Context, _ int64) error {
+	return nil
+}
+
+func (d *resourceOutboxMock) MarkFailed(_ context.Context, _ int64, _ time.Duration, _ string) error {
+	return nil
+}
+
+var _ dao.ResourceOutboxDao = &resourceOutboxMock{}
+
+// resourceEventMock implements dao.ResourceEventDao, keeping recorded audit events in order.
+type resourceEventMock struct {
+	events []*api.ResourceEvent
+}
+
+func (d *resourceEventMock) Record(_ context.Context, event *api.ResourceEvent) error {
+	d.events = append(d.events, event)
+	return nil
+}
+
+var _ dao.ResourceEventDao = &resourceEventMock{}
+
+// resourceRevisionMock implements dao.ResourceRevisionDao, keeping recorded revisions in order.
+type resourceRevisionMock struct {
+	revisions []*api.ResourceRevision
+}
+
+func (d *resourceRevisionMock) Record(_ context.Context, revision *api.ResourceRevision) error {
+	d.revisions = append(d.revisions, revision)
+	return nil
+}
+
+func (d *resourceRevisionMock) Get(
+	_ context.Context, resourceID string, generation int32,
+) (*api.ResourceRevision, error) {
+	for _, r := range d.revisions {
+		if r.ResourceID == resourceID && r.Generation == generation {
+			return r, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+var _ dao.ResourceRevisionDao = &resourceRevisionMock{}
+
 func newTestResourceService(mockDao *mockResourceDao) (ResourceService, *mockResourceDao, *resourceGenericMock) {
 	generic := &resourceGenericMock{}
 	svc, err := NewResourceService(
-		mockDao, newMockResourceLabelDao(), newMockAdapterStatusDao(), newResourceConditionMock(), generic,
+		mockDao, newMockResourceLabelDao(), newMockAdapterStatusDao(), newResourceConditionMock(),
+		newResourceChangeMock(), &resourceEventMock{}, &resourceRevisionMock{}, nil, generic, nil,
 	)
 	if err != nil {
 		panic("newTestResourceService: " + err.Error())
@@ -296,7 +482,8 @@ func newTestResourceServiceWithLabelDao(
 	generic := &resourceGenericMock{}
 	labelDao := newMockResourceLabelDao()
 	svc, err := NewResourceService(
-		mockDao, labelDao, newMockAdapterStatusDao(), newResourceConditionMock(), generic,
+		mockDao, labelDao, newMockAdapterStatusDao(), newResourceConditionMock(), newResourceChangeMock(),
+		&resourceEventMock{}, &resourceRevisionMock{}, nil, generic, nil,
 	)
 	if err != nil {
 		panic("newTestResourceServiceWithLabelDao: " + err.Error())
@@ -310,7 +497,10 @@ func newTestResourceServiceWithAdapterStatus(
 	asDao := newMockAdapterStatusDao()
 	rcDao := newResourceConditionMock()
 	generic := &resourceGenericMock{}
-	svc, err := NewResourceService(mockDao, newMockResourceLabelDao(), asDao, rcDao, generic)
+	svc, err := NewResourceService(
+		mockDao, newMockResourceLabelDao(), asDao, rcDao, newResourceChangeMock(),
+		&resourceEventMock{}, &resourceRevisionMock{}, nil, generic, nil,
+	)
 	if err != nil {
 		panic("newTestResourceServiceWithAdapterStatus: " + err.Error())
 	}
@@ -323,13 +513,42 @@ func newTestResourceServiceWithConditions(
 	asDao := newMockAdapterStatusDao()
 	rcDao := newResourceConditionMock()
 	generic := &resourceGenericMock{}
-	svc, err := NewResourceService(mockDao, newMockResourceLabelDao(), asDao, rcDao, generic)
+	svc, err := NewResourceService(
+		mockDao, newMockResourceLabelDao(), asDao, rcDao, newResourceChangeMock(),
+		&resourceEventMock{}, &resourceRevisionMock{}, nil, generic, nil,
+	)
 	if err != nil {
 		panic("newTestResourceServiceWithConditions: " + err.Error())
 	}
 	return svc, mockDao, asDao, rcDao
 }
 
+// resourceServiceMocks are the collaborators of a service built by
+// newTestResourceServiceWithMocks, for tests that inspect what the service recorded.
+type resourceServiceMocks struct {
+	changes   *resourceChangeMock
+	audit     *resourceEventMock
+	revisions *resourceRevisionMock
+	generic   *resourceGenericMock
+}
+
+func newTestResourceServiceWithMocks(mockDao *mockResourceDao) (ResourceService, *resourceServiceMocks) {
+	mocks := &resourceServiceMocks{
+		changes:   newResourceChangeMock(),
+		audit:     &resourceEventMock{},
+		revisions: &resourceRevisionMock{},
+		generic:   &resourceGenericMock{},
+	}
+	svc, err := NewResourceService(
+		mockDao, newMockResourceLabelDao(), newMockAdapterStatusDao(), newResourceConditionMock(),
+		mocks.changes, mocks.audit, mocks.revisions, nil, mocks.generic, nil,
+	)
+	if err != nil {
+		panic("newTestResourceServiceWithMocks: " + err.Error())
+	}
+	return svc, mocks
+}
+
 func testResource(kind, id, name string) *api.Resource {
 	spec, _ := json.Marshal(map[string]interface{}{"key": "value"})
 	r := &api.Resource{
@@ -833,6 +1052,55 @@ func TestResourceService_Patch_NoChange_KeepsGeneration(t *testing.T) {
 	Expect(result.Generation).To(Equal(int32(3)))
 }
 
+// specDefaulterMock defaults a missing "tier" to "standard" and records the plurals it saw.
+type specDefaulterMock struct {
+	plurals []string
+}
+
+func (m *specDefaulterMock) ApplyDefaults(resourcePlural string, spec []byte) ([]byte, error) {
+	m.plurals = append(m.plurals, resourcePlural)
+	var specData map[string]interface{}
+	if err := json.Unmarshal(spec, &specData); err != nil {
+		return nil, err
+	}
+	if _, ok := specData["tier"]; ok {
+		return spec, nil
+	}
+	specData["tier"] = "standard"
+	return json.Marshal(specData)
+}
+
+func TestResourceService_AppliesSpecDefaults(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	defaulter := &specDefaulterMock{}
+	svc, err := NewResourceService(
+		mockDao, newMockResourceLabelDao(), newMockAdapterStatusDao(), newResourceConditionMock(),
+		newResourceChangeMock(), &resourceEventMock{}, &resourceRevisionMock{}, nil, &resourceGenericMock{},
+		defaulter,
+	)
+	Expect(err).NotTo(HaveOccurred())
+
+	resource := testResource("Channel", "", "stable")
+	resource.Spec = []byte(`{"key":"value"}`)
+	created, svcErr := svc.Create(context.Background(), "Channel", resource, nil)
+	Expect(svcErr).To(BeNil())
+	Expect(string(created.Spec)).To(MatchJSON(`{"key":"value","tier":"standard"}`))
+	Expect(defaulter.plurals).To(Equal([]string{"channels"}))
+
+	patched, svcErr := svc.Patch(context.Background(), "Channel", created.ID,
+		&api.ResourcePatch{Spec: map[string]interface{}{"key": "new-value"}})
+	Expect(svcErr).To(BeNil())
+	Expect(string(patched.Spec)).To(MatchJSON(`{"key":"new-value","tier":"standard"}`))
+
+	_, svcErr = svc.Patch(context.Background(), "Channel", created.ID,
+		&api.ResourcePatch{Labels: map[string]string{"env": "prod"}})
+	Expect(svcErr).To(BeNil())
+	Expect(defaulter.plurals).To(HaveLen(2), "a patch without a spec leaves the stored spec alone")
+}
+
 func TestResourceService_Patch_DeletedResource_409(t *testing.T) {
 	RegisterTestingT(t)
 	setupTestDescriptors()
@@ -855,6 +1123,377 @@ func TestResourceService_Patch_DeletedResource_409(t *testing.T) {
 	Expect(svcErr.Reason).To(ContainSubstring("marked for deletion"))
 }
 
+func TestResourceService_Patch_ImmutableFields(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+	registry.UpdateDescriptor("Channel", func(d *registry.EntityDescriptor) {
+		d.ImmutableFields = []string{"key", "platform.type"}
+	})
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+	existing := testResource("Channel", "ch-1", "stable")
+	existing.Generation = 1
+	mockDao.addResource(existing)
+
+	patch := &api.ResourcePatch{Spec: map[string]interface{}{
+		"key":      "new-value",
+		"platform": map[string]interface{}{"type": "gcp"},
+	}}
+	result, svcErr := svc.Patch(context.Background(), "Channel", "ch-1", patch)
+	Expect(result).To(BeNil())
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(422))
+	Expect(svcErr.RFC9457Code).To(Equal(errors.CodeValidationMultiple))
+	fields := make([]string, 0, len(svcErr.Details))
+	for _, detail := range svcErr.Details {
+		fields = append(fields, detail.Field)
+	}
+	Expect(fields).To(ConsistOf("spec.key", "spec.platform.type"),
+		"a field set after initially being absent is also a change")
+
+	patch = &api.ResourcePatch{Spec: map[string]interface{}{"key": "value", "description": "mutable"}}
+	result, svcErr = svc.Patch(context.Background(), "Channel", "ch-1", patch)
+	Expect(svcErr).To(BeNil())
+	Expect(result.Generation).To(Equal(int32(2)))
+}
+
+func TestResourceService_Patch_IfMatch(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+
+	existing := testResource("Channel", "ch-1", "stable")
+	existing.Generation = 1
+	existing.UpdatedTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockDao.addResource(existing)
+	current := existing.ETag()
+
+	patch := &api.ResourcePatch{Spec: map[string]interface{}{"key": "new-value"}}
+
+	// A stale version is rejected with CNF-002 before anything is written.
+	stale := WithIfMatch(context.Background(), []string{`"0-0"`})
+	result, svcErr := svc.Patch(stale, "Channel", "ch-1", patch)
+	Expect(result).To(BeNil())
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.RFC9457Code).To(Equal(errors.CodeConflictVersion))
+	Expect(existing.Generation).To(Equal(int32(1)))
+
+	// The current version, in weak form among other tags, is accepted.
+	fresh := WithIfMatch(context.Background(), []string{`"0-0"`, "W/" + current})
+	result, svcErr = svc.Patch(fresh, "Channel", "ch-1", patch)
+	Expect(svcErr).To(BeNil())
+	Expect(result.Generation).To(Equal(int32(2)))
+}
+
+func TestResourceService_Delete_IfMatch(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+
+	existing := testResource("Channel", "ch-1", "stable")
+	mockDao.addResource(existing)
+
+	_, svcErr := svc.Delete(WithIfMatch(context.Background(), []string{`"0-0"`}), "Channel", "ch-1")
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.RFC9457Code).To(Equal(errors.CodeConflictVersion))
+	Expect(existing.DeletedTime).To(BeNil())
+
+	_, svcErr = svc.Delete(WithIfMatch(context.Background(), []string{"*"}), "Channel", "ch-1")
+	Expect(svcErr).To(BeNil())
+}
+
+func TestResourceService_RecordsChanges(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, mocks := newTestResourceServiceWithMocks(mockDao)
+
+	created, svcErr := svc.Create(context.Background(), "Channel", testResource("Channel", "ch-1", "stable"), nil)
+	Expect(svcErr).To(BeNil())
+
+	patch := &api.ResourcePatch{Spec: map[string]interface{}{"key": "new-value"}}
+	_, svcErr = svc.Patch(context.Background(), "Channel", created.ID, patch)
+	Expect(svcErr).To(BeNil())
+
+	// A patch that changes nothing is not a change.
+	_, svcErr = svc.Patch(context.Background(), "Channel", created.ID, patch)
+	Expect(svcErr).To(BeNil())
+
+	_, svcErr = svc.Delete(context.Background(), "Channel", created.ID)
+	Expect(svcErr).To(BeNil())
+
+	Expect(mocks.changes.types()).To(Equal([]string{api.ChangeAdded, api.ChangeModified, api.ChangeDeleted}))
+	for _, c := range mocks.changes.changes {
+		Expect(c.Kind).To(Equal("Channel"))
+		Expect(c.ResourceID).To(Equal(created.ID))
+	}
+
+	var object map[string]interface{}
+	Expect(json.Unmarshal(mocks.changes.changes[1].Object, &object)).To(Succeed())
+	Expect(object["id"]).To(Equal(created.ID))
+	Expect(object["spec"]).To(Equal(map[string]interface{}{"key": "new-value"}))
+	Expect(object["generation"]).To(BeEquivalentTo(2))
+}
+
+func TestResourceService_SoftDelete_RecordsModified(t *testing.T) {
+	RegisterTestingT(t)
+	setupManagedDescriptor()
+
+	mockDao := newMockResourceDao()
+	svc, mocks := newTestResourceServiceWithMocks(mockDao)
+
+	mockDao.addResource(testResource("Managed", "m-1", "managed-1"))
+
+	_, svcErr := svc.Delete(context.Background(), "Managed", "m-1")
+	Expect(svcErr).To(BeNil())
+	Expect(mocks.changes.types()).To(Equal([]string{api.ChangeModified}))
+}
+
+func TestResourceService_QueuesEventsInOutbox(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	outbox := &resourceOutboxMock{}
+	svc, err := NewResourceService(
+		mockDao, newMockResourceLabelDao(), newMockAdapterStatusDao(), newResourceConditionMock(),
+		newResourceChangeMock(), &resourceEventMock{}, &resourceRevisionMock{},
+		events.NewOutbox(outbox, "hyperfleet-test"), &resourceGenericMock{}, nil,
+	)
+	Expect(err).NotTo(HaveOccurred())
+
+	created, svcErr := svc.Create(context.Background(), "Channel", testResource("Channel", "ch-1", "stable"), nil)
+	Expect(svcErr).To(BeNil())
+	_, svcErr = svc.Delete(context.Background(), "Channel", created.ID)
+	Expect(svcErr).To(BeNil())
+
+	Expect(outbox.events).To(HaveLen(2))
+	var event events.Event
+	Expect(json.Unmarshal(outbox.events[0].Event, &event)).To(Succeed())
+	Expect(event.SpecVersion).To(Equal("1.0"))
+	Expect(event.ID).To(Equal(outbox.events[0].EventID))
+	Expect(event.Source).To(Equal("hyperfleet-test"))
+	Expect(event.Type).To(Equal("com.redhat.hyperfleet.channel.added"))
+
+	var data map[string]interface{}
+	Expect(json.Unmarshal(event.Data, &data)).To(Succeed())
+	Expect(data["id"]).To(Equal(created.ID))
+
+	Expect(json.Unmarshal(outbox.events[1].Event, &event)).To(Succeed())
+	Expect(event.Type).To(Equal("com.redhat.hyperfleet.channel.deleted"))
+	Expect(outbox.events[1].ResourceID).To(Equal(created.ID))
+}
+
+func TestResourceService_RecordsAuditEvents(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, mocks := newTestResourceServiceWithMocks(mockDao)
+	ctx := auth.SetUsernameContext(context.Background(), "alice@test.com")
+
+	created, svcErr := svc.Create(ctx, "Channel", testResource("Channel", "ch-1", "stable"), nil)
+	Expect(svcErr).To(BeNil())
+	_, svcErr = svc.Patch(ctx, "Channel", created.ID, &api.ResourcePatch{
+		Spec:   map[string]interface{}{"key": "new-value"},
+		Labels: map[string]string{"env": "prod"},
+	})
+	Expect(svcErr).To(BeNil())
+	_, svcErr = svc.Delete(ctx, "Channel", created.ID)
+	Expect(svcErr).To(BeNil())
+
+	Expect(mocks.audit.events).To(HaveLen(3))
+	for _, event := range mocks.audit.events {
+		Expect(event.ResourceID).To(Equal(created.ID))
+		Expect(event.Kind).To(Equal("Channel"))
+		Expect(event.Actor).To(Equal("alice@test.com"))
+	}
+	Expect(mocks.audit.events[0].Action).To(Equal(api.AuditCreate))
+	Expect(mocks.audit.events[0].Changes).To(MatchJSON(`[{"path":"spec.key","new":"value"}]`))
+	Expect(mocks.audit.events[1].Action).To(Equal(api.AuditPatch))
+	Expect(mocks.audit.events[1].Generation).To(Equal(int32(2)))
+	Expect(mocks.audit.events[1].Changes).To(MatchJSON(`[
+		{"path":"spec.key","old":"value","new":"new-value"},
+		{"path":"labels.env","new":"prod"}
+	]`))
+	Expect(mocks.audit.events[2].Action).To(Equal(api.AuditDelete))
+	Expect(mocks.audit.events[2].Changes).To(MatchJSON(`[]`))
+}
+
+func TestResourceService_ForceDelete_AuditsReason(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, mocks := newTestResourceServiceWithMocks(mockDao)
+
+	now := time.Now()
+	existing := testResource("Channel", testChannelID, "stable")
+	existing.DeletedTime = &now
+	mockDao.addResource(existing)
+
+	Expect(svc.ForceDelete(context.Background(), "Channel", testChannelID, "Stuck in finalizing")).To(BeNil())
+
+	Expect(mocks.audit.events).To(HaveLen(1))
+	Expect(mocks.audit.events[0].Action).To(Equal(api.AuditForceDelete))
+	Expect(mocks.audit.events[0].Reason).To(Equal(util.PtrString("Stuck in finalizing")))
+}
+
+func TestProcessAdapterStatus_AuditsConditionTransitions(t *testing.T) {
+	RegisterTestingT(t)
+	setupAdapterStatusDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, mocks := newTestResourceServiceWithMocks(mockDao)
+
+	r := testResource("TestResource", "r-1", "test")
+	r.Generation = 1
+	mockDao.addResource(r)
+
+	report := func(available api.AdapterConditionStatus) {
+		status := testAdapterStatusRequest(1)
+		status.Conditions = testConditionsJSON(testMandatoryConditions(available)...)
+		_, svcErr := svc.ProcessAdapterStatus(systemCtx(), "TestResource", "r-1", status)
+		Expect(svcErr).To(BeNil())
+	}
+
+	report(api.AdapterConditionFalse)
+	report(api.AdapterConditionFalse)
+	report(api.AdapterConditionTrue)
+
+	// The repeated report is not a transition.
+	Expect(mocks.audit.events).To(HaveLen(2))
+	for _, event := range mocks.audit.events {
+		Expect(event.Action).To(Equal(api.AuditAdapterStatus))
+		Expect(event.Adapter).To(Equal(util.PtrString("adapter1")))
+	}
+	Expect(mocks.audit.events[0].Changes).To(MatchJSON(`[
+		{"path":"conditions.Applied","new":"True"},
+		{"path":"conditions.Available","new":"False"},
+		{"path":"conditions.Health","new":"True"}
+	]`))
+	Expect(mocks.audit.events[1].Changes).To(MatchJSON(`[{"path":"conditions.Available","old":"False","new":"True"}]`))
+}
+
+func TestResourceService_History(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, _, generic := newTestResourceService(mockDao)
+	mockDao.addResource(testResource("Channel", "ch-1", "stable"))
+
+	_, _, svcErr := svc.History(context.Background(), "Channel", "ch-1", "", &ListArguments{Search: "action = 'PATCH'"})
+	Expect(svcErr).To(BeNil())
+	Expect(generic.lastSearch).To(Equal("action = 'PATCH'"))
+	Expect(generic.lastFilters).To(Equal([]dao.Where{dao.NewWhere(
+		"resource_events.resource_id = ? AND resource_events.kind = ?", []any{"ch-1", "Channel"},
+	)}))
+
+	// The path values are bound as parameters, so a quote cannot widen the filter.
+	injected := "x' or resource_id != '"
+	_, _, svcErr = svc.History(context.Background(), "Channel", "ch-1", injected, nil)
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(404))
+	Expect(generic.lastSearch).To(BeEmpty())
+	Expect(generic.lastFilters).To(Equal([]dao.Where{dao.NewWhere(
+		"resource_events.resource_id = ? AND resource_events.kind = ? AND resource_events.owner_id = ?",
+		[]any{"ch-1", "Channel", injected},
+	)}))
+
+	// Without events or a resource there is nothing to show.
+	_, _, svcErr = svc.History(context.Background(), "Channel", "missing", "", nil)
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(404))
+}
+
+func TestResourceService_RecordsRevisions(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, mocks := newTestResourceServiceWithMocks(mockDao)
+	ctx := auth.SetUsernameContext(context.Background(), "alice@test.com")
+
+	created, svcErr := svc.Create(ctx, "Channel", testResource("Channel", "ch-1", "stable"), nil)
+	Expect(svcErr).To(BeNil())
+	_, svcErr = svc.Patch(ctx, "Channel", created.ID, &api.ResourcePatch{
+		Spec:   map[string]interface{}{"key": "new-value"},
+		Labels: map[string]string{"env": "prod"},
+	})
+	Expect(svcErr).To(BeNil())
+	// A patch that changes nothing keeps the generation and records no revision.
+	_, svcErr = svc.Patch(ctx, "Channel", created.ID, &api.ResourcePatch{Labels: map[string]string{"env": "prod"}})
+	Expect(svcErr).To(BeNil())
+
+	Expect(mocks.revisions.revisions).To(HaveLen(2))
+	first, second := mocks.revisions.revisions[0], mocks.revisions.revisions[1]
+	Expect(first.Generation).To(Equal(int32(1)))
+	Expect(first.CreatedBy).To(Equal("alice@test.com"))
+	Expect(first.Spec).To(MatchJSON(`{"key":"value"}`))
+	Expect(first.Labels).To(MatchJSON(`{}`))
+	Expect(first.References).To(MatchJSON(`{}`))
+	Expect(second.Generation).To(Equal(int32(2)))
+	Expect(second.Spec).To(MatchJSON(`{"key":"new-value"}`))
+	Expect(second.Labels).To(MatchJSON(`{"env":"prod"}`))
+}
+
+func TestResourceService_GetRevision(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, _ := newTestResourceServiceWithMocks(mockDao)
+
+	created, svcErr := svc.Create(context.Background(), "Channel", testResource("Channel", "ch-1", "stable"), nil)
+	Expect(svcErr).To(BeNil())
+
+	revision, svcErr := svc.GetRevision(context.Background(), "Channel", created.ID, 1)
+	Expect(svcErr).To(BeNil())
+	Expect(revision.Spec).To(MatchJSON(`{"key":"value"}`))
+
+	revision, svcErr = svc.GetRevision(context.Background(), "", created.ID, 1)
+	Expect(svcErr).To(BeNil())
+	Expect(revision.Kind).To(Equal("Channel"))
+
+	_, svcErr = svc.GetRevision(context.Background(), "Channel", created.ID, 7)
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(404))
+	Expect(svcErr.Reason).To(ContainSubstring("Channel revision with generation='7' not found"))
+
+	_, svcErr = svc.GetRevision(context.Background(), "Channel", "missing", 1)
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(404))
+}
+
+func TestResourceService_ListRevisions(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+
+	mockDao := newMockResourceDao()
+	svc, mocks := newTestResourceServiceWithMocks(mockDao)
+	mockDao.addResource(testResource("Channel", "ch-1", "stable"))
+
+	_, _, svcErr := svc.ListRevisions(context.Background(), "Channel", "ch-1", &ListArguments{Search: "generation > 1"})
+	Expect(svcErr).To(BeNil())
+	Expect(mocks.generic.lastSearch).To(Equal("generation > 1"))
+	Expect(mocks.generic.lastFilters).To(Equal([]dao.Where{
+		dao.NewWhere("resource_revisions.resource_id = ?", []any{"ch-1"}),
+	}))
+
+	mocks.generic.listCalled = false
+	_, _, svcErr = svc.ListRevisions(context.Background(), "Channel", "missing", nil)
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(404))
+	Expect(mocks.generic.listCalled).To(BeFalse())
+}
+
 func TestResourceService_Patch_NotFound(t *testing.T) {
 	RegisterTestingT(t)
 	setupTestDescriptors()
@@ -1502,6 +2141,65 @@ func TestResourceService_ListByOwner_UnknownKind(t *testing.T) {
 	Expect(svcErr.HTTPCode).To(Equal(400))
 }
 
+// --- ListByAncestor ---
+
+// setupMachineDescriptors registers Cluster -> NodePool -> Machine.
+func setupMachineDescriptors() {
+	registry.Reset()
+	registry.Register(registry.EntityDescriptor{Kind: "Cluster", Plural: "clusters"})
+	registry.Register(registry.EntityDescriptor{Kind: "NodePool", Plural: "nodepools", ParentKind: "Cluster"})
+	registry.Register(registry.EntityDescriptor{Kind: "Machine", Plural: "machines", ParentKind: "NodePool"})
+}
+
+func TestResourceService_ListByAncestor_FiltersByIntermediateOwners(t *testing.T) {
+	RegisterTestingT(t)
+	setupMachineDescriptors()
+
+	svc, _, generic := newTestResourceService(newMockResourceDao())
+
+	args := &ListArguments{Page: 1, Size: 100, Search: "name = 'm'"}
+	_, _, svcErr := svc.ListByAncestor(context.Background(), "Machine", "Cluster", "c-1", args)
+	Expect(svcErr).To(BeNil())
+	Expect(args.Search).To(Equal("name = 'm'"))
+	Expect(args.Filters).To(BeEmpty())
+	Expect(generic.lastSearch).To(Equal("(name = 'm') AND kind = 'Machine'"))
+	Expect(generic.lastFilters).To(Equal([]dao.Where{dao.NewWhere(
+		"resources.owner_id IN (SELECT id FROM resources WHERE kind = ? AND owner_id IN (?))",
+		[]any{"NodePool", "c-1"},
+	)}))
+}
+
+func TestResourceService_ListByAncestor_NestsEachIntermediateKind(t *testing.T) {
+	RegisterTestingT(t)
+	setupMachineDescriptors()
+	registry.Register(registry.EntityDescriptor{Kind: "Disk", Plural: "disks", ParentKind: "Machine"})
+
+	svc, _, generic := newTestResourceService(newMockResourceDao())
+
+	_, _, svcErr := svc.ListByAncestor(context.Background(), "Disk", "Cluster", "c-1", nil)
+	Expect(svcErr).To(BeNil())
+	Expect(generic.lastSearch).To(Equal("kind = 'Disk'"))
+	Expect(generic.lastFilters).To(Equal([]dao.Where{dao.NewWhere(
+		"resources.owner_id IN (SELECT id FROM resources WHERE kind = ? AND owner_id IN "+
+			"(SELECT id FROM resources WHERE kind = ? AND owner_id IN (?)))",
+		[]any{"Machine", "NodePool", "c-1"},
+	)}))
+}
+
+func TestResourceService_ListByAncestor_RejectsParentAndUnrelatedKinds(t *testing.T) {
+	RegisterTestingT(t)
+	setupMachineDescriptors()
+
+	svc, _, generic := newTestResourceService(newMockResourceDao())
+
+	for _, ancestorKind := range []string{"NodePool", "Machine", "Channel"} {
+		_, _, svcErr := svc.ListByAncestor(context.Background(), "Machine", ancestorKind, "x-1", nil)
+		Expect(svcErr).ToNot(BeNil())
+		Expect(svcErr.HTTPCode).To(Equal(400))
+	}
+	Expect(generic.listCalled).To(BeFalse())
+}
+
 // --- Parent/Child Delete with RequiredAdapters ---
 
 // setupDescriptorsWithRequiredAdapters creates Channel (parent) and Version (child with RequiredAdapters)
@@ -2483,6 +3181,154 @@ func TestResourceService_Delete_UnreferencedResource_Succeeds(t *testing.T) {
 	Expect(result.DeletedTime).ToNot(BeNil())
 }
 
+// setupTargetDeleteDescriptors registers Parent -> Target "dep" with the given
+// on_target_delete policy, plus Other -> Parent "upstream" (restrict).
+func setupTargetDeleteDescriptors(policy registry.OnTargetDeletePolicy) {
+	registry.Reset()
+	registry.Register(registry.EntityDescriptor{
+		Kind:   "Target",
+		Plural: "targets",
+	})
+	registry.Register(registry.EntityDescriptor{
+		Kind:   "Parent",
+		Plural: "parents",
+		References: []registry.ReferenceDescriptor{
+			{RefType: "dep", TargetKind: "Target", Max: 1, OnTargetDelete: policy},
+		},
+	})
+	registry.Register(registry.EntityDescriptor{
+		Kind:   "Other",
+		Plural: "others",
+		References: []registry.ReferenceDescriptor{
+			{RefType: "upstream", TargetKind: "Parent"},
+		},
+	})
+}
+
+// addReferencedTarget stores Target t-1 and Parent p-1 referencing it under "dep".
+func addReferencedTarget(mockDao *mockResourceDao) *api.Resource {
+	mockDao.addResource(testResource("Target", "t-1", "target-1"))
+	parent := testResource("Parent", "p-1", "parent-1")
+	parent.References = []api.ResourceReference{
+		{SourceID: "p-1", RefType: "dep", TargetID: "t-1", TargetKind: "Target"},
+	}
+	mockDao.addResource(parent)
+	mockDao.referencersByTarget = map[string][]api.ResourceSummary{
+		"t-1": {{ID: "p-1", Kind: "Parent", Name: "parent-1", RefType: "dep"}},
+	}
+	return parent
+}
+
+func TestResourceService_Delete_DetachReferencer_BumpsGeneration(t *testing.T) {
+	RegisterTestingT(t)
+	setupTargetDeleteDescriptors(registry.OnTargetDeleteDetach)
+
+	mockDao := newMockResourceDao()
+	svc, mocks := newTestResourceServiceWithMocks(mockDao)
+	parent := addReferencedTarget(mockDao)
+
+	_, svcErr := svc.Delete(context.Background(), "Target", "t-1")
+	Expect(svcErr).To(BeNil())
+
+	Expect(parent.DeletedTime).To(BeNil())
+	Expect(parent.Generation).To(Equal(int32(2)))
+	Expect(parent.References).To(BeEmpty())
+	Expect(mockDao.clearedTargets).To(ContainElement("t-1"))
+
+	var modified []string
+	for _, c := range mocks.changes.changes {
+		if c.Type == api.ChangeModified {
+			modified = append(modified, c.ResourceID)
+		}
+	}
+	Expect(modified).To(ContainElement("p-1"))
+}
+
+func TestResourceService_Delete_CascadeReferencer_DeletesReferencer(t *testing.T) {
+	RegisterTestingT(t)
+	setupTargetDeleteDescriptors(registry.OnTargetDeleteCascade)
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+	addReferencedTarget(mockDao)
+
+	_, svcErr := svc.Delete(context.Background(), "Target", "t-1")
+	Expect(svcErr).To(BeNil())
+
+	_, exists := mockDao.resources[resourceKey("Parent", "p-1")]
+	Expect(exists).To(BeFalse())
+}
+
+func TestResourceService_Delete_CascadeReferencer_HonoursItsReferencers(t *testing.T) {
+	RegisterTestingT(t)
+	setupTargetDeleteDescriptors(registry.OnTargetDeleteCascade)
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+	addReferencedTarget(mockDao)
+	mockDao.referencersByTarget["p-1"] = []api.ResourceSummary{
+		{ID: "o-1", Kind: "Other", Name: "other-1", RefType: "upstream"},
+	}
+
+	_, svcErr := svc.Delete(context.Background(), "Target", "t-1")
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(409))
+	Expect(svcErr.Reason).To(ContainSubstring(`Parent "parent-1"`))
+	Expect(svcErr.Reason).To(ContainSubstring(`Other "other-1"`))
+}
+
+func TestResourceService_Delete_CascadeReferenceCycle_Terminates(t *testing.T) {
+	RegisterTestingT(t)
+	setupTargetDeleteDescriptors(registry.OnTargetDeleteCascade)
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+	addReferencedTarget(mockDao)
+	// An undeclared ref type resolves to restrict, but t-1 is already being deleted.
+	mockDao.referencersByTarget["p-1"] = []api.ResourceSummary{
+		{ID: "t-1", Kind: "Target", Name: "target-1", RefType: "back"},
+	}
+
+	_, svcErr := svc.Delete(context.Background(), "Target", "t-1")
+	Expect(svcErr).To(BeNil())
+}
+
+func TestResourceService_ForceDelete_RestrictedReferencer_Returns409(t *testing.T) {
+	RegisterTestingT(t)
+	setupTargetDeleteDescriptors(registry.OnTargetDeleteRestrict)
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+	addReferencedTarget(mockDao)
+	now := time.Now()
+	mockDao.resources[resourceKey("Target", "t-1")].DeletedTime = &now
+
+	svcErr := svc.ForceDelete(context.Background(), "Target", "t-1", "stuck")
+	Expect(svcErr).ToNot(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(409))
+	Expect(svcErr.Reason).To(ContainSubstring(`Parent "parent-1"`))
+	Expect(mockDao.clearedTargets).To(BeEmpty())
+}
+
+func TestResourceService_ForceDelete_DetachReferencer_Succeeds(t *testing.T) {
+	RegisterTestingT(t)
+	setupTargetDeleteDescriptors(registry.OnTargetDeleteDetach)
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+	parent := addReferencedTarget(mockDao)
+	now := time.Now()
+	mockDao.resources[resourceKey("Target", "t-1")].DeletedTime = &now
+
+	svcErr := svc.ForceDelete(context.Background(), "Target", "t-1", "stuck")
+	Expect(svcErr).To(BeNil())
+
+	_, exists := mockDao.resources[resourceKey("Target", "t-1")]
+	Expect(exists).To(BeFalse())
+	Expect(parent.Generation).To(Equal(int32(2)))
+	Expect(parent.References).To(BeEmpty())
+}
+
 func TestResourceService_Create_DuplicateRefTargetID_Returns400(t *testing.T) {
 	RegisterTestingT(t)
 	setupOptionalRefDescriptors() // Max: 3 on "dep"
@@ -3482,3 +4328,86 @@ func TestResourceService_ConditionMapper_IntegrationPath(t *testing.T) {
 		"ObservedGeneration should match resource generation",
 	)
 }
+
+func TestResourceService_StatusFields_IntegrationPath(t *testing.T) {
+	RegisterTestingT(t)
+	registry.Reset()
+	t.Cleanup(registry.Reset)
+	registry.Register(registry.EntityDescriptor{
+		Kind:   "Cluster",
+		Plural: "clusters",
+		StatusFields: []registry.StatusFieldRule{
+			{Name: "api_url", Expression: `statuses.filter(s, s.adapter == "dns")[0].data.api_url`},
+		},
+	})
+
+	mockDao := newMockResourceDao()
+	svc, _, _, _ := newTestResourceServiceWithConditions(mockDao)
+
+	cluster := testResource("Cluster", "cl-1", "test-cluster")
+	mockDao.addResource(cluster)
+
+	req := &api.AdapterStatus{
+		Adapter:            "dns",
+		ObservedGeneration: 1,
+		LastReportTime:     time.Now().UTC(),
+		Conditions: testConditionsJSON(
+			api.AdapterCondition{Type: api.AdapterConditionTypeAvailable, Status: api.AdapterConditionTrue},
+			api.AdapterCondition{Type: api.AdapterConditionTypeApplied, Status: api.AdapterConditionTrue},
+			api.AdapterCondition{Type: api.AdapterConditionTypeHealth, Status: api.AdapterConditionTrue},
+		),
+		Data: datatypes.JSON(`{"api_url":"https://api.example.com"}`),
+	}
+
+	_, svcErr := svc.ProcessAdapterStatus(context.Background(), "Cluster", cluster.ID, req)
+	Expect(svcErr).To(BeNil())
+	Expect(cluster.StatusFields).To(MatchJSON(`{"api_url":"https://api.example.com"}`))
+}
+
+func TestResourceService_ReloadDescriptors(t *testing.T) {
+	RegisterTestingT(t)
+	setupTestDescriptors()
+	defer registry.Reset()
+
+	svc, _, _ := newTestResourceService(newMockResourceDao())
+	concrete, ok := svc.(*sqlResourceService)
+	Expect(ok).To(BeTrue())
+	Expect(concrete.conditionMapper("Channel")).To(BeNil())
+
+	next := registry.All()
+	for i := range next {
+		if next[i].Kind != "Channel" {
+			continue
+		}
+		next[i].RequiredAdapters = []string{"validation"}
+		next[i].Conditions = []registry.ConditionMappingRule{{
+			Type: "Published",
+			When: registry.MappingExpression{Expression: `true`},
+			Output: registry.MappingOutput{
+				Status:  registry.MappingExpression{Expression: `"True"`},
+				Reason:  registry.MappingExpression{Expression: `"Published"`},
+				Message: registry.MappingExpression{Expression: `"Channel is published"`},
+			},
+		}}
+		next[i].StatusFields = []registry.StatusFieldRule{{Name: "adapters", Expression: `size(statuses)`}}
+	}
+	Expect(svc.ReloadDescriptors(next)).To(Succeed())
+
+	channel, found := registry.Get("Channel")
+	Expect(found).To(BeTrue())
+	Expect(channel.RequiredAdapters).To(ConsistOf("validation"))
+	Expect(concrete.conditionMapper("Channel")).NotTo(BeNil())
+	Expect(concrete.statusFieldMapper("Channel")).NotTo(BeNil())
+
+	removed := registry.All()
+	for i := range removed {
+		if removed[i].Kind == "WifConfig" {
+			removed = append(removed[:i], removed[i+1:]...)
+			break
+		}
+	}
+	Expect(svc.ReloadDescriptors(removed)).To(MatchError(ContainSubstring("WifConfig")))
+	_, found = registry.Get("WifConfig")
+	Expect(found).To(BeTrue(), "a rejected reload leaves the registry unchanged")
+	Expect(concrete.conditionMapper("Channel")).NotTo(BeNil())
+}
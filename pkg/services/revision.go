@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/openapi"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+)
+
+// recordRevision stores the spec, labels and references of resource at its current
+// generation inside the mutation's transaction.
+func (s *sqlResourceService) recordRevision(ctx context.Context, resource *api.Resource) *errors.ServiceError {
+	revision, err := newResourceRevision(ctx, resource)
+	if err != nil {
+		db.MarkForRollback(ctx, err)
+		return errors.GeneralError("Failed to marshal %s revision: %s", resource.Kind, err)
+	}
+	if err := s.resourceRevisionDao.Record(ctx, revision); err != nil {
+		return errors.GeneralError("Failed to record %s revision: %s", resource.Kind, err)
+	}
+	return nil
+}
+
+func newResourceRevision(ctx context.Context, resource *api.Resource) (*api.ResourceRevision, error) {
+	labels, err := json.Marshal(labelMap(resource.Labels))
+	if err != nil {
+		return nil, err
+	}
+	refs, err := json.Marshal(referenceMap(resource.References))
+	if err != nil {
+		return nil, err
+	}
+	spec := resource.Spec
+	if len(spec) == 0 {
+		spec = []byte("{}")
+	}
+	tenancy := resource.Tenancy
+	if len(tenancy) == 0 {
+		tenancy = []byte("{}")
+	}
+	return &api.ResourceRevision{
+		ResourceID: resource.ID,
+		Kind:       resource.Kind,
+		Generation: resource.Generation,
+		CreatedBy:  actorFromContext(ctx),
+		Spec:       spec,
+		Labels:     labels,
+		References: refs,
+		Tenancy:    tenancy,
+	}, nil
+}
+
+// referenceMap converts stored reference rows back to the API form a patch accepts.
+func referenceMap(refs []api.ResourceReference) api.ReferenceMap {
+	m := make(api.ReferenceMap, len(refs))
+	for _, ref := range refs {
+		id := ref.TargetID
+		m[ref.RefType] = append(m[ref.RefType], openapi.ObjectReference{Id: &id, Kind: ref.TargetKind})
+	}
+	return m
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/util"
+)
+
+// SpecValidator evaluates the CEL validation rules of one entity kind
+type SpecValidator struct {
+	resourceKind string
+	rules        []*compiledValidation
+}
+
+type compiledValidation struct {
+	program    cel.Program
+	rule       registry.ValidationRule
+	transition bool // refers to oldSelf, so only checked on update
+}
+
+// specValidationInput is what a validation rule sees of a write
+type specValidationInput struct {
+	labels  map[string]string
+	parent  *api.Resource // nil for top-level entities
+	spec    []byte
+	oldSpec []byte // nil on create
+}
+
+// NewSpecValidator compiles the validation rules of an entity kind
+func NewSpecValidator(resourceKind string, rules []registry.ValidationRule) (*SpecValidator, error) {
+	env, err := util.NewSpecValidationEnvironment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	validator := &SpecValidator{resourceKind: resourceKind}
+	for i, rule := range rules {
+		ast, issues := env.Compile(rule.Rule)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("validations[%d]: %w", i, issues.Err())
+		}
+		prg, prgErr := env.Program(ast, cel.CostLimit(util.CELCostLimit))
+		if prgErr != nil {
+			return nil, fmt.Errorf("validations[%d]: program error: %w", i, prgErr)
+		}
+		validator.rules = append(validator.rules, &compiledValidation{
+			program:    prg,
+			rule:       rule,
+			transition: referencesVariable(ast, util.CELVarOldSelf),
+		})
+	}
+	return validator, nil
+}
+
+// referencesVariable reports whether the checked expression reads the named variable
+func referencesVariable(ast *cel.Ast, name string) bool {
+	for _, reference := range ast.NativeRep().ReferenceMap() {
+		if reference.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate evaluates every rule against the write and returns one detail per failed rule.
+// Transition rules are skipped on create. A rule that fails to evaluate, for example because
+// it reads a field the spec does not set, counts as failed.
+func (v *SpecValidator) Validate(input specValidationInput) ([]errors.ValidationDetail, error) {
+	self, err := decodeCELValue(input.spec)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s spec: %w", v.resourceKind, err)
+	}
+	oldSelf, err := decodeCELValue(input.oldSpec)
+	if err != nil {
+		return nil, fmt.Errorf("decode stored %s spec: %w", v.resourceKind, err)
+	}
+	labels := input.labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	var parent interface{}
+	if input.parent != nil {
+		if parent, err = parentCELValue(input.parent); err != nil {
+			return nil, fmt.Errorf("decode %s parent: %w", v.resourceKind, err)
+		}
+	}
+	activation := map[string]interface{}{
+		util.CELVarSelf:    self,
+		util.CELVarOldSelf: oldSelf,
+		util.CELVarLabels:  labels,
+		util.CELVarParent:  parent,
+	}
+
+	var details []errors.ValidationDetail
+	for _, compiled := range v.rules {
+		if compiled.transition && input.oldSpec == nil {
+			continue
+		}
+		message := compiled.rule.Message
+		if message == "" {
+			message = "failed rule: " + compiled.rule.Rule
+		}
+		out, _, evalErr := compiled.program.Eval(activation)
+		switch {
+		case evalErr != nil:
+			message = fmt.Sprintf("%s (%s)", message, evalErr)
+		case out.Value() == true:
+			continue
+		}
+		field := compiled.rule.FieldPath
+		if field == "" {
+			field = "spec"
+		}
+		details = append(details, errors.ValidationDetail{
+			Field:      field,
+			Constraint: compiled.rule.Rule,
+			Message:    message,
+		})
+	}
+	return details, nil
+}
+
+// parentCELValue returns the parent resource as rules see it: its JSON representation with
+// its labels as a map.
+func parentCELValue(parent *api.Resource) (interface{}, error) {
+	data, err := json.Marshal(parent)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeCELValue(data)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("parent is not a JSON object")
+	}
+	object["labels"] = labelMap(parent.Labels)
+	return object, nil
+}
+
+// decodeCELValue decodes JSON for CEL evaluation. Whole numbers become int64 so rules can
+// use integer arithmetic on them; other numbers become float64.
+func decodeCELValue(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return celNumbers(value), nil
+}
+
+func celNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return v.String()
+		}
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = celNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = celNumbers(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+)
+
+func setupValidationDescriptors() {
+	setupTestDescriptors()
+	registry.UpdateDescriptor("Channel", func(d *registry.EntityDescriptor) {
+		d.Validations = []registry.ValidationRule{
+			{Rule: "self.replicas <= 100", FieldPath: "spec.replicas"},
+			{
+				Rule:      "self.replicas - oldSelf.replicas <= 10 && oldSelf.replicas - self.replicas <= 10",
+				Message:   "channel replicas may only be scaled by 10 at a time",
+				FieldPath: "spec.replicas",
+			},
+		}
+	})
+	registry.UpdateDescriptor("Version", func(d *registry.EntityDescriptor) {
+		d.Validations = []registry.ValidationRule{
+			{Rule: `labels["tier"] == parent.labels.tier`, Message: "version tier must match its channel"},
+		}
+	})
+}
+
+func specWithReplicas(replicas int) []byte {
+	spec, err := json.Marshal(map[string]interface{}{"replicas": replicas})
+	if err != nil {
+		panic(err)
+	}
+	return spec
+}
+
+func TestResourceService_Create_ValidationRules(t *testing.T) {
+	RegisterTestingT(t)
+	setupValidationDescriptors()
+	defer registry.Reset()
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+
+	channel := testResource("Channel", "ch-1", "stable")
+	channel.Spec = specWithReplicas(50)
+	result, svcErr := svc.Create(context.Background(), "Channel", channel, nil)
+	Expect(svcErr).To(BeNil(), "transition rules are not checked on create")
+	Expect(result).NotTo(BeNil())
+
+	tooLarge := testResource("Channel", "ch-2", "fast")
+	tooLarge.Spec = specWithReplicas(500)
+	result, svcErr = svc.Create(context.Background(), "Channel", tooLarge, nil)
+	Expect(result).To(BeNil())
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(400))
+	Expect(svcErr.Details).To(HaveLen(1))
+	Expect(svcErr.Details[0].Field).To(Equal("spec.replicas"))
+	Expect(svcErr.Details[0].Message).To(Equal("failed rule: self.replicas <= 100"))
+}
+
+func TestResourceService_Create_ValidationRulesSeeParent(t *testing.T) {
+	RegisterTestingT(t)
+	setupValidationDescriptors()
+	defer registry.Reset()
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+
+	parent := testResource("Channel", "ch-1", "stable")
+	parent.Labels = []api.ResourceLabel{{Key: "tier", Value: "gold"}}
+	mockDao.addResource(parent)
+
+	child := testResource("Version", "v-1", "4.18")
+	child.OwnerID = &parent.ID
+	child.Labels = []api.ResourceLabel{{Key: "tier", Value: "silver"}}
+	result, svcErr := svc.Create(context.Background(), "Version", child, nil)
+	Expect(result).To(BeNil())
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.Details).To(HaveLen(1))
+	Expect(svcErr.Details[0].Field).To(Equal("spec"))
+	Expect(svcErr.Details[0].Message).To(Equal("version tier must match its channel"))
+
+	child.Labels = []api.ResourceLabel{{Key: "tier", Value: "gold"}}
+	result, svcErr = svc.Create(context.Background(), "Version", child, nil)
+	Expect(svcErr).To(BeNil())
+	Expect(result).NotTo(BeNil())
+}
+
+func TestResourceService_Patch_TransitionRules(t *testing.T) {
+	RegisterTestingT(t)
+	setupValidationDescriptors()
+	defer registry.Reset()
+
+	mockDao := newMockResourceDao()
+	svc, _, _ := newTestResourceService(mockDao)
+
+	existing := testResource("Channel", "ch-1", "stable")
+	existing.Spec = specWithReplicas(20)
+	mockDao.addResource(existing)
+
+	patch := &api.ResourcePatch{Spec: map[string]interface{}{"replicas": 35}}
+	result, svcErr := svc.Patch(context.Background(), "Channel", "ch-1", patch)
+	Expect(result).To(BeNil())
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(400))
+	Expect(svcErr.Details).To(HaveLen(1))
+	Expect(svcErr.Details[0].Message).To(Equal("channel replicas may only be scaled by 10 at a time"))
+
+	patch = &api.ResourcePatch{Spec: map[string]interface{}{"replicas": 28}}
+	result, svcErr = svc.Patch(context.Background(), "Channel", "ch-1", patch)
+	Expect(svcErr).To(BeNil())
+	Expect(result).NotTo(BeNil())
+}
+
+func TestSpecValidator_EvaluationErrorFailsRule(t *testing.T) {
+	RegisterTestingT(t)
+
+	validator, err := NewSpecValidator("Channel", []registry.ValidationRule{{Rule: "self.replicas > 0"}})
+	Expect(err).NotTo(HaveOccurred())
+
+	details, err := validator.Validate(specValidationInput{spec: []byte(`{"name":"x"}`)})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(details).To(HaveLen(1))
+	Expect(details[0].Message).To(HavePrefix("failed rule: self.replicas > 0 ("))
+}
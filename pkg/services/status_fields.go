@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/util"
+)
+
+// StatusFieldMapper evaluates the CEL status_fields rules of an entity kind, projecting
+// adapter-reported data into named status fields.
+type StatusFieldMapper struct {
+	resourceKind string
+	rules        []compiledStatusField // sorted by name
+}
+
+type compiledStatusField struct {
+	program cel.Program
+	name    string
+}
+
+// NewStatusFieldMapper creates a status field mapper with pre-compiled rules.
+func NewStatusFieldMapper(resourceKind string, rules []registry.StatusFieldRule) (*StatusFieldMapper, error) {
+	// Same environment as condition mapping: rules see statuses and resource
+	env, err := util.NewConditionMappingEnvironment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	compiled := make([]compiledStatusField, 0, len(rules))
+	for _, rule := range rules {
+		program, compileErr := compileExpression(env, rule.Expression)
+		if compileErr != nil {
+			return nil, fmt.Errorf("failed to compile status field %s: %w", rule.Name, compileErr)
+		}
+		compiled = append(compiled, compiledStatusField{name: rule.Name, program: program})
+	}
+	sort.Slice(compiled, func(i, j int) bool { return compiled[i].name < compiled[j].name })
+
+	return &StatusFieldMapper{resourceKind: resourceKind, rules: compiled}, nil
+}
+
+// Apply evaluates every rule against the adapter statuses and the resource and returns the
+// fields that produced a value. Adapter data and the resource are masked before evaluation,
+// and the fields are masked again before they are returned.
+//
+// Unlike condition mapping, a failing rule does not fail the status update: an expression
+// commonly cannot be evaluated until its adapter has reported, so the field is left unset.
+func (m *StatusFieldMapper) Apply(
+	ctx context.Context,
+	statuses api.AdapterStatusList,
+	resource *api.Resource,
+) map[string]interface{} {
+	activation := map[string]interface{}{
+		util.CELVarStatuses: buildStatusesList(ctx, statuses),
+		util.CELVarResource: util.MaskSensitiveFields(resourceToMap(ctx, resource, m.resourceKind)),
+	}
+
+	fields := make(map[string]interface{}, len(m.rules))
+	for _, rule := range m.rules {
+		log := logger.With(ctx, "resource_kind", m.resourceKind, "status_field", rule.name)
+
+		result, _, err := rule.program.Eval(activation)
+		if err != nil {
+			log.WithError(err).Debug("Status field unset: expression evaluation failed")
+			continue
+		}
+		value, err := statusFieldValue(result)
+		if err != nil {
+			log.WithError(err).Warn("Status field unset: expression result is not a JSON value")
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil || len(encoded) > registry.MaxStatusFieldValueSize {
+			log.Warn("Status field unset: value exceeds max size")
+			continue
+		}
+		fields[rule.name] = value
+	}
+
+	return util.MaskSensitiveFields(fields)
+}
+
+// statusFieldValue converts a CEL result to a JSON-compatible Go value. null and an empty
+// optional convert to nil; timestamps convert to RFC 3339 strings.
+func statusFieldValue(val ref.Val) (interface{}, error) {
+	switch v := val.(type) {
+	case types.Null:
+		return nil, nil
+	case types.Bool:
+		return bool(v), nil
+	case types.Int:
+		return int64(v), nil
+	case types.Uint:
+		return uint64(v), nil
+	case types.Double:
+		return float64(v), nil
+	case types.String:
+		return string(v), nil
+	case types.Timestamp:
+		return v.UTC().Format(time.RFC3339Nano), nil
+	case *types.Optional:
+		if !v.HasValue() {
+			return nil, nil
+		}
+		return statusFieldValue(v.GetValue())
+	case traits.Mapper:
+		object := make(map[string]interface{})
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			key := it.Next()
+			name, ok := key.(types.String)
+			if !ok {
+				return nil, fmt.Errorf("map key %v is not a string", key)
+			}
+			item, err := statusFieldValue(v.Get(key))
+			if err != nil {
+				return nil, err
+			}
+			object[string(name)] = item
+		}
+		return object, nil
+	case traits.Lister:
+		size, ok := v.Size().(types.Int)
+		if !ok {
+			return nil, fmt.Errorf("list has no size")
+		}
+		list := make([]interface{}, 0, int(size))
+		for i := types.Int(0); i < size; i++ {
+			item, err := statusFieldValue(v.Get(i))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", val.Type())
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/util"
+)
+
+const dnsAPIURLExpression = `statuses.filter(s, s.adapter == "dns")[0].data.api_url`
+
+func dnsStatus(data string) *api.AdapterStatus {
+	return &api.AdapterStatus{
+		Adapter:            "dns",
+		ObservedGeneration: 1,
+		Conditions:         datatypes.JSON(`[]`),
+		Data:               datatypes.JSON(data),
+	}
+}
+
+func applyStatusFields(
+	t *testing.T, rules []registry.StatusFieldRule, statuses ...*api.AdapterStatus,
+) map[string]interface{} {
+	t.Helper()
+	mapper, err := NewStatusFieldMapper("Cluster", rules)
+	Expect(err).NotTo(HaveOccurred())
+	return mapper.Apply(context.Background(), statuses, testResource("Cluster", "cl-1", "test-cluster"))
+}
+
+func TestStatusFieldMapper_ProjectsAdapterData(t *testing.T) {
+	RegisterTestingT(t)
+
+	fields := applyStatusFields(t, []registry.StatusFieldRule{
+		{Name: "api_url", Expression: dnsAPIURLExpression},
+		{Name: "name", Expression: "resource.name"},
+		{Name: "summary", Expression: `{"adapters": statuses.map(s, s.adapter), "ready": true, "count": 2}`},
+	}, dnsStatus(`{"api_url":"https://api.example.com"}`))
+
+	Expect(fields).To(HaveKeyWithValue("api_url", "https://api.example.com"))
+	Expect(fields).To(HaveKeyWithValue("name", "test-cluster"))
+	Expect(fields).To(HaveKeyWithValue("summary", map[string]interface{}{
+		"adapters": []interface{}{"dns"},
+		"ready":    true,
+		"count":    int64(2),
+	}))
+}
+
+func TestStatusFieldMapper_LeavesFieldsUnset(t *testing.T) {
+	RegisterTestingT(t)
+
+	fields := applyStatusFields(t, []registry.StatusFieldRule{
+		{Name: "api_url", Expression: dnsAPIURLExpression},
+		{Name: "console_url", Expression: "null"},
+		{Name: "region", Expression: `resource.spec.?region`},
+	})
+	Expect(fields).To(BeEmpty(), "no adapter has reported and the spec has no region")
+
+	fields = applyStatusFields(t, []registry.StatusFieldRule{
+		{Name: "blob", Expression: `statuses[0].data.blob`},
+	}, dnsStatus(`{"blob":"`+strings.Repeat("a", registry.MaxStatusFieldValueSize)+`"}`))
+	Expect(fields).NotTo(HaveKey("blob"), "values over the size limit are skipped")
+}
+
+func TestStatusFieldMapper_MasksSensitiveFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	fields := applyStatusFields(t, []registry.StatusFieldRule{
+		{Name: "endpoint", Expression: `statuses[0].data.endpoint`},
+		{Name: "kubeconfig", Expression: `"apiVersion: v1"`},
+	}, dnsStatus(`{"endpoint":{"url":"https://api.example.com","admin_password":"hunter2"}}`))
+
+	Expect(fields).To(HaveKeyWithValue("endpoint", map[string]interface{}{
+		"url":            "https://api.example.com",
+		"admin_password": util.RedactedPlaceholder,
+	}))
+	Expect(fields).To(HaveKeyWithValue("kubeconfig", util.RedactedPlaceholder))
+}
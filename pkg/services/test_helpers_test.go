@@ -106,23 +106,6 @@ func (d *mockAdapterStatusDao) FindByResourceIDs(
 	return result, nil
 }
 
-func (d *mockAdapterStatusDao) FindByResourcePaginated(
-	ctx context.Context,
-	resourceType, resourceID string,
-	offset, limit int,
-) (api.AdapterStatusList, int64, error) {
-	statuses, err := d.FindByResource(ctx, resourceType, resourceID)
-	if err != nil {
-		return nil, 0, err
-	}
-	total := int64(len(statuses))
-	if offset >= len(statuses) {
-		return nil, total, nil
-	}
-	end := min(offset+limit, len(statuses))
-	return statuses[offset:end], total, nil
-}
-
 func (d *mockAdapterStatusDao) FindByResourceAndAdapter(
 	ctx context.Context,
 	resourceType, resourceID, adapter string,
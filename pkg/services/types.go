@@ -1,16 +1,29 @@
 package services
 
+import "github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+
 // ListArguments are arguments relevant for listing objects.
 // This struct is common to all service List funcs in this package
+//
+// Continue is an opaque token taken from a previous page's PagingMeta; when set, the list
+// resumes after that page's last row instead of at an offset. SkipCount leaves
+// PagingMeta.Total unset instead of running COUNT. LabelSelector is a Kubernetes-style
+// label selector, applied together with Search; only resource lists support it. Filters
+// are conditions a service adds to the caller's Search, with their values bound as query
+// parameters rather than written into it.
 type ListArguments struct {
-	Search      string
-	RefType     string
-	RefTargetID string
-	Preloads    []string
-	Order       []string
-	Fields      []string
-	Size        int64
-	Page        int64
+	Search        string
+	LabelSelector string
+	RefType       string
+	RefTargetID   string
+	Continue      string
+	Preloads      []string
+	Order         []string
+	Fields        []string
+	Filters       []dao.Where
+	Size          int64
+	Page          int64
+	SkipCount     bool
 }
 
 func NewListArguments() *ListArguments {
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/dao"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/logger"
+)
+
+const (
+	// watchBatchSize bounds how many changes a single Poll returns.
+	watchBatchSize = 500
+	// changePruneInterval is how often expired changes are deleted. Every replica
+	// prunes; the delete is idempotent, so no lock is taken.
+	changePruneInterval = 10 * time.Minute
+)
+
+// WatchFilter narrows a watch stream. Empty fields match everything.
+type WatchFilter struct {
+	Kind    string
+	OwnerID string
+}
+
+// WatchService serves the resource change log recorded by ResourceService writes to
+// long-lived watch streams.
+type WatchService interface {
+	// Head returns the position a new stream starts from: just past the latest change.
+	Head(ctx context.Context) (api.ChangePosition, *errors.ServiceError)
+	// CheckPosition returns a 410 error if changes after position may have been pruned.
+	CheckPosition(ctx context.Context, position api.ChangePosition) *errors.ServiceError
+	// Poll returns the next changes after position that match filter and are visible to
+	// the caller, oldest first, and the position reached. When there is nothing to
+	// return, the position still advances past changes other streams care about, so
+	// an idle stream can bookmark it.
+	Poll(
+		ctx context.Context, filter WatchFilter, after api.ChangePosition,
+	) ([]*api.ResourceChange, api.ChangePosition, *errors.ServiceError)
+	// Subscribe returns a channel signalled whenever new changes may have committed.
+	// The channel is closed when the service stops. The returned func unsubscribes.
+	Subscribe() (<-chan struct{}, func())
+	// Heartbeat is how often an idle stream should send a keep-alive.
+	Heartbeat() time.Duration
+	// Run listens for change notifications and prunes expired changes. It blocks
+	// until ctx is done, then closes every subscription.
+	Run(ctx context.Context)
+}
+
+func NewWatchService(
+	resourceChangeDao dao.ResourceChangeDao,
+	sessionFactory db.SessionFactory,
+	heartbeat, retention time.Duration,
+) WatchService {
+	return &sqlWatchService{
+		resourceChangeDao: resourceChangeDao,
+		sessionFactory:    sessionFactory,
+		heartbeat:         heartbeat,
+		retention:         retention,
+		subscribers:       make(map[chan struct{}]struct{}),
+	}
+}
+
+var _ WatchService = &sqlWatchService{}
+
+type sqlWatchService struct {
+	resourceChangeDao dao.ResourceChangeDao
+	sessionFactory    db.SessionFactory
+	subscribers       map[chan struct{}]struct{}
+	heartbeat         time.Duration
+	retention         time.Duration
+	mu                sync.Mutex
+	stopped           bool
+}
+
+func (s *sqlWatchService) Head(ctx context.Context) (api.ChangePosition, *errors.ServiceError) {
+	head, err := s.resourceChangeDao.Head(ctx)
+	if err != nil {
+		return api.ChangePosition{}, errors.GeneralError("Unable to read change log position: %s", err)
+	}
+	return head, nil
+}
+
+func (s *sqlWatchService) CheckPosition(ctx context.Context, position api.ChangePosition) *errors.ServiceError {
+	if position.IsZero() {
+		return nil
+	}
+	oldest, ok, err := s.resourceChangeDao.Oldest(ctx)
+	if err != nil {
+		return errors.GeneralError("Unable to read change log position: %s", err)
+	}
+	if ok && position.Before(oldest) {
+		return errors.Gone("watch position %s has expired; list the resources again and watch from now", position)
+	}
+	return nil
+}
+
+func (s *sqlWatchService) Poll(
+	ctx context.Context, filter WatchFilter, after api.ChangePosition,
+) ([]*api.ResourceChange, api.ChangePosition, *errors.ServiceError) {
+	// Read the head first: every change up to it is already committed, so once the
+	// filtered read below comes back short, nothing this stream wants lies before it.
+	head, err := s.resourceChangeDao.Head(ctx)
+	if err != nil {
+		return nil, after, errors.GeneralError("Unable to read change log position: %s", err)
+	}
+	changes, err := s.resourceChangeDao.FindAfter(ctx, after, dao.ChangeFilter{
+		Kind:    filter.Kind,
+		OwnerID: filter.OwnerID,
+	}, watchBatchSize)
+	if err != nil {
+		return nil, after, errors.GeneralError("Unable to read resource changes: %s", err)
+	}
+
+	next := after
+	if len(changes) > 0 {
+		next = changes[len(changes)-1].Position()
+	}
+	if len(changes) < watchBatchSize && next.Before(head) {
+		next = head
+	}
+	return changes, next, nil
+}
+
+func (s *sqlWatchService) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	if s.stopped {
+		close(ch)
+	} else {
+		s.subscribers[ch] = struct{}{}
+	}
+	s.mu.Unlock()
+	return ch, func() {
+		s.mu.Lock()
+		delete(s.subscribers, ch)
+		s.mu.Unlock()
+	}
+}
+
+func (s *sqlWatchService) Heartbeat() time.Duration {
+	return s.heartbeat
+}
+
+func (s *sqlWatchService) Run(ctx context.Context) {
+	go s.sessionFactory.NewListener(ctx, dao.ResourceChangesChannel, func(string) { s.notify() })
+
+	ticker := time.NewTicker(changePruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.stop()
+			return
+		case <-ticker.C:
+			s.prune(ctx)
+		}
+	}
+}
+
+func (s *sqlWatchService) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	for ch := range s.subscribers {
+		close(ch)
+		delete(s.subscribers, ch)
+	}
+}
+
+// notify wakes every subscriber. Channels hold one pending signal, so a slow stream
+// coalesces a burst of notifications into a single poll.
+func (s *sqlWatchService) notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *sqlWatchService) prune(ctx context.Context) {
+	deleted, err := s.resourceChangeDao.DeleteBefore(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		logger.WithError(ctx, err).Warn("Failed to prune expired resource changes")
+		return
+	}
+	if deleted > 0 {
+		logger.With(ctx, "deleted", deleted).Info("Pruned expired resource changes")
+	}
+}
@@ -41,3 +41,22 @@ func TenancyJSON(ctx context.Context) datatypes.JSON {
 	}
 	return datatypes.JSON(b)
 }
+
+// ScopeJSON returns the caller's dimensions as JSONB for a tenancy containment filter.
+// ok is false when reads must not be filtered: no tenant was resolved (enforcement disabled)
+// or the caller is the system identity. A non-system caller without dimensions gets an
+// empty scope, which callers must treat as matching nothing.
+func ScopeJSON(ctx context.Context) (scope datatypes.JSON, ok bool) {
+	t := FromContext(ctx)
+	if t == nil || t.System {
+		return nil, false
+	}
+	if len(t.Dimensions) == 0 {
+		return datatypes.JSON{}, true
+	}
+	b, err := json.Marshal(t.Dimensions)
+	if err != nil {
+		return datatypes.JSON{}, true
+	}
+	return datatypes.JSON(b), true
+}
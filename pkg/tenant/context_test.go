@@ -72,3 +72,47 @@ func TestTenancyJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestScopeJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		wantOK bool
+		want   string
+	}{
+		{
+			name:   "no tenant in context",
+			ctx:    context.Background(),
+			wantOK: false,
+		},
+		{
+			name:   "system identity",
+			ctx:    WithTenant(context.Background(), &ResolvedTenant{System: true, Dimensions: map[string]string{"org": "acme"}}),
+			wantOK: false,
+		},
+		{
+			name:   "empty dimensions",
+			ctx:    WithTenant(context.Background(), &ResolvedTenant{Dimensions: map[string]string{}}),
+			wantOK: true,
+		},
+		{
+			name:   "tenant with dimensions",
+			ctx:    WithTenant(context.Background(), &ResolvedTenant{Dimensions: map[string]string{"org": "acme", "project": "p1"}}),
+			wantOK: true,
+			want:   `{"org":"acme","project":"p1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			got, ok := ScopeJSON(tt.ctx)
+			Expect(ok).To(Equal(tt.wantOK))
+			if tt.want == "" {
+				Expect(got).To(BeEmpty())
+				return
+			}
+			Expect(string(got)).To(MatchJSON(tt.want))
+		})
+	}
+}
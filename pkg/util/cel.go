@@ -48,6 +48,14 @@ const (
 	CELVarResource = "resource" // Full cluster/nodepool object
 )
 
+// CEL variable names of spec validation rules, modelled on Kubernetes x-kubernetes-validations
+const (
+	CELVarSelf    = "self"    // Spec being written
+	CELVarOldSelf = "oldSelf" // Stored spec, only set on update
+	CELVarLabels  = "labels"  // Labels being written
+	CELVarParent  = "parent"  // Full parent object, null for top-level entities
+)
+
 // NewConditionMappingEnvironment creates a CEL environment for condition mapping
 // with context variables and custom functions.
 // This environment is used both for validation (at config load time) and runtime evaluation.
@@ -75,6 +83,32 @@ func NewConditionMappingEnvironment() (*cel.Env, error) {
 	)
 }
 
+// NewSpecValidationEnvironment creates a CEL environment for spec validation rules, with
+// the same custom functions as NewConditionMappingEnvironment.
+// This environment is used both for validation (at config load time) and runtime evaluation.
+func NewSpecValidationEnvironment() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.OptionalTypes(),
+
+		cel.Variable(CELVarSelf, cel.DynType),
+		cel.Variable(CELVarOldSelf, cel.DynType),
+		cel.Variable(CELVarLabels, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(CELVarParent, cel.DynType),
+
+		cel.Function("toJson",
+			cel.Overload("toJson_dyn",
+				[]*cel.Type{cel.DynType},
+				cel.StringType,
+				cel.UnaryBinding(toJSONFunc))),
+
+		cel.Function("dig",
+			cel.Overload("dig_dyn_string",
+				[]*cel.Type{cel.DynType, cel.StringType},
+				cel.DynType,
+				cel.BinaryBinding(digFunc))),
+	)
+}
+
 // toJSONFunc implements the toJson() CEL function
 func toJSONFunc(val ref.Val) ref.Val {
 	v := val.Value()
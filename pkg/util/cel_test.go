@@ -21,6 +21,31 @@ func TestNewConditionMappingEnvironment(t *testing.T) {
 	g.Expect(env).NotTo(BeNil())
 }
 
+func TestNewSpecValidationEnvironment(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	env, err := NewSpecValidationEnvironment()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ast, issues := env.Compile(`self.replicas - oldSelf.replicas <= 10 && labels["tier"] == dig(parent, "spec.tier")`)
+	g.Expect(issues.Err()).NotTo(HaveOccurred())
+	prg, err := env.Program(ast, cel.CostLimit(CELCostLimit))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	out, _, err := prg.Eval(map[string]interface{}{
+		CELVarSelf:    map[string]interface{}{"replicas": int64(12)},
+		CELVarOldSelf: map[string]interface{}{"replicas": int64(3)},
+		CELVarLabels:  map[string]string{"tier": "gold"},
+		CELVarParent:  map[string]interface{}{"spec": map[string]interface{}{"tier": "gold"}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.Value()).To(BeTrue())
+
+	_, issues = env.Compile(`statuses.size() > 0`)
+	g.Expect(issues.Err()).To(HaveOccurred(), "condition mapping variables are not declared")
+}
+
 func TestDigFunc_MapNavigation(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
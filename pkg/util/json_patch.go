@@ -0,0 +1,293 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONPatchOperation is a single RFC 6902 operation. Value holds the raw "value" member:
+// it is nil when the member is missing and "null" when it is a JSON null, which add,
+// replace and test accept as a value.
+type JSONPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// decodeValue decodes the operation's value, which add, replace and test require.
+func (op JSONPatchOperation) decodeValue() (interface{}, error) {
+	if op.Value == nil {
+		return nil, fmt.Errorf("%s operation at %q requires a value", op.Op, op.Path)
+	}
+	var value interface{}
+	if err := json.Unmarshal(op.Value, &value); err != nil {
+		return nil, fmt.Errorf("invalid value for %q: %w", op.Path, err)
+	}
+	return value, nil
+}
+
+// MergePatch applies an RFC 7386 JSON merge patch to target and returns the result.
+// Both arguments are decoded JSON values (map[string]interface{}, []interface{}, scalars).
+// Objects are merged recursively, null removes a member, and anything else replaces it.
+func MergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	result := make(map[string]interface{}, len(targetObj))
+	for k, v := range targetObj {
+		result[k] = v
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+		result[k] = MergePatch(result[k], v)
+	}
+	return result
+}
+
+// ApplyJSONPatch applies RFC 6902 operations to doc in order and returns the result.
+// doc is not modified; the first failing operation aborts the whole patch.
+func ApplyJSONPatch(doc interface{}, ops []JSONPatchOperation) (interface{}, error) {
+	result := deepCopyJSON(doc)
+	for i, op := range ops {
+		var err error
+		switch op.Op {
+		case "add":
+			var value interface{}
+			if value, err = op.decodeValue(); err == nil {
+				result, err = jsonPointerAdd(result, op.Path, value)
+			}
+		case "remove":
+			result, _, err = jsonPointerRemove(result, op.Path)
+		case "replace":
+			var value interface{}
+			if value, err = op.decodeValue(); err == nil {
+				if result, _, err = jsonPointerRemove(result, op.Path); err == nil {
+					result, err = jsonPointerAdd(result, op.Path, value)
+				}
+			}
+		case "move":
+			var value interface{}
+			if strings.HasPrefix(op.Path+"/", op.From+"/") && op.Path != op.From {
+				err = fmt.Errorf("cannot move %q into its own child %q", op.From, op.Path)
+			} else if result, value, err = jsonPointerRemove(result, op.From); err == nil {
+				result, err = jsonPointerAdd(result, op.Path, value)
+			}
+		case "copy":
+			var value interface{}
+			if value, err = jsonPointerGet(result, op.From); err == nil {
+				result, err = jsonPointerAdd(result, op.Path, deepCopyJSON(value))
+			}
+		case "test":
+			var expected, value interface{}
+			if expected, err = op.decodeValue(); err == nil {
+				if value, err = jsonPointerGet(result, op.Path); err == nil && !reflect.DeepEqual(value, expected) {
+					err = fmt.Errorf("test failed: value at %q does not match", op.Path)
+				}
+			}
+		default:
+			err = fmt.Errorf("unsupported op %q", op.Op)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return result, nil
+}
+
+// parseJSONPointer splits an RFC 6901 pointer into unescaped reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func jsonPointerGet(doc interface{}, pointer string) (interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := doc
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", pointer)
+			}
+			current = value
+		case []interface{}:
+			idx, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, fmt.Errorf("path %q: %w", pointer, err)
+			}
+			current = node[idx]
+		default:
+			return nil, fmt.Errorf("path %q does not exist", pointer)
+		}
+	}
+	return current, nil
+}
+
+func jsonPointerAdd(doc interface{}, pointer string, value interface{}) (interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return addAt(doc, tokens, value, pointer)
+}
+
+func addAt(node interface{}, tokens []string, value interface{}, pointer string) (interface{}, error) {
+	token := tokens[0]
+	last := len(tokens) == 1
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if last {
+			n[token] = value
+			return n, nil
+		}
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("path %q does not exist", pointer)
+		}
+		updated, err := addAt(child, tokens[1:], value, pointer)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = updated
+		return n, nil
+	case []interface{}:
+		if last {
+			idx, err := arrayIndex(token, len(n), true)
+			if err != nil {
+				return nil, fmt.Errorf("path %q: %w", pointer, err)
+			}
+			n = append(n, nil)
+			copy(n[idx+1:], n[idx:])
+			n[idx] = value
+			return n, nil
+		}
+		idx, err := arrayIndex(token, len(n), false)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", pointer, err)
+		}
+		updated, err := addAt(n[idx], tokens[1:], value, pointer)
+		if err != nil {
+			return nil, err
+		}
+		n[idx] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("path %q does not exist", pointer)
+	}
+}
+
+func jsonPointerRemove(doc interface{}, pointer string) (interface{}, interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the document root")
+	}
+	return removeAt(doc, tokens, pointer)
+}
+
+func removeAt(node interface{}, tokens []string, pointer string) (interface{}, interface{}, error) {
+	token := tokens[0]
+	last := len(tokens) == 1
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("path %q does not exist", pointer)
+		}
+		if last {
+			delete(n, token)
+			return n, child, nil
+		}
+		updated, removed, err := removeAt(child, tokens[1:], pointer)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[token] = updated
+		return n, removed, nil
+	case []interface{}:
+		idx, err := arrayIndex(token, len(n), false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("path %q: %w", pointer, err)
+		}
+		if last {
+			removed := n[idx]
+			return append(n[:idx], n[idx+1:]...), removed, nil
+		}
+		updated, removed, err := removeAt(n[idx], tokens[1:], pointer)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[idx] = updated
+		return n, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("path %q does not exist", pointer)
+	}
+}
+
+// arrayIndex parses an array reference token. "-" (and length itself) is only valid
+// when inserting.
+func arrayIndex(token string, length int, insert bool) (int, error) {
+	if insert && token == "-" {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if idx > length || (!insert && idx == length) {
+		return 0, fmt.Errorf("array index %d out of range", idx)
+	}
+	return idx, nil
+}
+
+func deepCopyJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = deepCopyJSON(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = deepCopyJSON(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
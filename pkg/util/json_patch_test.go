@@ -0,0 +1,189 @@
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func decodeJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid test JSON %q: %v", s, err)
+	}
+	return v
+}
+
+func TestMergePatch(t *testing.T) {
+	t.Parallel()
+	// Cases from RFC 7386 appendix A.
+	testCases := []struct {
+		target   string
+		patch    string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.target+"+"+tt.patch, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			result := MergePatch(decodeJSON(t, tt.target), decodeJSON(t, tt.patch))
+			out, err := json.Marshal(result)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(out).To(gomega.MatchJSON(tt.expected))
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		doc      string
+		ops      string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "replace nested value",
+			doc:      `{"release":{"version":"1"},"region":"us"}`,
+			ops:      `[{"op":"replace","path":"/release/version","value":"2"}]`,
+			expected: `{"release":{"version":"2"},"region":"us"}`,
+		},
+		{
+			name:     "add member and append to array",
+			doc:      `{"zones":["a"]}`,
+			ops:      `[{"op":"add","path":"/replicas","value":3},{"op":"add","path":"/zones/-","value":"b"}]`,
+			expected: `{"zones":["a","b"],"replicas":3}`,
+		},
+		{
+			name:     "insert into array",
+			doc:      `{"zones":["a","c"]}`,
+			ops:      `[{"op":"add","path":"/zones/1","value":"b"}]`,
+			expected: `{"zones":["a","b","c"]}`,
+		},
+		{
+			name:     "remove array element",
+			doc:      `{"zones":["a","b","c"]}`,
+			ops:      `[{"op":"remove","path":"/zones/1"}]`,
+			expected: `{"zones":["a","c"]}`,
+		},
+		{
+			name:     "move and copy",
+			doc:      `{"a":{"x":1},"b":{}}`,
+			ops:      `[{"op":"move","from":"/a/x","path":"/b/y"},{"op":"copy","from":"/b","path":"/c"}]`,
+			expected: `{"a":{},"b":{"y":1},"c":{"y":1}}`,
+		},
+		{
+			name:     "escaped pointer tokens",
+			doc:      `{"a/b":1,"m~n":2}`,
+			ops:      `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`,
+			expected: `{"a/b":3}`,
+		},
+		{
+			name:     "passing test op",
+			doc:      `{"version":"1"}`,
+			ops:      `[{"op":"test","path":"/version","value":"1"},{"op":"replace","path":"/version","value":"2"}]`,
+			expected: `{"version":"2"}`,
+		},
+		{
+			name:     "null is a value",
+			doc:      `{"a":1,"b":null}`,
+			ops:      `[{"op":"test","path":"/b","value":null},{"op":"replace","path":"/a","value":null}]`,
+			expected: `{"a":null,"b":null}`,
+		},
+		{
+			name:    "add without value",
+			doc:     `{}`,
+			ops:     `[{"op":"add","path":"/a"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "replace without value",
+			doc:     `{"a":1}`,
+			ops:     `[{"op":"replace","path":"/a"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "test without value",
+			doc:     `{"a":null}`,
+			ops:     `[{"op":"test","path":"/a"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "failing test op aborts the patch",
+			doc:     `{"version":"1"}`,
+			ops:     `[{"op":"test","path":"/version","value":"0"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "replace missing path",
+			doc:     `{}`,
+			ops:     `[{"op":"replace","path":"/missing","value":1}]`,
+			wantErr: true,
+		},
+		{
+			name:    "add below missing parent",
+			doc:     `{}`,
+			ops:     `[{"op":"add","path":"/a/b","value":1}]`,
+			wantErr: true,
+		},
+		{
+			name:    "array index out of range",
+			doc:     `{"zones":["a"]}`,
+			ops:     `[{"op":"remove","path":"/zones/1"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "move into own child",
+			doc:     `{"a":{"b":{}}}`,
+			ops:     `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown op",
+			doc:     `{}`,
+			ops:     `[{"op":"merge","path":"/a","value":1}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var ops []JSONPatchOperation
+			g.Expect(json.Unmarshal([]byte(tt.ops), &ops)).To(gomega.Succeed())
+
+			doc := decodeJSON(t, tt.doc)
+			result, err := ApplyJSONPatch(doc, ops)
+			if tt.wantErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			out, err := json.Marshal(result)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(out).To(gomega.MatchJSON(tt.expected))
+
+			// The input document is left untouched.
+			original, err := json.Marshal(doc)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(original).To(gomega.MatchJSON(tt.doc))
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package validators
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -11,6 +13,8 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/registry"
 )
 
+const componentSchemaPrefix = "#/components/schemas/"
+
 // ResourceSchema represents a validation schema for a specific resource type
 type ResourceSchema struct {
 	Schema   *openapi3.SchemaRef
@@ -42,6 +46,9 @@ func NewSchemaValidator(schemaPath string) (*SchemaValidator, error) {
 	})
 
 	schemas := buildSchemasMap(doc)
+	if err = validateImmutableFields(schemas); err != nil {
+		return nil, err
+	}
 
 	return &SchemaValidator{
 		doc:     doc,
@@ -72,11 +79,248 @@ func buildSchemasMap(doc *openapi3.T) map[string]*ResourceSchema {
 	return schemas
 }
 
+// validateImmutableFields checks that every immutable field of a registered entity names a
+// property of its spec schema, so that a typo cannot silently leave a field mutable.
+func validateImmutableFields(schemas map[string]*ResourceSchema) error {
+	for _, d := range registry.All() {
+		if len(d.ImmutableFields) == 0 {
+			continue
+		}
+		resourceSchema := schemas[d.Plural]
+		if resourceSchema == nil {
+			return fmt.Errorf("entity kind %q declares immutable_fields but has no spec schema", d.Kind)
+		}
+		for _, field := range d.ImmutableFields {
+			if !schemaHasPath(resourceSchema.Schema, strings.Split(field, "."), false) {
+				return fmt.Errorf(
+					"entity kind %q: immutable field %q is not a property of %s",
+					d.Kind, field, resourceSchema.TypeName,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// schemaHasPath reports whether the property path resolves in the schema, looking through
+// allOf, oneOf, and anyOf compositions. With elements set, the path also continues into
+// the items of arrays and the additional properties of objects, including objects that
+// declare no properties, as field projections do.
+func schemaHasPath(ref *openapi3.SchemaRef, path []string, elements bool) bool {
+	if len(path) == 0 {
+		return true
+	}
+	if ref == nil || ref.Value == nil {
+		return false
+	}
+	schema := ref.Value
+	if property := schema.Properties[path[0]]; property != nil && schemaHasPath(property, path[1:], elements) {
+		return true
+	}
+	for _, composed := range [][]*openapi3.SchemaRef{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, member := range composed {
+			if schemaHasPath(member, path, elements) {
+				return true
+			}
+		}
+	}
+	if !elements {
+		return false
+	}
+	if schema.Items != nil && schemaHasPath(schema.Items, path, elements) {
+		return true
+	}
+	additional := schema.AdditionalProperties
+	if additional.Schema != nil {
+		return schemaHasPath(additional.Schema, path[1:], elements)
+	}
+	if additional.Has != nil {
+		return *additional.Has
+	}
+	// an object that declares no properties of its own holds arbitrary keys
+	return schema.Type.Is(openapi3.TypeObject) && len(schema.Properties) == 0 && len(schema.AllOf) == 0
+}
+
+// HasSpecField reports whether the dotted field path, split into segments, selects part
+// of a spec of the given resource plural: every segment names a property, descending into
+// array items and additional properties. It is true when no schema is loaded for the
+// plural, as there is nothing to check the path against.
+func (v *SchemaValidator) HasSpecField(resourcePlural string, path []string) bool {
+	if v == nil || v.schemas[resourcePlural] == nil {
+		return true
+	}
+	return schemaHasPath(v.schemas[resourcePlural].Schema, path, true)
+}
+
 // HasSchema reports whether a validation schema was loaded for the given resource plural.
 func (v *SchemaValidator) HasSchema(resourcePlural string) bool {
 	return v.schemas[resourcePlural] != nil
 }
 
+// ComponentSchemas returns the component schemas of the loaded OpenAPI spec, or nil when
+// the validator is nil. The returned schemas are shared and must not be modified.
+func (v *SchemaValidator) ComponentSchemas() openapi3.Schemas {
+	if v == nil || v.doc.Components == nil {
+		return nil
+	}
+	return v.doc.Components.Schemas
+}
+
+// SpecJSONSchema returns the spec schema of the given resource plural as a JSON object,
+// with references to other component schemas inlined so it stands on its own. A schema
+// that refers to itself keeps its $ref at the point of recursion. Returns nil when no
+// schema is loaded for the plural.
+func (v *SchemaValidator) SpecJSONSchema(resourcePlural string) (map[string]interface{}, error) {
+	if v == nil || v.schemas[resourcePlural] == nil {
+		return nil, nil
+	}
+	resourceSchema := v.schemas[resourcePlural]
+	inlined, err := v.inlineSchema(resourceSchema.Schema.Value, map[string]bool{resourceSchema.TypeName: true})
+	if err != nil {
+		return nil, fmt.Errorf("inline %s: %w", resourceSchema.TypeName, err)
+	}
+	object, ok := inlined.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("inline %s: schema is not a JSON object", resourceSchema.TypeName)
+	}
+	return object, nil
+}
+
+func (v *SchemaValidator) inlineSchema(schema *openapi3.Schema, visiting map[string]bool) (interface{}, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var node interface{}
+	if err = json.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	return v.inlineRefs(node, visiting)
+}
+
+func (v *SchemaValidator) inlineRefs(node interface{}, visiting map[string]bool) (interface{}, error) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := n["$ref"].(string); ok {
+			name, local := strings.CutPrefix(ref, componentSchemaPrefix)
+			target := v.ComponentSchemas()[name]
+			if !local || target == nil || target.Value == nil || visiting[name] {
+				return n, nil
+			}
+			visiting[name] = true
+			defer delete(visiting, name)
+			return v.inlineSchema(target.Value, visiting)
+		}
+		for key, value := range n {
+			inlined, err := v.inlineRefs(value, visiting)
+			if err != nil {
+				return nil, err
+			}
+			n[key] = inlined
+		}
+	case []interface{}:
+		for i, value := range n {
+			inlined, err := v.inlineRefs(value, visiting)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = inlined
+		}
+	}
+	return node, nil
+}
+
+// ApplyDefaults fills the default values declared by the schema of the given resource plural
+// into the JSON object spec: a property missing from an object is set to a copy of its
+// default, then objects and arrays of objects are defaulted recursively. A missing object
+// without a default of its own is left missing. Numbers are decoded as json.Number, so
+// values the spec already holds are written back unchanged; spec itself is returned when
+// no schema is loaded for the plural or no default applies.
+func (v *SchemaValidator) ApplyDefaults(resourcePlural string, spec []byte) ([]byte, error) {
+	if v == nil || len(spec) == 0 {
+		return spec, nil
+	}
+	resourceSchema := v.schemas[resourcePlural]
+	if resourceSchema == nil {
+		return spec, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(spec))
+	decoder.UseNumber()
+	var specData map[string]interface{}
+	if err := decoder.Decode(&specData); err != nil {
+		return nil, fmt.Errorf("failed to decode spec: %w", err)
+	}
+	if specData == nil || !applyDefaults(resourceSchema.Schema, specData) {
+		return spec, nil
+	}
+	defaulted, err := json.Marshal(specData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode defaulted spec: %w", err)
+	}
+	return defaulted, nil
+}
+
+func applyDefaults(ref *openapi3.SchemaRef, value interface{}) bool {
+	if ref == nil || ref.Value == nil {
+		return false
+	}
+	schema := ref.Value
+	applied := false
+	for _, member := range schema.AllOf {
+		applied = applyDefaults(member, value) || applied
+	}
+
+	switch node := value.(type) {
+	case map[string]interface{}:
+		for name, property := range schema.Properties {
+			if property == nil || property.Value == nil {
+				continue
+			}
+			if _, ok := node[name]; !ok && property.Value.Default != nil {
+				node[name] = copyJSONValue(property.Value.Default)
+				applied = true
+			}
+			if child, ok := node[name]; ok {
+				applied = applyDefaults(property, child) || applied
+			}
+		}
+		if additional := schema.AdditionalProperties.Schema; additional != nil {
+			for name, child := range node {
+				if _, declared := schema.Properties[name]; !declared {
+					applied = applyDefaults(additional, child) || applied
+				}
+			}
+		}
+	case []interface{}:
+		for _, item := range node {
+			applied = applyDefaults(schema.Items, item) || applied
+		}
+	}
+	return applied
+}
+
+// copyJSONValue deep-copies a decoded JSON value, so defaults written into a spec never
+// alias the schema.
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyJSONValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyJSONValue(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
 // Validate validates a spec for the given resource plural (URL path segment).
 // Returns nil when no schema is loaded for the plural (validation skipped).
 func (v *SchemaValidator) Validate(resourcePlural string, spec map[string]interface{}) error {
@@ -92,7 +336,10 @@ func (v *SchemaValidator) Validate(resourcePlural string, spec map[string]interf
 func (v *SchemaValidator) validateSpec(
 	spec map[string]interface{}, schemaRef *openapi3.SchemaRef, specTypeName string,
 ) error {
-	var specData interface{} = spec
+	// The spec is checked as it will be stored, with the schema's defaults filled in, so a
+	// required property that declares a default may be omitted. spec itself is not changed.
+	specData := copyJSONValue(spec)
+	applyDefaults(schemaRef, specData)
 
 	if err := schemaRef.Value.VisitJSON(specData); err != nil {
 		validationDetails := convertValidationError(err, "spec")
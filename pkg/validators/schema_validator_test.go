@@ -3,6 +3,7 @@ package validators
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -494,6 +495,164 @@ func TestValidateNodePoolSpec_EmptyMachineType(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 }
 
+func TestNewSchemaValidator_ImmutableFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	registerRequiredSpecValidationEntities()
+	tmpDir := t.TempDir()
+	schemaPath := filepath.Join(tmpDir, "test-schema.yaml")
+	Expect(os.WriteFile(schemaPath, []byte(testSchema), 0600)).To(Succeed())
+
+	registry.UpdateDescriptor("Cluster", func(d *registry.EntityDescriptor) {
+		d.ImmutableFields = []string{"region", "network.vpc_id"}
+	})
+	_, err := NewSchemaValidator(schemaPath)
+	Expect(err).To(BeNil())
+
+	registry.UpdateDescriptor("Cluster", func(d *registry.EntityDescriptor) {
+		d.ImmutableFields = []string{"network.subnet_id"}
+	})
+	_, err = NewSchemaValidator(schemaPath)
+	Expect(err).To(MatchError(ContainSubstring(`immutable field "network.subnet_id" is not a property of ClusterSpec`)))
+
+	registry.Register(registry.EntityDescriptor{
+		Kind:            "Channel",
+		Plural:          "channels",
+		ImmutableFields: []string{"display_name"},
+	})
+	registry.UpdateDescriptor("Cluster", func(d *registry.EntityDescriptor) {
+		d.ImmutableFields = nil
+	})
+	_, err = NewSchemaValidator(schemaPath)
+	Expect(err).To(MatchError(ContainSubstring(`entity kind "Channel" declares immutable_fields but has no spec schema`)))
+}
+
+const defaultsSchema = `
+openapi: 3.0.0
+info:
+  title: Defaults Schema
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ClusterSpec:
+      allOf:
+        - $ref: '#/components/schemas/Platform'
+        - type: object
+          required: [region]
+          properties:
+            region:
+              type: string
+              default: us-east1
+            network:
+              type: object
+              properties:
+                mtu:
+                  type: integer
+                  default: 1500
+            pools:
+              type: array
+              items:
+                type: object
+                properties:
+                  name:
+                    type: string
+                  replicas:
+                    type: integer
+                    default: 2
+            labels:
+              type: object
+              default:
+                managed: "true"
+    Platform:
+      type: object
+      properties:
+        platform:
+          type: string
+          default: gcp
+`
+
+func TestApplyDefaults(t *testing.T) {
+	RegisterTestingT(t)
+
+	registerRequiredSpecValidationEntities()
+	schemaPath := filepath.Join(t.TempDir(), "defaults-schema.yaml")
+	Expect(os.WriteFile(schemaPath, []byte(defaultsSchema), 0600)).To(Succeed())
+	validator, err := NewSchemaValidator(schemaPath)
+	Expect(err).NotTo(HaveOccurred())
+
+	// 2^53+1 cannot be held by a float64; it must be written back as sent.
+	defaulted, err := validator.ApplyDefaults("clusters",
+		[]byte(`{"network":{},"pools":[{"name":"a"},{"name":"b","replicas":9007199254740993}]}`))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(string(defaulted)).To(MatchJSON(`{
+		"platform": "gcp",
+		"region": "us-east1",
+		"network": {"mtu": 1500},
+		"pools": [{"name": "a", "replicas": 2}, {"name": "b", "replicas": 9007199254740993}],
+		"labels": {"managed": "true"}
+	}`))
+	Expect(string(defaulted)).To(ContainSubstring("9007199254740993"))
+
+	complete := []byte(`{"platform":"aws", "region":"eu-west1", "labels":{}, "network":{"mtu":9000}}`)
+	unchanged, err := validator.ApplyDefaults("clusters", complete)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(unchanged).To(Equal(complete), "a spec no default applies to is returned as sent")
+
+	unknown := []byte(`{"a":1}`)
+	Expect(validator.ApplyDefaults("widgets", unknown)).To(Equal(unknown))
+	_, err = validator.ApplyDefaults("clusters", []byte(`[1]`))
+	Expect(err).To(HaveOccurred())
+}
+
+func TestHasSpecField(t *testing.T) {
+	RegisterTestingT(t)
+
+	registerRequiredSpecValidationEntities()
+	schemaPath := filepath.Join(t.TempDir(), "defaults-schema.yaml")
+	Expect(os.WriteFile(schemaPath, []byte(defaultsSchema), 0600)).To(Succeed())
+	validator, err := NewSchemaValidator(schemaPath)
+	Expect(err).NotTo(HaveOccurred())
+
+	tests := []struct {
+		field    string
+		expected bool
+	}{
+		{"region", true},
+		{"platform", true},
+		{"network.mtu", true},
+		{"pools.replicas", true},
+		{"labels.team", true},
+		{"zone", false},
+		{"network.gateway", false},
+		{"region.name", false},
+	}
+	for _, tt := range tests {
+		Expect(validator.HasSpecField("clusters", strings.Split(tt.field, "."))).To(Equal(tt.expected), tt.field)
+	}
+	Expect(validator.HasSpecField("widgets", []string{"zone"})).To(BeTrue(), "no schema to check against")
+	Expect((*SchemaValidator)(nil).HasSpecField("clusters", []string{"zone"})).To(BeTrue())
+}
+
+func TestValidate_ChecksDefaultedSpec(t *testing.T) {
+	RegisterTestingT(t)
+
+	registerRequiredSpecValidationEntities()
+	schemaPath := filepath.Join(t.TempDir(), "defaults-schema.yaml")
+	Expect(os.WriteFile(schemaPath, []byte(defaultsSchema), 0600)).To(Succeed())
+	validator, err := NewSchemaValidator(schemaPath)
+	Expect(err).NotTo(HaveOccurred())
+
+	// region is required but has a default, so it may be omitted.
+	spec := map[string]interface{}{"network": map[string]interface{}{}}
+	Expect(validator.Validate("clusters", spec)).To(Succeed())
+	Expect(spec).To(Equal(map[string]interface{}{"network": map[string]interface{}{}}),
+		"validation does not change the spec")
+
+	invalid := map[string]interface{}{"region": float64(1)}
+	Expect(validator.Validate("clusters", invalid)).NotTo(Succeed())
+}
+
 // Helper functions
 
 func setupTestValidator(t *testing.T) *SchemaValidator {
@@ -575,3 +734,54 @@ func getServiceError(err error) *errors.ServiceError {
 	}
 	return nil
 }
+
+func TestSpecJSONSchema_InlinesReferences(t *testing.T) {
+	RegisterTestingT(t)
+
+	registry.Reset()
+	registry.Register(registry.EntityDescriptor{
+		Kind:           "Channel",
+		Plural:         "channels",
+		SpecSchemaName: "ChannelSpec",
+	})
+
+	schemaPath := filepath.Join(t.TempDir(), "test-schema.yaml")
+	err := os.WriteFile(schemaPath, []byte(`
+openapi: 3.0.0
+info:
+  title: Test Schema
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ChannelSpec:
+      type: object
+      properties:
+        mirror:
+          $ref: '#/components/schemas/Mirror'
+    Mirror:
+      type: object
+      properties:
+        url:
+          type: string
+        fallback:
+          $ref: '#/components/schemas/Mirror'
+`), 0600)
+	Expect(err).To(BeNil())
+	validator, err := NewSchemaValidator(schemaPath)
+	Expect(err).To(BeNil())
+
+	schema, err := validator.SpecJSONSchema("channels")
+	Expect(err).To(BeNil())
+	Expect(schema).To(HaveKeyWithValue("type", "object"))
+
+	mirror := schema["properties"].(map[string]interface{})["mirror"].(map[string]interface{})
+	Expect(mirror).NotTo(HaveKey("$ref"))
+	Expect(mirror["properties"]).To(HaveKey("url"))
+	fallback := mirror["properties"].(map[string]interface{})["fallback"]
+	Expect(fallback).To(Equal(map[string]interface{}{"$ref": "#/components/schemas/Mirror"}))
+
+	missing, err := validator.SpecJSONSchema("versions")
+	Expect(err).To(BeNil())
+	Expect(missing).To(BeNil())
+}
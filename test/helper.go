@@ -247,11 +247,18 @@ func (helper *Helper) startAPIServer() {
 	jwtHandler := helper.Container.JWTHandler()
 	helper.jwtHandler = jwtHandler
 
+	openapiHandler, err := servecmd.NewOpenAPIHandler(helper.Container.SchemaValidator())
+	if err != nil {
+		abortSetup(ctx, helper.closer, err, "Unable to build Test OpenAPI document")
+	}
 	apiServer, err := servecmd.BuildAPIServer(
 		cfg,
 		helper.Container.ResourceService(),
 		helper.Container.AdapterStatusService(),
+		helper.Container.WatchService(),
+		helper.Container.IdempotencyService(),
 		helper.Container.SchemaValidator(),
+		openapiHandler,
 		jwtHandler,
 		helper.DBFactory,
 	)
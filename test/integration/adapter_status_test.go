@@ -7,6 +7,7 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
 
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
 	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/openapi"
@@ -82,6 +83,57 @@ func TestClusterStatusPut(t *testing.T) {
 	Expect(len(resp.JSON201.Conditions)).To(BeNumerically(">", 0))
 }
 
+// TestClusterStatusPut_ChangesETag verifies that a status report, which leaves the
+// cluster's generation and updated_time alone, still fails a conditional GET.
+func TestClusterStatusPut_ChangesETag(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	token := test.GetAccessTokenFromContext(ctx)
+
+	cluster, err := h.Factories.NewClusters(h.NewID())
+	Expect(err).NotTo(HaveOccurred())
+
+	get := func(etag string) *resty.Response {
+		resp, getErr := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+			SetHeader("If-None-Match", etag).
+			Get(h.RestURL("/clusters/" + cluster.ID))
+		Expect(getErr).NotTo(HaveOccurred())
+		return resp
+	}
+
+	first := get("")
+	Expect(first.StatusCode()).To(Equal(http.StatusOK), string(first.Body()))
+	etag := first.Header().Get("ETag")
+	Expect(etag).NotTo(BeEmpty())
+	Expect(get(etag).StatusCode()).To(Equal(http.StatusNotModified))
+
+	statusInput := newAdapterStatusRequest(
+		"validation",
+		cluster.Generation,
+		[]openapi.ConditionRequest{
+			{Type: api.AdapterConditionTypeAvailable, Status: openapi.AdapterConditionStatusTrue},
+			{Type: api.AdapterConditionTypeApplied, Status: openapi.AdapterConditionStatusTrue},
+			{Type: api.AdapterConditionTypeHealth, Status: openapi.AdapterConditionStatusTrue},
+			{Type: api.AdapterConditionTypeReconciled, Status: openapi.AdapterConditionStatusTrue},
+		},
+		nil,
+	)
+	resp, err := client.PutClusterStatusesWithResponse(
+		ctx, cluster.ID,
+		openapi.PutClusterStatusesJSONRequestBody(statusInput), test.WithAuthToken(ctx),
+	)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusCreated))
+
+	after := get(etag)
+	Expect(after.StatusCode()).To(Equal(http.StatusOK), string(after.Body()))
+	Expect(after.Header().Get("ETag")).NotTo(Equal(etag))
+	Expect(get(after.Header().Get("ETag")).StatusCode()).To(Equal(http.StatusNotModified))
+}
+
 // TestClusterStatusGet tests retrieving adapter statuses for a cluster
 func TestClusterStatusGet(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
@@ -690,7 +742,7 @@ func TestAdapterStatusPagingEdgeCases(t *testing.T) {
 	emptyResp, err := client.GetClusterStatusesWithResponse(ctx, emptyCluster.ID, nil, test.WithAuthToken(ctx))
 	Expect(err).NotTo(HaveOccurred())
 	Expect(emptyResp.JSON200).NotTo(BeNil())
-	Expect(emptyResp.JSON200.Total).To(Equal(int32(0)))
+	Expect(emptyResp.JSON200.Total).To(HaveValue(Equal(int32(0))))
 	Expect(len(emptyResp.JSON200.Items)).To(Equal(0))
 
 	// Test 2: Page beyond total pages
@@ -704,7 +756,7 @@ func TestAdapterStatusPagingEdgeCases(t *testing.T) {
 	Expect(err).NotTo(HaveOccurred())
 	Expect(beyondResp.JSON200).NotTo(BeNil())
 	Expect(len(beyondResp.JSON200.Items)).To(Equal(0), "Should return empty when page exceeds total pages")
-	Expect(beyondResp.JSON200.Total).To(Equal(int32(10)), "Total should still reflect actual count")
+	Expect(beyondResp.JSON200.Total).To(HaveValue(Equal(int32(10))), "Total should still reflect actual count")
 
 	// Test 3: Single item dataset
 	singleCluster, err := h.Factories.NewClusters(h.NewID())
@@ -742,7 +794,7 @@ func TestAdapterStatusPagingEdgeCases(t *testing.T) {
 	singleResp, err := client.GetClusterStatusesWithResponse(ctx, singleCluster.ID, nil, test.WithAuthToken(ctx))
 	Expect(err).NotTo(HaveOccurred())
 	Expect(singleResp.JSON200).NotTo(BeNil())
-	Expect(singleResp.JSON200.Total).To(Equal(int32(1)))
+	Expect(singleResp.JSON200.Total).To(HaveValue(Equal(int32(1))))
 	Expect(len(singleResp.JSON200.Items)).To(Equal(1))
 	Expect(singleResp.JSON200.Page).To(Equal(int32(1)))
 
@@ -1181,7 +1233,7 @@ func TestClusterStatusPut_FutureRejectedThenCurrentAccepted(t *testing.T) {
 	// Verify no status record was created by the rejected request
 	listResp, err := client.GetClusterStatusesWithResponse(ctx, cluster.ID, nil, test.WithAuthToken(ctx))
 	Expect(err).NotTo(HaveOccurred())
-	Expect(listResp.JSON200.Total).To(Equal(int32(0)),
+	Expect(listResp.JSON200.Total).To(HaveValue(Equal(int32(0))),
 		"No adapter status should be created when observed_time is rejected")
 
 	// Step 2: Accepted (current timestamp, same adapter name)
@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+	"github.com/openshift-hyperfleet/hyperfleet-api/test/factories"
+)
+
+// TestAggregate verifies that :aggregate counts the resources a list would return
+// per group.
+func TestAggregate(t *testing.T) {
+	RegisterTestingT(t)
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	token := test.GetAccessTokenFromContext(ctx)
+
+	// A run-specific label keeps clusters from other tests out of the counts.
+	run := uuid.NewString()[:8]
+	for _, labels := range []map[string]string{
+		{"region": "us-east", "tier": "web"},
+		{"region": "us-east", "tier": "api"},
+		{"region": "eu-west", "tier": "web"},
+		{},
+	} {
+		labels["run"] = run
+		_, err := factories.NewClusterWithLabels(&h.Factories, h.DBFactory, h.NewID(), labels)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	type aggregate struct {
+		Groups []struct {
+			Values map[string]*string `json:"values"`
+			Count  int64              `json:"count"`
+		} `json:"groups"`
+		Total int64 `json:"total"`
+	}
+	get := func(path string, query url.Values) (int, aggregate) {
+		query.Set("labelSelector", "run="+run)
+		resp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+			Get(h.RestURL(path + "?" + query.Encode()))
+		Expect(err).NotTo(HaveOccurred())
+		var result aggregate
+		if resp.StatusCode() == http.StatusOK {
+			Expect(json.Unmarshal(resp.Body(), &result)).To(Succeed())
+		}
+		return resp.StatusCode(), result
+	}
+	str := func(s string) *string { return &s }
+
+	t.Run("GroupByLabel", func(t *testing.T) {
+		status, result := get("/clusters:aggregate", url.Values{"groupBy": {"labels.region"}})
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(result.Total).To(Equal(int64(4)))
+		Expect(result.Groups).To(HaveLen(3))
+		Expect(result.Groups[0].Values).To(HaveKeyWithValue("labels.region", str("us-east")))
+		Expect(result.Groups[0].Count).To(Equal(int64(2)))
+		Expect(result.Groups[1].Values).To(HaveKeyWithValue("labels.region", str("eu-west")))
+		Expect(result.Groups[1].Count).To(Equal(int64(1)))
+		Expect(result.Groups[2].Values).To(HaveKeyWithValue("labels.region", BeNil()))
+		Expect(result.Groups[2].Count).To(Equal(int64(1)))
+	})
+
+	t.Run("WithSearch", func(t *testing.T) {
+		status, result := get("/clusters:aggregate", url.Values{
+			"groupBy": {"labels.region,labels.tier"},
+			"search":  {"labels.tier='web'"},
+		})
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(result.Total).To(Equal(int64(2)))
+		Expect(result.Groups).To(HaveLen(2))
+	})
+
+	t.Run("AcrossKinds", func(t *testing.T) {
+		status, result := get("/resources:aggregate", url.Values{"groupBy": {"kind"}})
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(result.Groups).To(HaveLen(1))
+		Expect(result.Groups[0].Values).To(HaveKeyWithValue("kind", str("Cluster")))
+		Expect(result.Groups[0].Count).To(Equal(int64(4)))
+	})
+
+	t.Run("InvalidGroupBy", func(t *testing.T) {
+		status, _ := get("/clusters:aggregate", url.Values{"groupBy": {"name"}})
+		Expect(status).To(Equal(http.StatusBadRequest))
+	})
+}
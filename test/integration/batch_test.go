@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+)
+
+// TestBatch verifies that a batch applies its operations in order, resolving temporary IDs,
+// and that a failing operation rolls back the ones before it.
+func TestBatch(t *testing.T) {
+	RegisterTestingT(t)
+	h, _ := test.RegisterIntegration(t)
+	svc := h.Container.ResourceService()
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	token := test.GetAccessTokenFromContext(ctx)
+	request := func() *resty.Request {
+		return resty.R().
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	channelName := fmt.Sprintf("batch-%s", uuid.NewString()[:8])
+	body := fmt.Sprintf(`{"operations": [
+		{"op": "create", "ref": "ch", "resource": {"kind": "Channel", "name": %q,
+			"spec": {"is_default": false, "enabled_regex": ".*"}}},
+		{"op": "create", "parent_id": "$ch", "resource": {"kind": "Version", "name": "4.17.0",
+			"spec": {"raw_version": "4.17.0", "enabled": true, "is_default": false,
+				"release_image": "quay.io/openshift-release-dev/ocp-release:4.17.0"}}},
+		{"op": "patch", "kind": "Channel", "id": "$ch", "patch": {"labels": {"env": "prod"}}}
+	]}`, channelName)
+
+	resp, err := request().SetBody(body).Post(h.RestURL("/resources:batch"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK), string(resp.Body()))
+
+	var result struct {
+		Results []struct {
+			Resource struct {
+				ID         string `json:"id"`
+				Generation int32  `json:"generation"`
+			} `json:"resource"`
+			Status int `json:"status"`
+		} `json:"results"`
+	}
+	Expect(json.Unmarshal(resp.Body(), &result)).To(Succeed())
+	Expect(result.Results).To(HaveLen(3))
+	channelID := result.Results[0].Resource.ID
+	Expect(result.Results[1].Status).To(Equal(http.StatusCreated))
+	Expect(result.Results[2].Resource.ID).To(Equal(channelID))
+	Expect(result.Results[2].Resource.Generation).To(Equal(int32(2)))
+
+	version, svcErr := svc.Get(t.Context(), "Version", result.Results[1].Resource.ID)
+	Expect(svcErr).To(BeNil())
+	Expect(*version.OwnerID).To(Equal(channelID))
+
+	// The delete of a missing resource fails the batch, so the create before it is undone.
+	rolledBackName := fmt.Sprintf("batch-%s", uuid.NewString()[:8])
+	body = fmt.Sprintf(`{"operations": [
+		{"op": "create", "resource": {"kind": "Channel", "name": %q,
+			"spec": {"is_default": false, "enabled_regex": ".*"}}},
+		{"op": "delete", "kind": "Channel", "id": %q}
+	]}`, rolledBackName, uuid.NewString())
+
+	resp, err = request().SetBody(body).Post(h.RestURL("/resources:batch"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusNotFound), string(resp.Body()))
+
+	var problem struct {
+		Operations []struct {
+			Status int `json:"status"`
+		} `json:"operations"`
+	}
+	Expect(json.Unmarshal(resp.Body(), &problem)).To(Succeed())
+	Expect(problem.Operations).To(HaveLen(2))
+	Expect(problem.Operations[0].Status).To(Equal(http.StatusFailedDependency))
+
+	createChannel(t, svc, rolledBackName)
+}
@@ -202,6 +202,49 @@ func TestChannelList(t *testing.T) {
 		Expect(len(list)).To(BeNumerically(">=", 5), "page 2 should have at least 5 items")
 	})
 
+	t.Run("Continue", func(t *testing.T) {
+		svc, _ := setupResourceTest(t)
+
+		prefix := uuid.NewString()[:8]
+		for i := range 5 {
+			createChannel(t, svc, fmt.Sprintf("continue-%s-%d", prefix, i))
+		}
+
+		// Walk the list two at a time without counting, following the continue token
+		args := &services.ListArguments{
+			Page:      1,
+			Size:      2,
+			Search:    fmt.Sprintf("name like 'continue-%s-%%'", prefix),
+			Order:     []string{"name asc"},
+			SkipCount: true,
+		}
+		var names []string
+		for pages := 1; ; pages++ {
+			list, paging, svcErr := svc.List(t.Context(), "Channel", args)
+			Expect(svcErr).To(BeNil(), "list should succeed")
+			Expect(paging.Total).To(BeZero(), "total should not be counted")
+			for _, item := range list {
+				names = append(names, item.Name)
+			}
+			if paging.Continue == "" {
+				Expect(pages).To(Equal(3))
+				break
+			}
+			args.Continue = paging.Continue
+		}
+
+		Expect(names).To(HaveLen(5))
+		for i, name := range names {
+			Expect(name).To(Equal(fmt.Sprintf("continue-%s-%d", prefix, i)))
+		}
+
+		// A token only resumes the order it was issued for
+		args.Order = []string{"name desc"}
+		_, _, svcErr := svc.List(t.Context(), "Channel", args)
+		Expect(svcErr).ToNot(BeNil())
+		Expect(svcErr.HTTPCode).To(Equal(400))
+	})
+
 	t.Run("WithOrdering", func(t *testing.T) {
 		svc, _ := setupResourceTest(t)
 
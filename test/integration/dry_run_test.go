@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/handlers"
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+)
+
+// TestDryRun verifies that ?dryRun=true runs a write in full and returns its result
+// without changing anything.
+func TestDryRun(t *testing.T) {
+	RegisterTestingT(t)
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	token := test.GetAccessTokenFromContext(ctx)
+	request := func() *resty.Request {
+		return resty.R().
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	svc := h.Container.ResourceService()
+
+	name := fmt.Sprintf("dry-%s", uuid.NewString()[:8])
+	body := fmt.Sprintf(`{"kind": "Channel", "name": %q, "spec": {"is_default": false, "enabled_regex": ".*"}}`, name)
+
+	t.Run("Create", func(t *testing.T) {
+		resp, err := request().SetBody(body).Post(h.RestURL("/channels?dryRun=true"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode()).To(Equal(http.StatusCreated), string(resp.Body()))
+		Expect(resp.Header().Get(handlers.DryRunHeader)).To(Equal("true"))
+
+		var created struct {
+			ID         string `json:"id"`
+			Generation int32  `json:"generation"`
+		}
+		Expect(json.Unmarshal(resp.Body(), &created)).To(Succeed())
+		Expect(created.Generation).To(Equal(int32(1)))
+
+		_, svcErr := svc.Get(t.Context(), "Channel", created.ID)
+		Expect(svcErr).ToNot(BeNil())
+		Expect(svcErr.HTTPCode).To(Equal(http.StatusNotFound))
+	})
+
+	t.Run("CreateInvalid", func(t *testing.T) {
+		resp, err := request().SetBody(`{"kind": "Channel", "name": "", "spec": {}}`).
+			Post(h.RestURL("/channels?dryRun=true"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest), string(resp.Body()))
+	})
+
+	created, createErr := request().SetBody(body).Post(h.RestURL("/channels"))
+	Expect(createErr).NotTo(HaveOccurred())
+	Expect(created.StatusCode()).To(Equal(http.StatusCreated), string(created.Body()))
+	var channel struct {
+		ID string `json:"id"`
+	}
+	Expect(json.Unmarshal(created.Body(), &channel)).To(Succeed())
+
+	t.Run("Patch", func(t *testing.T) {
+		resp, err := request().SetBody(`{"labels": {"tier": "gold"}}`).
+			Patch(h.RestURL("/channels/" + channel.ID + "?dryRun=true"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode()).To(Equal(http.StatusOK), string(resp.Body()))
+		Expect(resp.Header().Get(handlers.DryRunHeader)).To(Equal("true"))
+
+		var patched struct {
+			Labels     map[string]string `json:"labels"`
+			Generation int32             `json:"generation"`
+		}
+		Expect(json.Unmarshal(resp.Body(), &patched)).To(Succeed())
+		Expect(patched.Labels).To(HaveKeyWithValue("tier", "gold"))
+
+		stored, svcErr := svc.Get(t.Context(), "Channel", channel.ID)
+		Expect(svcErr).To(BeNil())
+		Expect(labelsToMap(stored.Labels)).ToNot(HaveKey("tier"))
+		Expect(stored.Generation).To(Equal(int32(1)))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		resp, err := request().Delete(h.RestURL("/resources/" + channel.ID + "?dryRun=true"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode()).To(Equal(http.StatusAccepted), string(resp.Body()))
+		Expect(resp.Header().Get(handlers.DryRunHeader)).To(Equal("true"))
+
+		stored, svcErr := svc.Get(t.Context(), "Channel", channel.ID)
+		Expect(svcErr).To(BeNil())
+		Expect(stored.DeletedTime).To(BeNil())
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		resp, err := request().Delete(h.RestURL("/channels/" + channel.ID + "?dryRun=soon"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest), string(resp.Body()))
+	})
+}
@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/events"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+)
+
+// failingSink rejects every event.
+type failingSink struct{}
+
+func (failingSink) Send(context.Context, json.RawMessage) error {
+	return fmt.Errorf("sink unavailable")
+}
+
+// TestOutboxDeliversCommittedChanges verifies that resource writes queue CloudEvents in
+// the outbox within their transaction and that the dispatcher delivers and removes them,
+// keeping failed deliveries queued for retry.
+func TestOutboxDeliversCommittedChanges(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+	ctr := h.Container
+	sf := ctr.SessionFactory()
+	outboxDao := ctr.ResourceOutboxDao()
+
+	svc, err := services.NewResourceService(
+		ctr.ResourceDao(), ctr.ResourceLabelDao(), ctr.AdapterStatusDao(), ctr.ResourceConditionDao(),
+		ctr.ResourceChangeDao(), ctr.ResourceEventDao(), ctr.ResourceRevisionDao(),
+		events.NewOutbox(outboxDao, "hyperfleet-test"), ctr.GenericService(), nil,
+	)
+	Expect(err).NotTo(HaveOccurred())
+
+	ctx := tenancyCtx(map[string]string{tenancyOrgKey: "acme"})
+	cluster, svcErr := createInTx(ctx, sf, svc, newTenancyCluster("outbox-cluster"))
+	Expect(svcErr).To(BeNil())
+
+	cfg := config.NewEventsConfig()
+	queued := func() []*api.OutboxEvent {
+		pending, findErr := outboxDao.FindDue(context.Background(), 1000)
+		Expect(findErr).NotTo(HaveOccurred())
+		var mine []*api.OutboxEvent
+		for _, e := range pending {
+			if e.ResourceID == cluster.ID {
+				mine = append(mine, e)
+			}
+		}
+		return mine
+	}
+	Expect(queued()).To(HaveLen(1))
+
+	// A failing sink leaves the event queued and pushes its next attempt back.
+	events.NewDispatcher(outboxDao, sf, failingSink{}, cfg).Dispatch(context.Background())
+	Expect(queued()).To(BeEmpty())
+
+	var attempts int
+	Expect(sf.New(context.Background()).Raw(
+		"SELECT attempts FROM resource_outbox WHERE resource_id = ?", cluster.ID,
+	).Scan(&attempts).Error).To(Succeed())
+	Expect(attempts).To(Equal(1))
+
+	// Make it due again and deliver it.
+	Expect(sf.New(context.Background()).Exec(
+		"UPDATE resource_outbox SET next_attempt_time = now() WHERE resource_id = ?", cluster.ID,
+	).Error).To(Succeed())
+	sink := events.NewMemorySink()
+	events.NewDispatcher(outboxDao, sf, sink, cfg).Dispatch(context.Background())
+	Expect(queued()).To(BeEmpty())
+
+	var delivered []events.Event
+	for _, e := range sink.Events() {
+		if e.Subject == cluster.Href {
+			delivered = append(delivered, e)
+		}
+	}
+	Expect(delivered).To(HaveLen(1))
+	Expect(delivered[0].Type).To(Equal("com.redhat.hyperfleet.cluster.added"))
+	Expect(delivered[0].Source).To(Equal("hyperfleet-test"))
+
+	var data map[string]any
+	Expect(json.Unmarshal(delivered[0].Data, &data)).To(Succeed())
+	Expect(data["id"]).To(Equal(cluster.ID))
+}
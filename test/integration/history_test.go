@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+)
+
+// TestHistoryRecordsMutations verifies that resource mutations land in the audit log with
+// their diff, that history is searchable with TSL and only served to the owning tenant,
+// and that it stays readable after the resource is gone.
+func TestHistoryRecordsMutations(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+	svc := h.Container.ResourceService()
+	sf := h.Container.SessionFactory()
+
+	ctxAcme := tenancyCtx(map[string]string{tenancyOrgKey: "acme"})
+	ctxGlobex := tenancyCtx(map[string]string{tenancyOrgKey: "globex"})
+
+	cluster, svcErr := createInTx(ctxAcme, sf, svc, newTenancyCluster("acme-history"))
+	Expect(svcErr).To(BeNil())
+
+	txCtx, err := db.NewContext(ctxAcme, sf)
+	Expect(err).NotTo(HaveOccurred())
+	_, svcErr = svc.Patch(txCtx, tenancyClusterKind, cluster.ID, &api.ResourcePatch{
+		Spec: map[string]interface{}{"region": "europe-west1", "provider": "gcp"},
+	})
+	db.Resolve(txCtx)
+	Expect(svcErr).To(BeNil())
+
+	Expect(deleteInTx(ctxAcme, sf, svc, cluster.ID)).To(BeNil())
+
+	args := services.NewListArguments()
+	args.Order = []string{"created_time asc"}
+	events, paging, svcErr := svc.History(ctxAcme, tenancyClusterKind, cluster.ID, "", args)
+	Expect(svcErr).To(BeNil())
+	Expect(paging.Total).To(Equal(int64(3)))
+	Expect(events[0].Action).To(Equal(api.AuditCreate))
+	Expect(events[1].Action).To(Equal(api.AuditPatch))
+	Expect(events[1].Changes).To(MatchJSON(`[{"path":"spec.region","old":"us-central1","new":"europe-west1"}]`))
+	Expect(events[2].Action).To(Equal(api.AuditDelete))
+
+	args = services.NewListArguments()
+	args.Search = "action = 'PATCH'"
+	events, _, svcErr = svc.History(ctxAcme, tenancyClusterKind, cluster.ID, "", args)
+	Expect(svcErr).To(BeNil())
+	Expect(events).To(HaveLen(1))
+	Expect(events[0].Generation).To(Equal(int32(2)))
+
+	// A quote in the ID is compared as part of the value, not read as search syntax.
+	_, _, svcErr = svc.History(ctxAcme, "", cluster.ID+"' or resource_id != '", "", nil)
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(404))
+
+	// Another tenant sees neither the events nor the resource.
+	_, _, svcErr = svc.History(ctxGlobex, tenancyClusterKind, cluster.ID, "", nil)
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(404))
+
+	// History outlives the row once the resource is force-deleted.
+	txCtx, err = db.NewContext(ctxAcme, sf)
+	Expect(err).NotTo(HaveOccurred())
+	svcErr = svc.ForceDelete(txCtx, tenancyClusterKind, cluster.ID, "adapter decommissioned")
+	db.Resolve(txCtx)
+	Expect(svcErr).To(BeNil())
+
+	events, _, svcErr = svc.History(ctxAcme, "", cluster.ID, "", nil)
+	Expect(svcErr).To(BeNil())
+	Expect(events).To(HaveLen(4))
+	Expect(events[0].Action).To(Equal(api.AuditForceDelete))
+	Expect(*events[0].Reason).To(Equal("adapter decommissioned"))
+}
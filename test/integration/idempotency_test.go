@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/handlers"
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+)
+
+// TestIdempotencyKey verifies that a create retried with the same Idempotency-Key replays
+// the original response instead of creating the resource again.
+func TestIdempotencyKey(t *testing.T) {
+	RegisterTestingT(t)
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	token := test.GetAccessTokenFromContext(ctx)
+	key := uuid.NewString()
+	request := func() *resty.Request {
+		return resty.R().
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+			SetHeader(handlers.IdempotencyKeyHeader, key)
+	}
+
+	body := fmt.Sprintf(`{"kind": "Channel", "name": "idem-%s", "spec": {"is_default": false, "enabled_regex": ".*"}}`,
+		uuid.NewString()[:8])
+
+	first, err := request().SetBody(body).Post(h.RestURL("/channels"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(first.StatusCode()).To(Equal(http.StatusCreated), string(first.Body()))
+	Expect(first.Header().Get(handlers.IdempotentReplayedHeader)).To(BeEmpty())
+
+	retry, err := request().SetBody(body).Post(h.RestURL("/channels"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(retry.StatusCode()).To(Equal(http.StatusCreated), string(retry.Body()))
+	Expect(retry.Header().Get(handlers.IdempotentReplayedHeader)).To(Equal("true"))
+	Expect(retry.Header().Get("ETag")).To(Equal(first.Header().Get("ETag")))
+	Expect(retry.Body()).To(MatchJSON(first.Body()))
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	Expect(json.Unmarshal(first.Body(), &created)).To(Succeed())
+	_, svcErr := h.Container.ResourceService().Get(t.Context(), "Channel", created.ID)
+	Expect(svcErr).To(BeNil())
+
+	mismatch, err := request().
+		SetBody(fmt.Sprintf(`{"kind": "Channel", "name": "idem-%s", "spec": {}}`, uuid.NewString()[:8])).
+		Post(h.RestURL("/channels"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mismatch.StatusCode()).To(Equal(http.StatusUnprocessableEntity), string(mismatch.Body()))
+}
+
+// TestIdempotencyKey_FailedCreateRunsAgain verifies that a create failing in the database,
+// here on a duplicate name, returns its own error rather than failing to release the key,
+// and that a retry with the same key runs again instead of being replayed.
+func TestIdempotencyKey_FailedCreateRunsAgain(t *testing.T) {
+	RegisterTestingT(t)
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	token := test.GetAccessTokenFromContext(ctx)
+	body := fmt.Sprintf(`{"kind": "Channel", "name": "idem-%s", "spec": {"is_default": false, "enabled_regex": ".*"}}`,
+		uuid.NewString()[:8])
+	request := func() *resty.Request {
+		return resty.R().
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	existing, err := request().SetBody(body).Post(h.RestURL("/channels"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(existing.StatusCode()).To(Equal(http.StatusCreated), string(existing.Body()))
+
+	key := uuid.NewString()
+	for range 2 {
+		resp, postErr := request().SetHeader(handlers.IdempotencyKeyHeader, key).SetBody(body).Post(h.RestURL("/channels"))
+		Expect(postErr).NotTo(HaveOccurred())
+		Expect(resp.StatusCode()).To(Equal(http.StatusConflict), string(resp.Body()))
+		Expect(resp.Header().Get(handlers.IdempotentReplayedHeader)).To(BeEmpty())
+	}
+}
@@ -0,0 +1,65 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+)
+
+// TestKinds verifies that the discovery endpoints present the registered kinds with
+// their spec schemas.
+func TestKinds(t *testing.T) {
+	RegisterTestingT(t)
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	token := test.GetAccessTokenFromContext(ctx)
+
+	resp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+		Get(h.RestURL("/kinds"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK), string(resp.Body()))
+
+	var list struct {
+		Items []struct {
+			Kind   string `json:"kind"`
+			Plural string `json:"plural"`
+		} `json:"items"`
+		Total int `json:"total"`
+	}
+	Expect(json.Unmarshal(resp.Body(), &list)).To(Succeed())
+	Expect(list.Total).To(Equal(len(list.Items)))
+	kinds := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		kinds = append(kinds, item.Kind)
+	}
+	Expect(kinds).To(ContainElements("Cluster", "NodePool", "Channel", "Version"))
+
+	resp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+		Get(h.RestURL("/kinds/Version"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK), string(resp.Body()))
+
+	var version struct {
+		SpecSchema map[string]interface{} `json:"spec_schema"`
+		ParentKind string                 `json:"parent_kind"`
+	}
+	Expect(json.Unmarshal(resp.Body(), &version)).To(Succeed())
+	Expect(version.ParentKind).To(Equal("Channel"))
+	Expect(version.SpecSchema).To(HaveKeyWithValue("type", "object"))
+
+	resp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+		Get(h.RestURL("/kinds/Widget"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusNotFound))
+}
@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+	"github.com/openshift-hyperfleet/hyperfleet-api/test/factories"
+)
+
+// TestLabelSelector verifies that labelSelector filters cluster lists by label,
+// on its own and together with search.
+func TestLabelSelector(t *testing.T) {
+	RegisterTestingT(t)
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	token := test.GetAccessTokenFromContext(ctx)
+
+	// A run-specific label keeps clusters from other tests out of the results.
+	run := uuid.NewString()[:8]
+	newCluster := func(labels map[string]string) string {
+		labels["run"] = run
+		cluster, err := factories.NewClusterWithLabels(&h.Factories, h.DBFactory, h.NewID(), labels)
+		Expect(err).NotTo(HaveOccurred())
+		return cluster.ID
+	}
+	prod := newCluster(map[string]string{"env": "prod", "tier": "web"})
+	staging := newCluster(map[string]string{"env": "staging", "tier": "api"})
+	canary := newCluster(map[string]string{"env": "prod", "canary": "true"})
+	unlabeled := newCluster(map[string]string{})
+
+	list := func(query url.Values) (int, []string) {
+		resp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+			Get(h.RestURL("/clusters?" + query.Encode()))
+		Expect(err).NotTo(HaveOccurred())
+		var page struct {
+			Items []struct {
+				ID string `json:"id"`
+			} `json:"items"`
+		}
+		if resp.StatusCode() == http.StatusOK {
+			Expect(json.Unmarshal(resp.Body(), &page)).To(Succeed())
+		}
+		ids := make([]string, 0, len(page.Items))
+		for _, item := range page.Items {
+			ids = append(ids, item.ID)
+		}
+		return resp.StatusCode(), ids
+	}
+
+	tests := []struct {
+		name     string
+		selector string
+		search   string
+		expected []string
+	}{
+		{name: "Equality", selector: "env=prod", expected: []string{prod, canary}},
+		{name: "Inequality", selector: "env!=prod", expected: []string{staging, unlabeled}},
+		{name: "In", selector: "tier in (web, api)", expected: []string{prod, staging}},
+		{name: "NotIn", selector: "tier notin (web)", expected: []string{staging, canary, unlabeled}},
+		{name: "Exists", selector: "canary", expected: []string{canary}},
+		{name: "NotExists", selector: "!env", expected: []string{unlabeled}},
+		{name: "Multiple", selector: "env=prod,!canary", expected: []string{prod}},
+		{name: "WithSearch", selector: "env=prod", search: "labels.tier='web'", expected: []string{prod}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{"labelSelector": {"run=" + run + "," + tt.selector}}
+			if tt.search != "" {
+				query.Set("search", tt.search)
+			}
+			status, ids := list(query)
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(ids).To(ConsistOf(tt.expected))
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		status, _ := list(url.Values{"labelSelector": {"tier in (web"}})
+		Expect(status).To(Equal(http.StatusBadRequest))
+	})
+}
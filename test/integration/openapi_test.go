@@ -68,6 +68,31 @@ func TestOpenAPIGet(t *testing.T) {
 	Expect(info).To(HaveKey("version"), "Expected 'info' to have 'version' field")
 }
 
+// TestOpenAPIGet_IncludesConfiguredEntities verifies that kinds configured under entities
+// are described alongside the core spec.
+func TestOpenAPIGet_IncludesConfiguredEntities(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	resp, err := resty.R().Get(h.RestURL("/openapi"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+
+	var openAPISpec struct {
+		Paths      map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	Expect(json.Unmarshal(resp.Body(), &openAPISpec)).To(Succeed())
+
+	Expect(openAPISpec.Paths).To(HaveKey("/channels"))
+	Expect(openAPISpec.Paths).To(HaveKey("/channels/{parent_id}/versions"))
+	Expect(openAPISpec.Paths).To(HaveKey("/versions/{id}/statuses"))
+	Expect(openAPISpec.Components.Schemas).To(HaveKey("Channel"))
+	Expect(openAPISpec.Components.Schemas).To(HaveKey("VersionCreateRequest"))
+	Expect(string(openAPISpec.Components.Schemas["Channel"])).To(ContainSubstring("#/components/schemas/ChannelSpec"))
+}
+
 func TestOpenAPIUIGet(t *testing.T) {
 	h, _ := test.RegisterIntegration(t)
 
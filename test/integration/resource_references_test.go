@@ -342,7 +342,7 @@ func TestResourceReferences_Delete(t *testing.T) {
 				Expect(dbErr).To(BeNil(), "target should be hard-deleted from DB")
 			})
 
-			t.Run("ForceDeleteReferencedTarget_Succeeds", func(t *testing.T) {
+			t.Run("ForceDeleteReferencedTarget_HonoursRestrict", func(t *testing.T) {
 				RegisterTestingT(t)
 				svc, h := setupRefTest(t)
 
@@ -362,16 +362,96 @@ func TestResourceReferences_Delete(t *testing.T) {
 				markFinalizing(t, h, target.ID)
 
 				svcErr = svc.ForceDelete(t.Context(), p.target, target.ID, "force delete target")
-				Expect(svcErr).To(BeNil(), "force-delete should bypass reference restriction")
+				Expect(svcErr).ToNot(BeNil(), "force-delete must not bypass on_target_delete restrict")
+				Expect(svcErr.HTTPCode).To(Equal(409))
 
-				_, getErr := svc.Get(t.Context(), p.target, target.ID)
-				Expect(getErr).ToNot(BeNil())
-				Expect(getErr.HTTPCode).To(Equal(404), "target should be gone after force-delete")
+				dbErr := checkResourceCount(t.Context(), h, []string{target.ID}, 1)
+				Expect(dbErr).To(BeNil(), "target should survive the rejected force-delete")
 			})
 		})
 	}
 }
 
+// setOptSourceTargetDelete temporarily sets the on_target_delete policy of the
+// OptSource "link" reference.
+func setOptSourceTargetDelete(t *testing.T, policy registry.OnTargetDeletePolicy) {
+	t.Helper()
+	registry.UpdateDescriptor("OptSource", func(d *registry.EntityDescriptor) {
+		d.References = []registry.ReferenceDescriptor{
+			{RefType: "link", TargetKind: "RefTarget", OnTargetDelete: policy},
+		}
+	})
+	t.Cleanup(func() {
+		registry.UpdateDescriptor("OptSource", func(d *registry.EntityDescriptor) {
+			d.References = []registry.ReferenceDescriptor{
+				{RefType: "link", TargetKind: "RefTarget", Min: 0, Max: 0},
+			}
+		})
+	})
+}
+
+func TestResourceReferences_OnTargetDelete(t *testing.T) {
+	suffix := func(base string) string { return fmt.Sprintf("%s-%s", base, uuid.NewString()[:8]) }
+
+	// createLinked creates a RefTarget and an OptSource linking to it.
+	createLinked := func(t *testing.T, svc services.ResourceService) (*api.Resource, *api.Resource) {
+		target, svcErr := svc.Create(t.Context(), "RefTarget", newRefTestResource("RefTarget", suffix("target")), nil)
+		Expect(svcErr).To(BeNil())
+		refs := makeRefs("link", struct{ id, kind string }{target.ID, "RefTarget"})
+		source, svcErr := svc.Create(t.Context(), "OptSource",
+			newRefTestResource("OptSource", suffix("source")), refs)
+		Expect(svcErr).To(BeNil())
+		return target, source
+	}
+
+	t.Run("Detach_DropsReferenceAndBumpsGeneration", func(t *testing.T) {
+		RegisterTestingT(t)
+		svc, _ := setupRefTest(t)
+		setOptSourceTargetDelete(t, registry.OnTargetDeleteDetach)
+
+		target, source := createLinked(t, svc)
+
+		_, svcErr := svc.Delete(t.Context(), "RefTarget", target.ID)
+		Expect(svcErr).To(BeNil(), "delete should detach the referencer")
+
+		detached, svcErr := svc.Get(t.Context(), "OptSource", source.ID)
+		Expect(svcErr).To(BeNil())
+		Expect(detached.DeletedTime).To(BeNil())
+		Expect(detached.References).To(BeEmpty())
+		Expect(detached.Generation).To(Equal(source.Generation + 1))
+	})
+
+	t.Run("Detach_AppliesToForceDelete", func(t *testing.T) {
+		RegisterTestingT(t)
+		svc, h := setupRefTest(t)
+		setOptSourceTargetDelete(t, registry.OnTargetDeleteDetach)
+
+		target, source := createLinked(t, svc)
+		markFinalizing(t, h, target.ID)
+
+		svcErr := svc.ForceDelete(t.Context(), "RefTarget", target.ID, "stuck")
+		Expect(svcErr).To(BeNil())
+
+		detached, svcErr := svc.Get(t.Context(), "OptSource", source.ID)
+		Expect(svcErr).To(BeNil())
+		Expect(detached.References).To(BeEmpty())
+	})
+
+	t.Run("Cascade_DeletesReferencer", func(t *testing.T) {
+		RegisterTestingT(t)
+		svc, h := setupRefTest(t)
+		setOptSourceTargetDelete(t, registry.OnTargetDeleteCascade)
+
+		target, source := createLinked(t, svc)
+
+		_, svcErr := svc.Delete(t.Context(), "RefTarget", target.ID)
+		Expect(svcErr).To(BeNil(), "delete should cascade to the referencer")
+
+		dbErr := checkResourceCount(t.Context(), h, []string{target.ID, source.ID}, 0)
+		Expect(dbErr).To(BeNil(), "target and referencer should both be hard-deleted")
+	})
+}
+
 // --- List with ref_type filter ---
 
 func TestResourceReferences_ListByRefTypeAndTarget(t *testing.T) {
@@ -570,3 +650,34 @@ func TestResourceReferences_CreateRefToSoftDeletedTarget(t *testing.T) {
 	Expect(svcErr.HTTPCode).To(Equal(400))
 	Expect(svcErr.Reason).To(ContainSubstring("marked for deletion"))
 }
+
+// --- Referencers and graph ---
+
+func TestResourceReferences_ReferencersAndGraph(t *testing.T) {
+	RegisterTestingT(t)
+	svc, _ := setupRefTest(t)
+
+	targetName := fmt.Sprintf("target-%s", uuid.NewString()[:8])
+	target, svcErr := svc.Create(t.Context(), "RefTarget", newRefTestResource("RefTarget", targetName), nil)
+	Expect(svcErr).To(BeNil())
+
+	sourceName := fmt.Sprintf("source-%s", uuid.NewString()[:8])
+	refs := makeRefs("dep", struct{ id, kind string }{target.ID, "RefTarget"})
+	source, svcErr := svc.Create(t.Context(), "RefSource", newRefTestResource("RefSource", sourceName), refs)
+	Expect(svcErr).To(BeNil())
+
+	referencers, svcErr := svc.ListReferencers(t.Context(), "RefTarget", target.ID)
+	Expect(svcErr).To(BeNil())
+	Expect(referencers).To(HaveLen(1))
+	Expect(referencers[0].ID).To(Equal(source.ID))
+	Expect(referencers[0].Name).To(Equal(sourceName))
+	Expect(referencers[0].RefType).To(Equal("dep"))
+	Expect(referencers[0].Href).To(Equal(source.Href))
+
+	graph, svcErr := svc.Graph(t.Context(), target.ID, 1)
+	Expect(svcErr).To(BeNil())
+	Expect(graph.Nodes).To(HaveLen(2))
+	Expect(graph.Edges).To(ConsistOf(api.GraphEdge{
+		Type: api.GraphEdgeReferences, SourceID: source.ID, TargetID: target.ID, RefType: "dep",
+	}))
+}
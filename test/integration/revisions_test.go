@@ -0,0 +1,79 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api/presenters"
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+)
+
+// TestRevisionsAndRollback verifies that every generation's spec and labels are kept as
+// a revision, and that rolling back re-applies one as a new generation.
+func TestRevisionsAndRollback(t *testing.T) {
+	RegisterTestingT(t)
+	h, _ := test.RegisterIntegration(t)
+	svc := h.Container.ResourceService()
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	token := test.GetAccessTokenFromContext(ctx)
+	request := func() *resty.Request {
+		return resty.R().
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	channel := createChannel(t, svc, fmt.Sprintf("rev-%s", uuid.NewString()[:8]))
+	_, svcErr := svc.Patch(t.Context(), "Channel", channel.ID, &api.ResourcePatch{
+		Spec:   map[string]interface{}{"is_default": true, "enabled_regex": "4\\.17\\..*"},
+		Labels: map[string]string{"env": "prod"},
+	})
+	Expect(svcErr).To(BeNil())
+
+	resp, err := request().Get(h.RestURL(fmt.Sprintf("/channels/%s/revisions", channel.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK), string(resp.Body()))
+	var list presenters.ResourceRevisionList
+	Expect(json.Unmarshal(resp.Body(), &list)).To(Succeed())
+	Expect(list.Total).To(HaveValue(Equal(int64(2))))
+	Expect(list.Items[0].Generation).To(Equal(int32(2)))
+	Expect(list.Items[0].Labels).To(MatchJSON(`{"env":"prod"}`))
+
+	resp, err = request().Get(h.RestURL(fmt.Sprintf("/channels/%s/revisions/1", channel.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK), string(resp.Body()))
+	var first presenters.ResourceRevision
+	Expect(json.Unmarshal(resp.Body(), &first)).To(Succeed())
+	Expect(first.Spec).To(MatchJSON(channel.Spec))
+
+	resp, err = request().
+		SetBody(`{"generation": 1}`).
+		Post(h.RestURL(fmt.Sprintf("/channels/%s/rollback", channel.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK), string(resp.Body()))
+
+	rolledBack, svcErr := svc.Get(t.Context(), "Channel", channel.ID)
+	Expect(svcErr).To(BeNil())
+	Expect(rolledBack.Generation).To(Equal(int32(3)))
+	Expect([]byte(rolledBack.Spec)).To(MatchJSON(channel.Spec))
+	Expect(rolledBack.Labels).To(BeEmpty())
+
+	// The rollback is itself a revision.
+	revision, svcErr := svc.GetRevision(t.Context(), "Channel", channel.ID, 3)
+	Expect(svcErr).To(BeNil())
+	Expect([]byte(revision.Spec)).To(MatchJSON(channel.Spec))
+
+	resp, err = request().
+		SetBody(`{"generation": 9}`).
+		Post(h.RestURL(fmt.Sprintf("/channels/%s/rollback", channel.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusNotFound))
+}
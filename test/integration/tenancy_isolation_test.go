@@ -0,0 +1,133 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/db"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/tenant"
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+)
+
+// TestResourceReadsAndWritesAreTenantScoped verifies that a tenant cannot see or mutate
+// another tenant's resources: foreign rows are reported as not found on every path, while
+// the system identity still sees everything.
+func TestResourceReadsAndWritesAreTenantScoped(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+	svc := h.Container.ResourceService()
+	sf := h.Container.SessionFactory()
+
+	ctxAcme := tenancyCtx(map[string]string{tenancyOrgKey: "acme"})
+	ctxGlobex := tenancyCtx(map[string]string{tenancyOrgKey: "globex"})
+
+	acme, svcErr := createInTx(ctxAcme, sf, svc, newTenancyCluster("acme-prod"))
+	Expect(svcErr).To(BeNil())
+	_, svcErr = createInTx(ctxGlobex, sf, svc, newTenancyCluster("globex-prod"))
+	Expect(svcErr).To(BeNil())
+
+	// The owning tenant can read its own cluster.
+	_, svcErr = svc.Get(ctxAcme, tenancyClusterKind, acme.ID)
+	Expect(svcErr).To(BeNil())
+
+	// Foreign reads are 404, not 403, so IDs don't leak across tenants.
+	_, svcErr = svc.Get(ctxGlobex, tenancyClusterKind, acme.ID)
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(http.StatusNotFound))
+
+	_, svcErr = svc.GetByID(ctxGlobex, acme.ID)
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(http.StatusNotFound))
+
+	// Lists and their totals only include the caller's rows.
+	list, paging, svcErr := svc.List(ctxGlobex, tenancyClusterKind, services.NewListArguments())
+	Expect(svcErr).To(BeNil())
+	Expect(list).To(HaveLen(1))
+	Expect(list[0].Name).To(Equal("globex-prod"))
+	Expect(paging.Total).To(Equal(int64(1)))
+
+	all, _, svcErr := svc.ListAll(ctxGlobex, services.NewListArguments())
+	Expect(svcErr).To(BeNil())
+	for _, r := range all {
+		Expect(r.ID).NotTo(Equal(acme.ID))
+	}
+
+	// Foreign mutations are 404 as well.
+	txCtx, err := db.NewContext(ctxGlobex, sf)
+	Expect(err).NotTo(HaveOccurred())
+	_, svcErr = svc.Patch(txCtx, tenancyClusterKind, acme.ID, &api.ResourcePatch{
+		Labels: map[string]string{"owner": "globex"},
+	})
+	db.Resolve(txCtx)
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(http.StatusNotFound))
+
+	svcErr = deleteInTx(ctxGlobex, sf, svc, acme.ID)
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(http.StatusNotFound))
+
+	// A tenant without dimensions sees nothing.
+	ctxEmpty := tenancyCtx(map[string]string{})
+	_, svcErr = svc.Get(ctxEmpty, tenancyClusterKind, acme.ID)
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HTTPCode).To(Equal(http.StatusNotFound))
+
+	// The system identity is unscoped.
+	ctxSystem := tenant.WithTenant(ctxAcme, &tenant.ResolvedTenant{System: true})
+	_, svcErr = svc.Get(ctxSystem, tenancyClusterKind, acme.ID)
+	Expect(svcErr).To(BeNil())
+	list, _, svcErr = svc.List(ctxSystem, tenancyClusterKind, services.NewListArguments())
+	Expect(svcErr).To(BeNil())
+	Expect(list).To(HaveLen(2))
+}
+
+// TestGraphIsTenantScoped verifies that a resource graph leaves out children that belong
+// to another tenant, even when they are owned by one of the caller's resources.
+func TestGraphIsTenantScoped(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+	svc := h.Container.ResourceService()
+	sf := h.Container.SessionFactory()
+
+	ctxAcme := tenancyCtx(map[string]string{tenancyOrgKey: "acme"})
+
+	cluster, svcErr := createInTx(ctxAcme, sf, svc, newTenancyCluster("acme-graph"))
+	Expect(svcErr).To(BeNil())
+
+	newNodePool := func(name string) *api.Resource {
+		txCtx, err := db.NewContext(ctxAcme, sf)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Resolve(txCtx)
+		ownerKind := tenancyClusterKind
+		nodePool, createErr := svc.Create(txCtx, "NodePool", &api.Resource{
+			Name:      name,
+			OwnerID:   &cluster.ID,
+			OwnerKind: &ownerKind,
+			Spec:      []byte(`{"machine_type": "n1-standard-4", "replicas": 3}`),
+			CreatedBy: tenancyTestActor,
+			UpdatedBy: tenancyTestActor,
+		}, nil)
+		Expect(createErr).To(BeNil())
+		return nodePool
+	}
+	own := newNodePool("acme-pool")
+	foreign := newNodePool("globex-pool")
+
+	// Re-stamp one child with another tenant's tenancy.
+	Expect(sf.New(context.Background()).Model(&api.Resource{}).Where("id = ?", foreign.ID).
+		Update("tenancy", `{"org": "globex"}`).Error).To(Succeed())
+
+	graph, svcErr := svc.Graph(ctxAcme, cluster.ID, 1)
+	Expect(svcErr).To(BeNil())
+	ids := make([]string, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		ids = append(ids, node.ID)
+	}
+	Expect(ids).To(ConsistOf(cluster.ID, own.ID))
+	for _, edge := range graph.Edges {
+		Expect(edge.TargetID).NotTo(Equal(foreign.ID))
+	}
+}
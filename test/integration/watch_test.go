@@ -0,0 +1,57 @@
+package integration
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/api"
+	"github.com/openshift-hyperfleet/hyperfleet-api/pkg/services"
+	"github.com/openshift-hyperfleet/hyperfleet-api/test"
+)
+
+// TestWatchPollReturnsCommittedChanges verifies that resource writes land in the change
+// log in commit order, carry the presented resource, and are only served to the tenant
+// that owns them.
+func TestWatchPollReturnsCommittedChanges(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+	svc := h.Container.ResourceService()
+	watch := h.Container.WatchService()
+	sf := h.Container.SessionFactory()
+
+	ctxAcme := tenancyCtx(map[string]string{tenancyOrgKey: "acme"})
+	ctxGlobex := tenancyCtx(map[string]string{tenancyOrgKey: "globex"})
+
+	start, svcErr := watch.Head(ctxAcme)
+	Expect(svcErr).To(BeNil())
+
+	acme, svcErr := createInTx(ctxAcme, sf, svc, newTenancyCluster("acme-watch"))
+	Expect(svcErr).To(BeNil())
+	_, svcErr = createInTx(ctxGlobex, sf, svc, newTenancyCluster("globex-watch"))
+	Expect(svcErr).To(BeNil())
+	Expect(deleteInTx(ctxAcme, sf, svc, acme.ID)).To(BeNil())
+
+	filter := services.WatchFilter{Kind: tenancyClusterKind}
+	changes, next, svcErr := watch.Poll(ctxAcme, filter, start)
+	Expect(svcErr).To(BeNil())
+	Expect(changes).To(HaveLen(2))
+	Expect(changes[0].Type).To(Equal(api.ChangeAdded))
+	Expect(changes[1].Type).To(Equal(api.ChangeModified))
+	for _, change := range changes {
+		Expect(change.ResourceID).To(Equal(acme.ID))
+	}
+
+	var object map[string]any
+	Expect(json.Unmarshal(changes[1].Object, &object)).To(Succeed())
+	Expect(object["id"]).To(Equal(acme.ID))
+	Expect(object["deleted_time"]).NotTo(BeNil())
+
+	// Resuming from the returned position yields nothing new.
+	changes, _, svcErr = watch.Poll(ctxAcme, filter, next)
+	Expect(svcErr).To(BeNil())
+	Expect(changes).To(BeEmpty())
+
+	// The start position is still retained.
+	Expect(watch.CheckPosition(ctxAcme, start)).To(BeNil())
+}